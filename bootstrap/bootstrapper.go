@@ -11,6 +11,8 @@ const (
 	NodeObjRegistry         string = "NodeObjRegistry"
 	// BootstrappedNFTService is the key to NFT Service in bootstrap context.
 	BootstrappedNFTService = "BootstrappedNFTService"
+	// BootstrappedPendingDocumentSweeper is the key to the sweeper of untouched pending documents.
+	BootstrappedPendingDocumentSweeper = "BootstrappedPendingDocumentSweeper"
 )
 
 // Bootstrapper must be implemented by all packages that needs bootstrapping at application start
@@ -13,6 +13,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents/entity"
 	"github.com/centrifuge/go-centrifuge/documents/entityrelationship"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/documents/invoice"
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/extensions/funding"
 	"github.com/centrifuge/go-centrifuge/extensions/transferdetails"
@@ -22,10 +23,13 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/schemas"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/templates"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	logging "github.com/ipfs/go-log"
 )
@@ -43,9 +47,13 @@ var bootstrappers = []bootstrap.TestBootstrapper{
 	&ideth.Bootstrapper{},
 	&configstore.Bootstrapper{},
 	anchors.Bootstrapper{},
+	notification.Bootstrapper{},
 	documents.Bootstrapper{},
 	&entityrelationship.Bootstrapper{},
+	schemas.Bootstrapper{},
+	templates.Bootstrapper{},
 	generic.Bootstrapper{},
+	invoice.Bootstrapper{},
 	&nft.Bootstrapper{},
 	p2p.Bootstrapper{},
 	documents.PostBootstrapper{},
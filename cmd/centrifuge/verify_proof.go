@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/cmd"
+	"github.com/centrifuge/go-centrifuge/documents/verifier"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+
+	//specific param
+	var proofFileParam string
+	var anchorParam bool
+
+	var verifyProofCmd = &cobra.Command{
+		Use:   "verifyproof",
+		Short: "verify the field proofs of a document",
+		Long:  "recomputes the document root from a proofs JSON file and optionally checks it against the anchor on chain using the node config",
+		Run: func(c *cobra.Command, args []string) {
+			data, err := ioutil.ReadFile(proofFileParam)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var resp coreapi.ProofsResponse
+			err = json.Unmarshal(data, &resp)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			p := verifier.DocumentProof{
+				DocumentID:     resp.Header.DocumentID,
+				VersionID:      resp.Header.VersionID,
+				FieldProofs:    resp.FieldProofs,
+				LeftDataRoot:   resp.LeftDataRoot,
+				RightDataRoot:  resp.RightDataRoot,
+				SigningRoot:    resp.SigningRoot,
+				SignaturesRoot: resp.SignaturesRoot,
+			}
+
+			var docRoot []byte
+			if anchorParam {
+				ctx, canc, _ := cmd.CommandBootstrap(ensureConfigFile())
+				anchorSrv := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+				docRoot, err = verifier.VerifyAnchored(p, anchorSrv)
+				canc()
+			} else {
+				docRoot, err = verifier.Verify(p)
+			}
+
+			if err != nil {
+				log.Error(err)
+				fmt.Println(false)
+				return
+			}
+
+			log.Infof("document root: %s", hexutil.Encode(docRoot))
+			fmt.Println(true)
+		},
+	}
+
+	rootCmd.AddCommand(verifyProofCmd)
+	verifyProofCmd.Flags().StringVarP(&proofFileParam, "proof", "p", "", "proofs JSON file as returned by the proofs API")
+	verifyProofCmd.Flags().BoolVarP(&anchorParam, "anchor", "a", false, "check the document root against the anchor on chain")
+}
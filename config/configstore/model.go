@@ -62,6 +62,8 @@ type NodeConfig struct {
 	CentChainIntervalRetry         time.Duration
 	CentChainMaxRetries            int
 	CentChainAnchorLifespan        time.Duration
+	PendingDocumentTTL             time.Duration
+	PendingDocumentSweepInterval   time.Duration
 }
 
 // IsSet refer the interface
@@ -264,6 +266,16 @@ func (nc *NodeConfig) GetCentChainAnchorLifespan() time.Duration {
 	return nc.CentChainAnchorLifespan
 }
 
+// GetPendingDocumentTTL returns the duration after which untouched pending documents are discarded.
+func (nc *NodeConfig) GetPendingDocumentTTL() time.Duration {
+	return nc.PendingDocumentTTL
+}
+
+// GetPendingDocumentSweepInterval returns the interval at which the pending documents are checked for expiry.
+func (nc *NodeConfig) GetPendingDocumentSweepInterval() time.Duration {
+	return nc.PendingDocumentSweepInterval
+}
+
 // GetEthereumDefaultAccountName refer the interface
 func (nc *NodeConfig) GetEthereumDefaultAccountName() string {
 	return nc.MainIdentity.EthereumDefaultAccountName
@@ -385,6 +397,8 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		CentChainIntervalRetry:         c.GetCentChainIntervalRetry(),
 		CentChainAnchorLifespan:        c.GetCentChainAnchorLifespan(),
 		CentChainNodeURL:               c.GetCentChainNodeURL(),
+		PendingDocumentTTL:             c.GetPendingDocumentTTL(),
+		PendingDocumentSweepInterval:   c.GetPendingDocumentSweepInterval(),
 	}
 }
 
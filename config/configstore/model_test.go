@@ -273,6 +273,16 @@ func (m *mockConfig) GetCentChainAnchorLifespan() time.Duration {
 	return args.Get(0).(time.Duration)
 }
 
+func (m *mockConfig) GetPendingDocumentTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetPendingDocumentSweepInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (m *mockConfig) GetCentChainNodeURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -338,5 +348,7 @@ func createMockConfig() *mockConfig {
 	c.On("GetCentChainAnchorLifespan").Return(time.Second).Once()
 	c.On("GetCentChainMaxRetries").Return(1).Once()
 	c.On("GetCentChainNodeURL").Return("dummyNode").Once()
+	c.On("GetPendingDocumentTTL").Return(time.Hour).Once()
+	c.On("GetPendingDocumentSweepInterval").Return(time.Minute).Once()
 	return c
 }
@@ -158,6 +158,10 @@ type Configuration interface {
 	GetCentChainMaxRetries() int
 	GetCentChainNodeURL() string
 	GetCentChainAnchorLifespan() time.Duration
+
+	// Pending document specific details.
+	GetPendingDocumentTTL() time.Duration
+	GetPendingDocumentSweepInterval() time.Duration
 }
 
 // Account exposes account options
@@ -454,6 +458,16 @@ func (c *configuration) GetCentChainAnchorLifespan() time.Duration {
 	return c.GetDuration("centChain.anchorLifespan")
 }
 
+// GetPendingDocumentTTL returns the duration after which untouched pending documents are discarded.
+func (c *configuration) GetPendingDocumentTTL() time.Duration {
+	return c.GetDuration("pending.ttl")
+}
+
+// GetPendingDocumentSweepInterval returns the interval at which the pending documents are checked for expiry.
+func (c *configuration) GetPendingDocumentSweepInterval() time.Duration {
+	return c.GetDuration("pending.sweepInterval")
+}
+
 // GetNetworkString returns defined network the node is connected to.
 func (c *configuration) GetNetworkString() string {
 	return c.GetString("centrifugeNetwork")
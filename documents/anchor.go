@@ -23,71 +23,118 @@ type AnchorProcessor interface {
 // updaterFunc is a wrapper that will be called to save the state of the model between processor steps
 type updaterFunc func(id []byte, model Model) error
 
+// stageFunc is a wrapper that will be called to record the stage of the anchoring completed for the model
+type stageFunc func(model Model, stage AnchorStage) error
+
 // AnchorDocument add signature, requests signatures, anchors document, and sends the anchored document
 // to collaborators
 func AnchorDocument(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, preAnchor bool) (Model, error) {
+	return ResumeAnchorDocument(ctx, model, proc, updater, func(Model, AnchorStage) error {
+		return nil
+	}, preAnchor, AnchorStageNone)
+}
+
+// ResumeAnchorDocument anchors the document skipping the stages already completed.
+// Each completed stage is recorded once the model is saved so that a failed anchoring can be resumed
+// without re-signing, re-requesting signatures, or pre-committing the model again.
+func ResumeAnchorDocument(
+	ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, recorder stageFunc,
+	preAnchor bool, stage AnchorStage) (Model, error) {
 	id := model.CurrentVersion()
-	err := proc.PrepareForSignatureRequests(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
-	}
+	if !stage.Reached(AnchorStagePrepared) {
+		err := proc.PrepareForSignatureRequests(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
+		}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, err
+		err = updater(id, model)
+		if err != nil {
+			return nil, err
+		}
+
+		err = recorder(model, AnchorStagePrepared)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 	}
 
-	if preAnchor {
-		err = proc.PreAnchorDocument(ctx, model)
+	if preAnchor && !stage.Reached(AnchorStagePrecommitted) {
+		err := proc.PreAnchorDocument(ctx, model)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	err = proc.RequestSignatures(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+		err = recorder(model, AnchorStagePrecommitted)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
-	}
+	if !stage.Reached(AnchorStageSigned) {
+		err := proc.RequestSignatures(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+		}
 
-	err = proc.PrepareForAnchoring(model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
-	}
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, err
+		err = recorder(model, AnchorStageSigned)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 	}
 
-	// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
-	err = proc.AnchorDocument(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
-	}
+	if !stage.Reached(AnchorStageAnchored) {
+		err := proc.PrepareForAnchoring(model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
+		}
 
-	// set the status to committed
-	if err = model.SetStatus(Committed); err != nil {
-		return nil, err
-	}
+		err = updater(id, model)
+		if err != nil {
+			return nil, err
+		}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
-	}
+		// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
+		err = proc.AnchorDocument(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
+		}
+
+		// set the status to committed
+		if err = model.SetStatus(Committed); err != nil {
+			return nil, err
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 
-	err = proc.SendDocument(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to send anchored document: %v", err))
+		err = recorder(model, AnchorStageAnchored)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+	if !stage.Reached(AnchorStageSent) {
+		err := proc.SendDocument(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to send anchored document: %v", err))
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		err = recorder(model, AnchorStageSent)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
 	}
 
 	return model, nil
@@ -0,0 +1,156 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+)
+
+// AnchorStage is the last stage of the anchoring completed for a document version.
+type AnchorStage string
+
+const (
+	// AnchorStageNone is the stage of a version that is yet to be prepared.
+	AnchorStageNone AnchorStage = ""
+
+	// AnchorStagePrepared is the stage of a version signed by the account.
+	AnchorStagePrepared AnchorStage = "prepared"
+
+	// AnchorStagePrecommitted is the stage of a version whose signing root is pre-committed.
+	AnchorStagePrecommitted AnchorStage = "precommitted"
+
+	// AnchorStageSigned is the stage of a version signed by the collaborators.
+	AnchorStageSigned AnchorStage = "signed"
+
+	// AnchorStageAnchored is the stage of a version whose document root is anchored.
+	AnchorStageAnchored AnchorStage = "anchored"
+
+	// AnchorStageSent is the stage of a version sent to the collaborators.
+	AnchorStageSent AnchorStage = "sent"
+)
+
+// anchorStages holds the stages in the order they are completed.
+var anchorStages = []AnchorStage{
+	AnchorStageNone,
+	AnchorStagePrepared,
+	AnchorStagePrecommitted,
+	AnchorStageSigned,
+	AnchorStageAnchored,
+	AnchorStageSent,
+}
+
+// Reached returns true if the stage is completed once the given stage is.
+func (s AnchorStage) Reached(stage AnchorStage) bool {
+	for _, st := range anchorStages {
+		if st == stage {
+			return true
+		}
+
+		if st == s {
+			return false
+		}
+	}
+
+	return false
+}
+
+// AnchorState records the last stage of the anchoring completed for a document version.
+// The intermediate state of the version is persisted along with the version itself.
+type AnchorState struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	Stage      AnchorStage        `json:"stage"`
+
+	// JobID is the job that completed the stage.
+	JobID     string    `json:"job_id"`
+	UpdatedAt time.Time `json:"updated_at" swaggertype:"primitive,string"`
+}
+
+// NewAnchorState returns the anchor state of the version at the stage completed within the job.
+func NewAnchorState(model Model, stage AnchorStage, jobID jobs.JobID) *AnchorState {
+	return &AnchorState{
+		DocumentID: model.ID(),
+		VersionID:  model.CurrentVersion(),
+		Stage:      stage,
+		JobID:      jobID.String(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+// JSON marshals AnchorState to json bytes.
+func (a *AnchorState) JSON() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// Type returns the type of AnchorState.
+func (a *AnchorState) Type() reflect.Type {
+	return reflect.TypeOf(a)
+}
+
+// FromJSON loads json bytes to AnchorState.
+func (a *AnchorState) FromJSON(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// checkRetryable returns ErrCommitNotRetryable if the anchoring of the latest version cannot be resumed.
+// Anchoring can be resumed if the version is not sent yet and the job that last anchored it is not running.
+// Without an anchor state, the job anchoring the version is unknown, so the anchoring is not resumed.
+func checkRetryable(jobMan jobs.Manager, accountID identity.DID, st *AnchorState) error {
+	if st == nil {
+		return errors.NewTypedError(ErrCommitNotRetryable, errors.New("no anchor job is recorded for the version"))
+	}
+
+	if st.Stage.Reached(AnchorStageSent) {
+		return errors.NewTypedError(ErrCommitNotRetryable, errors.New("document is already anchored and sent"))
+	}
+
+	jobID, err := jobs.FromString(st.JobID)
+	if err != nil {
+		return nil
+	}
+
+	job, err := jobMan.GetJob(accountID, jobID)
+	if err == nil && job.Status == jobs.Pending {
+		return errors.NewTypedError(ErrCommitNotRetryable, errors.New("anchor job %s is in progress", st.JobID))
+	}
+
+	return nil
+}
+
+// RetryCommit resumes the anchoring of the latest version of the document from the last stage completed.
+func (s service) RetryCommit(ctx context.Context, documentID []byte) (Model, jobs.JobID, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, jobs.NilJobID(), ErrDocumentConfigAccountID
+	}
+
+	model, err := s.repo.GetLatest(did[:], documentID)
+	if err != nil {
+		return nil, jobs.NilJobID(), errors.NewTypedError(ErrDocumentNotFound, err)
+	}
+
+	st, err := s.repo.GetAnchorState(did[:], documentID, model.CurrentVersion())
+	if err != nil && !errors.IsOfType(ErrAnchorStateNotFound, err) {
+		return nil, jobs.NilJobID(), err
+	}
+
+	err = checkRetryable(s.jobManager, did, st)
+	if err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
+	jobID := contextutil.Job(ctx)
+	jobID, _, err = CreateAnchorJob(ctx, s.jobManager, s.queueSrv, did, jobID, model.CurrentVersion())
+	if err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
+	return model, jobID, nil
+}
@@ -0,0 +1,170 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnchorStage_Reached(t *testing.T) {
+	assert.True(t, AnchorStageNone.Reached(AnchorStageNone))
+	assert.False(t, AnchorStageNone.Reached(AnchorStagePrepared))
+	assert.True(t, AnchorStageSigned.Reached(AnchorStagePrepared))
+	assert.True(t, AnchorStageSigned.Reached(AnchorStagePrecommitted))
+	assert.True(t, AnchorStageSigned.Reached(AnchorStageSigned))
+	assert.False(t, AnchorStageSigned.Reached(AnchorStageAnchored))
+	assert.True(t, AnchorStageSent.Reached(AnchorStageAnchored))
+	assert.False(t, AnchorStage("unknown").Reached(AnchorStagePrepared))
+}
+
+func TestRepo_AnchorState(t *testing.T) {
+	repo := getRepository(ctx)
+	accountID, docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing state
+	_, err := repo.GetAnchorState(accountID, docID, versionID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAnchorStateNotFound, err))
+
+	// store
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	m.On("CurrentVersion").Return(versionID)
+	jobID := jobs.NewJobID()
+	assert.NoError(t, repo.StoreAnchorState(accountID, NewAnchorState(m, AnchorStagePrepared, jobID)))
+	st, err := repo.GetAnchorState(accountID, docID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, AnchorStagePrepared, st.Stage)
+	assert.Equal(t, jobID.String(), st.JobID)
+
+	// overwrite
+	assert.NoError(t, repo.StoreAnchorState(accountID, NewAnchorState(m, AnchorStageSigned, jobID)))
+	st, err = repo.GetAnchorState(accountID, docID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, AnchorStageSigned, st.Stage)
+}
+
+func TestDocumentAnchorTask_AnchorStage(t *testing.T) {
+	accountID := did
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	m.On("CurrentVersion").Return(versionID)
+	task := &documentAnchorTask{accountID: accountID}
+	task.JobID = jobs.NewJobID()
+
+	// no state store
+	stage, err := task.getAnchorStage(m)
+	assert.NoError(t, err)
+	assert.Equal(t, AnchorStageNone, stage)
+	assert.NoError(t, task.saveAnchorStage(m, AnchorStagePrepared))
+
+	// missing state
+	repo := new(MockRepository)
+	task.anchorStateGetFunc = repo.GetAnchorState
+	task.anchorStateSaveFunc = repo.StoreAnchorState
+	repo.On("GetAnchorState", accountID[:], docID, versionID).Return(nil, ErrAnchorStateNotFound).Once()
+	stage, err = task.getAnchorStage(m)
+	assert.NoError(t, err)
+	assert.Equal(t, AnchorStageNone, stage)
+
+	// failed to get state
+	repo.On("GetAnchorState", accountID[:], docID, versionID).Return(nil, errors.New("failed")).Once()
+	_, err = task.getAnchorStage(m)
+	assert.Error(t, err)
+
+	// success
+	st := NewAnchorState(m, AnchorStageSigned, task.JobID)
+	repo.On("GetAnchorState", accountID[:], docID, versionID).Return(st, nil).Once()
+	stage, err = task.getAnchorStage(m)
+	assert.NoError(t, err)
+	assert.Equal(t, AnchorStageSigned, stage)
+
+	repo.On("StoreAnchorState", accountID[:], mock.MatchedBy(func(st *AnchorState) bool {
+		return st.Stage == AnchorStageAnchored && st.JobID == task.JobID.String()
+	})).Return(nil).Once()
+	assert.NoError(t, task.saveAnchorStage(m, AnchorStageAnchored))
+	repo.AssertExpectations(t)
+}
+
+func TestService_RetryCommit(t *testing.T) {
+	repo := new(MockRepository)
+	jobMan := new(testingjobs.MockJobManager)
+	s := service{repo: repo, jobManager: jobMan}
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing account
+	_, _, err := s.RetryCommit(context.Background(), docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// missing document
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	self, err := contextutil.AccountDID(ctxh)
+	assert.NoError(t, err)
+	repo.On("GetLatest", self[:], docID).Return(nil, errors.New("not found")).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+
+	// without anchor state
+	m := new(MockModel)
+	m.On("ID").Return(docID)
+	m.On("CurrentVersion").Return(versionID)
+	repo.On("GetLatest", self[:], docID).Return(m, nil)
+	repo.On("GetAnchorState", self[:], docID, versionID).Return(nil, ErrAnchorStateNotFound).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrCommitNotRetryable, err))
+
+	// anchoring not started yet
+	jobID := jobs.NewJobID()
+	repo.On("GetAnchorState", self[:], docID, versionID).Return(NewAnchorState(m, AnchorStageNone, jobID), nil).Once()
+	jobMan.On("GetJob", self, jobID).Return(jobs.NewJob(self, "anchor document"), nil).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrCommitNotRetryable, err))
+
+	// already sent
+	repo.On("GetAnchorState", self[:], docID, versionID).Return(NewAnchorState(m, AnchorStageSent, jobID), nil).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrCommitNotRetryable, err))
+
+	// anchor job in progress
+	job := jobs.NewJob(self, "anchor document")
+	repo.On("GetAnchorState", self[:], docID, versionID).Return(NewAnchorState(m, AnchorStageSigned, jobID), nil).Once()
+	jobMan.On("GetJob", self, jobID).Return(job, nil).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrCommitNotRetryable, err))
+
+	// failed to create job
+	job.Status = jobs.Failed
+	repo.On("GetAnchorState", self[:], docID, versionID).Return(NewAnchorState(m, AnchorStageSigned, jobID), nil)
+	jobMan.On("GetJob", self, jobID).Return(job, nil)
+	jobMan.On("ExecuteWithinJob", mock.Anything, self, jobs.NilJobID(), mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), errors.New("failed")).Once()
+	_, _, err = s.RetryCommit(ctxh, docID)
+	assert.Error(t, err)
+
+	// success
+	newJobID := jobs.NewJobID()
+	jobMan.On("ExecuteWithinJob", mock.Anything, self, jobs.NilJobID(), mock.Anything, mock.Anything).Return(newJobID, make(chan error), nil).Once()
+	doc, gjobID, err := s.RetryCommit(ctxh, docID)
+	assert.NoError(t, err)
+	assert.Equal(t, m, doc)
+	assert.Equal(t, newJobID, gjobID)
+	repo.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+	m.AssertExpectations(t)
+}
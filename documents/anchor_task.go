@@ -3,12 +3,14 @@ package documents
 import (
 	"context"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/gocelery"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -38,6 +40,12 @@ type documentAnchorTask struct {
 	processor     AnchorProcessor
 	modelGetFunc  func(tenantID, id []byte) (Model, error)
 	modelSaveFunc func(tenantID, id []byte, model Model) error
+	anchorSrv     anchors.Service
+	forkSaveFunc  func(tenantID []byte, fork *Fork) error
+	events        notification.EventBus
+
+	anchorStateGetFunc  func(tenantID, docID, versionID []byte) (*AnchorState, error)
+	anchorStateSaveFunc func(tenantID []byte, st *AnchorState) error
 }
 
 // TaskTypeName returns the name of the task.
@@ -82,6 +90,12 @@ func (d *documentAnchorTask) Copy() (gocelery.CeleryTask, error) {
 		processor:     d.processor,
 		modelGetFunc:  d.modelGetFunc,
 		modelSaveFunc: d.modelSaveFunc,
+		anchorSrv:     d.anchorSrv,
+		forkSaveFunc:  d.forkSaveFunc,
+		events:        d.events,
+
+		anchorStateGetFunc:  d.anchorStateGetFunc,
+		anchorStateSaveFunc: d.anchorStateSaveFunc,
 	}, nil
 }
 
@@ -108,15 +122,98 @@ func (d *documentAnchorTask) RunTask() (res interface{}, err error) {
 		return false, errors.New("failed to get model: %v", err)
 	}
 
-	if _, err = AnchorDocument(ctxh, model, d.processor, func(id []byte, model Model) error {
+	stage, err := d.getAnchorStage(model)
+	if err != nil {
+		return false, errors.New("failed to get anchor state: %v", err)
+	}
+
+	// record the job before the first stage so that the anchoring can be retried if the job fails
+	if stage == AnchorStageNone {
+		err = d.saveAnchorStage(model, AnchorStageNone)
+		if err != nil {
+			return false, errors.New("failed to save anchor state: %v", err)
+		}
+	}
+
+	if _, err = ResumeAnchorDocument(ctxh, model, d.processor, func(id []byte, model Model) error {
 		return d.modelSaveFunc(d.accountID[:], id, model)
-	}, tc.GetPrecommitEnabled()); err != nil {
+	}, d.saveAnchorStage, tc.GetPrecommitEnabled(), stage); err != nil {
+		if ferr := d.recordFork(model); ferr != nil {
+			return false, ferr
+		}
+
 		return false, errors.New("failed to anchor document: %v", err)
 	}
 
+	d.publishCommitted(model)
 	return true, nil
 }
 
+// getAnchorStage returns the last stage of the anchoring completed for the model by the previous jobs.
+func (d *documentAnchorTask) getAnchorStage(model Model) (AnchorStage, error) {
+	if d.anchorStateGetFunc == nil {
+		return AnchorStageNone, nil
+	}
+
+	st, err := d.anchorStateGetFunc(d.accountID[:], model.ID(), model.CurrentVersion())
+	if err != nil {
+		if errors.IsOfType(ErrAnchorStateNotFound, err) {
+			return AnchorStageNone, nil
+		}
+
+		return AnchorStageNone, err
+	}
+
+	if st.Stage != AnchorStageNone {
+		log.Infof("resuming anchoring of version %x from stage %s completed by job %s\n", st.VersionID, st.Stage, st.JobID)
+	}
+
+	return st.Stage, nil
+}
+
+// saveAnchorStage records the stage of the anchoring completed for the model by the job.
+func (d *documentAnchorTask) saveAnchorStage(model Model, stage AnchorStage) error {
+	if d.anchorStateSaveFunc == nil {
+		return nil
+	}
+
+	return d.anchorStateSaveFunc(d.accountID[:], NewAnchorState(model, stage, d.JobID))
+}
+
+// publishCommitted publishes the anchored version along with the NFTs added since the previous version.
+func (d *documentAnchorTask) publishCommitted(model Model) {
+	var old Model
+	if prev := model.PreviousVersion(); len(prev) > 0 {
+		old, _ = d.modelGetFunc(d.accountID[:], prev)
+	}
+
+	publishVersion(d.events, notification.VersionCommitted, d.accountID, nil, old, model)
+}
+
+// recordFork records the model if its version is anchored by another collaborator.
+// Returns ErrDocumentForked if the model is forked.
+func (d *documentAnchorTask) recordFork(model Model) error {
+	if d.anchorSrv == nil || d.forkSaveFunc == nil {
+		return nil
+	}
+
+	fork, err := DetectFork(d.anchorSrv, model)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+
+	if fork == nil {
+		return nil
+	}
+
+	if err := d.forkSaveFunc(d.accountID[:], fork); err != nil {
+		log.Error(err)
+	}
+
+	return errors.NewTypedError(ErrDocumentForked, errors.New("version %x of document %x", fork.VersionID, fork.DocumentID))
+}
+
 // initDocumentAnchorTask enqueues a new document anchor task for a given combination of accountID/modelID/txID.
 func initDocumentAnchorTask(jobMan jobs.Manager, tq queue.TaskQueuer, accountID identity.DID, modelID []byte, jobID jobs.JobID) (queue.TaskResult, error) {
 	params := map[string]interface{}{
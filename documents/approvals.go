@@ -0,0 +1,320 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// approvalLabelPrefix is the label prefix of the attributes holding the approval policy and the approvals.
+	approvalLabelPrefix = "_approval_"
+
+	// approvalPolicyLabel is the label of the attribute holding the approval policy.
+	approvalPolicyLabel = approvalLabelPrefix + "policy"
+
+	// approverLabelPrefix is the label prefix of the signed attributes holding the approvals.
+	approverLabelPrefix = approvalLabelPrefix + "by_"
+)
+
+// ApprovalPolicy requires Threshold members of the role to approve a pending version before it can be committed.
+type ApprovalPolicy struct {
+	RoleKey   byteutils.HexBytes `json:"role_key" swaggertype:"primitive,string"`
+	Threshold int                `json:"threshold"`
+}
+
+// ApprovalReceiver handles the approval requests and the approvals received from the collaborators.
+type ApprovalReceiver interface {
+	// ReceiveApprovalRequest stores the pending version of the owner awaiting the approval of the account.
+	ReceiveApprovalRequest(ctx context.Context, model Model, owner identity.DID) error
+
+	// ReceiveApproval adds the approval, of the approver, in the model to the pending version of the account.
+	ReceiveApproval(ctx context.Context, model Model, approver identity.DID) error
+}
+
+// ApprovalStatus holds the approvals of the pending version.
+type ApprovalStatus struct {
+	Policy    ApprovalPolicy
+	Approvers []identity.DID
+}
+
+// Approved returns true if the approval threshold is met.
+func (s ApprovalStatus) Approved() bool {
+	return len(s.Approvers) >= s.Policy.Threshold
+}
+
+// validate checks that the role exists in the model and has enough collaborators to meet the threshold.
+func (p ApprovalPolicy) validate(model Model) error {
+	role, err := model.GetRole(p.RoleKey)
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidApprovalPolicy, err)
+	}
+
+	if p.Threshold < 1 || p.Threshold > len(role.Collaborators) {
+		return errors.NewTypedError(ErrInvalidApprovalPolicy,
+			errors.New("threshold must be between 1 and %d", len(role.Collaborators)))
+	}
+
+	return nil
+}
+
+// approverLabel returns the label of the approval attribute of the approver.
+func approverLabel(approver identity.DID) string {
+	return approverLabelPrefix + approver.String()
+}
+
+// SetApprovalPolicy sets the approval policy of the pending version.
+// Changing the policy changes the approval root, so the existing approvals of the version are no longer counted.
+func SetApprovalPolicy(model Model, p ApprovalPolicy) error {
+	if err := p.validate(model); err != nil {
+		return err
+	}
+
+	d, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	attr, err := NewStringAttribute(approvalPolicyLabel, AttrString, string(d))
+	if err != nil {
+		return err
+	}
+
+	return model.AddAttributes(CollaboratorsAccess{}, false, attr)
+}
+
+// GetApprovalPolicy returns the approval policy of the model.
+// ok is false if the model doesn't require approvals.
+func GetApprovalPolicy(model Model) (p ApprovalPolicy, ok bool, err error) {
+	key, err := AttrKeyFromLabel(approvalPolicyLabel)
+	if err != nil {
+		return p, false, err
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return p, false, nil
+	}
+
+	err = json.Unmarshal([]byte(attr.Value.Str), &p)
+	if err != nil {
+		return p, false, errors.NewTypedError(ErrInvalidApprovalPolicy, err)
+	}
+
+	return p, true, nil
+}
+
+// IsApprover returns true if the account is an active member of the approval role at t.
+func IsApprover(model Model, p ApprovalPolicy, account identity.DID, t time.Time) bool {
+	role, err := model.GetRole(p.RoleKey)
+	if err != nil {
+		return false
+	}
+
+	if _, found := isDIDInRole(role, account); !found {
+		return false
+	}
+
+	rv, _, err := model.RoleValidity(p.RoleKey)
+	return err == nil && rv.ActiveAt(t)
+}
+
+// ApprovalRoot returns the signing root of the model excluding the approvals.
+// Approvals are bound to this root so that adding an approval doesn't invalidate the others.
+// The approvals are removed from a copy of the model, so the model is not modified.
+func ApprovalRoot(model Model) ([]byte, error) {
+	var keys []AttrKey
+	for _, attr := range model.GetAttributes() {
+		if strings.HasPrefix(attr.KeyLabel, approverLabelPrefix) {
+			keys = append(keys, attr.Key)
+		}
+	}
+
+	if len(keys) < 1 {
+		return model.CalculateSigningRoot()
+	}
+
+	c, err := copyOf(model)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if err := c.DeleteAttribute(key, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.CalculateSigningRoot()
+}
+
+// copyOf returns a deep copy of the model unpacked from its core document.
+func copyOf(model Model) (Model, error) {
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	tp := model.Type()
+	if tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+
+	c, ok := reflect.New(tp).Interface().(Model)
+	if !ok {
+		return nil, errors.New("%s is not a model", tp)
+	}
+
+	return c, c.UnpackCoreDocument(*proto.Clone(&cd).(*coredocumentpb.CoreDocument))
+}
+
+// NewApproval returns the signed attribute approving the current version of the model by the approver.
+func NewApproval(model Model, approver identity.DID, account config.Account) (attr Attribute, err error) {
+	root, err := ApprovalRoot(model)
+	if err != nil {
+		return attr, err
+	}
+
+	// current version is used since the version is not anchored yet
+	return NewSignedAttribute(approverLabel(approver), approver, account, model.ID(), model.CurrentVersion(), root)
+}
+
+// GetApproval returns the approval of the approver in the model.
+func GetApproval(model Model, approver identity.DID) (Attribute, error) {
+	key, err := AttrKeyFromLabel(approverLabel(approver))
+	if err != nil {
+		return Attribute{}, err
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return Attribute{}, errors.NewTypedError(ErrInvalidApproval, errors.New("missing approval of %s", approver.String()))
+	}
+
+	return attr, nil
+}
+
+// ValidateApproval validates the signature of the approval of the document against the signing key of the approver at t.
+func ValidateApproval(idSrv identity.Service, docID []byte, approval Attribute, t time.Time) error {
+	if approval.Value.Type != AttrSigned {
+		return ErrInvalidApproval
+	}
+
+	signed := approval.Value.Signed
+	payload := attributeSignaturePayload(signed.Identity[:], docID, signed.DocumentVersion, signed.Value)
+	err := idSrv.ValidateSignature(signed.Identity, signed.PublicKey, signed.Signature, payload, t)
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidApproval, errors.New("failed to validate the approval of %s: %v", signed.Identity.String(), err))
+	}
+
+	return nil
+}
+
+// AddApproval adds the approval to the model if it approves the current version of the model
+// and is of an active member of the approval role.
+// Note: signature of the approval is validated along with the other signed attributes when the document is committed.
+func AddApproval(model Model, approval Attribute) error {
+	p, ok, err := GetApprovalPolicy(model)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrApprovalPolicyMissing
+	}
+
+	root, err := ApprovalRoot(model)
+	if err != nil {
+		return err
+	}
+
+	if !isApproval(model, model, p, root, approval, time.Now().UTC()) {
+		return ErrInvalidApproval
+	}
+
+	return model.AddAttributes(CollaboratorsAccess{}, false, approval)
+}
+
+// isApproval returns true if the attribute is a signed approval of the current version of the model, bound to root,
+// by an active member, at t, of the approval role in roles.
+func isApproval(roles, model Model, p ApprovalPolicy, root []byte, attr Attribute, t time.Time) bool {
+	if !strings.HasPrefix(attr.KeyLabel, approverLabelPrefix) || attr.Value.Type != AttrSigned {
+		return false
+	}
+
+	signed := attr.Value.Signed
+	return attr.KeyLabel == approverLabel(signed.Identity) &&
+		bytes.Equal(signed.DocumentVersion, model.CurrentVersion()) &&
+		bytes.Equal(signed.Value, root) &&
+		IsApprover(roles, p, signed.Identity, t)
+}
+
+// GetApprovalStatus returns the approvers of the current version of the model that are active members of the approval role.
+// Approvals of earlier versions or with a different approval root are ignored.
+// Note: signatures of the approvals are validated along with the other signed attributes when the document is committed.
+func GetApprovalStatus(model Model, p ApprovalPolicy) (status ApprovalStatus, err error) {
+	return approvalStatus(model, model, p)
+}
+
+// approvalStatus returns the approvers of the current version of the model that are active members of the approval role in roles.
+func approvalStatus(roles, model Model, p ApprovalPolicy) (status ApprovalStatus, err error) {
+	status.Policy = p
+	root, err := ApprovalRoot(model)
+	if err != nil {
+		return status, err
+	}
+
+	now := time.Now().UTC()
+	for _, attr := range model.GetAttributes() {
+		if !isApproval(roles, model, p, root, attr, now) {
+			continue
+		}
+
+		status.Approvers = append(status.Approvers, attr.Value.Signed.Identity)
+	}
+
+	return status, nil
+}
+
+// ValidateApprovals returns an error if the model requires approvals and the threshold is not met.
+// The approval policy of the previous version, old, is enforced as well, along with its approval role,
+// so that the policy cannot be dropped or changed without the approval of its approvers.
+// old is nil if the model is the first version of the document.
+func ValidateApprovals(old, model Model) error {
+	err := validateApprovals(model, model)
+	if err != nil || old == nil {
+		return err
+	}
+
+	return validateApprovals(old, model)
+}
+
+// validateApprovals checks the approvals of the model against the approval policy and role of policyModel.
+func validateApprovals(policyModel, model Model) error {
+	p, ok, err := GetApprovalPolicy(policyModel)
+	if err != nil || !ok {
+		return err
+	}
+
+	status, err := approvalStatus(policyModel, model, p)
+	if err != nil {
+		return err
+	}
+
+	if !status.Approved() {
+		return errors.NewTypedError(ErrApprovalThreshold,
+			errors.New("%d of %d required approvals", len(status.Approvers), p.Threshold))
+	}
+
+	return nil
+}
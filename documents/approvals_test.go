@@ -0,0 +1,298 @@
+// +build unit
+
+package documents
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// coreDocModel is a model backed only by the core document.
+type coreDocModel struct {
+	Model
+	cd *CoreDocument
+}
+
+func (m *coreDocModel) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func (m *coreDocModel) PackCoreDocument() (coredocumentpb.CoreDocument, error) {
+	return m.cd.PackCoreDocument(nil), nil
+}
+
+func (m *coreDocModel) UnpackCoreDocument(cd coredocumentpb.CoreDocument) (err error) {
+	m.cd, err = NewCoreDocumentFromProtobuf(cd)
+	return err
+}
+
+func (m *coreDocModel) ID() []byte {
+	return m.cd.ID()
+}
+
+func (m *coreDocModel) CurrentVersion() []byte {
+	return m.cd.CurrentVersion()
+}
+
+func (m *coreDocModel) GetRole(roleID []byte) (*coredocumentpb.Role, error) {
+	return m.cd.GetRole(roleID)
+}
+
+func (m *coreDocModel) RoleValidity(roleID []byte) (role, read Validity, err error) {
+	return m.cd.RoleValidity(roleID)
+}
+
+func (m *coreDocModel) GetAttributes() []Attribute {
+	return m.cd.GetAttributes()
+}
+
+func (m *coreDocModel) GetAttribute(key AttrKey) (Attribute, error) {
+	return m.cd.GetAttribute(key)
+}
+
+func (m *coreDocModel) AddAttributes(ca CollaboratorsAccess, prepareNewVersion bool, attrs ...Attribute) error {
+	cd, err := m.cd.AddAttributes(ca, prepareNewVersion, nil, attrs...)
+	if err != nil {
+		return err
+	}
+
+	m.cd = cd
+	return nil
+}
+
+func (m *coreDocModel) DeleteAttribute(key AttrKey, prepareNewVersion bool) error {
+	cd, err := m.cd.DeleteAttribute(key, prepareNewVersion, nil)
+	if err != nil {
+		return err
+	}
+
+	m.cd = cd
+	return nil
+}
+
+func (m *coreDocModel) CalculateSigningRoot() ([]byte, error) {
+	tree, err := m.cd.DefaultTreeWithPrefix("invoice", []byte{1, 0, 0, 0})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cd.CalculateSigningRoot(documenttypes.InvoiceDataTypeUrl, tree.GetLeaves())
+}
+
+func TestSetApprovalPolicy(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: cd}
+
+	// missing policy
+	_, ok, err := GetApprovalPolicy(m)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, ValidateApprovals(nil, m))
+
+	// missing role
+	err = SetApprovalPolicy(m, ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApprovalPolicy, err))
+
+	// threshold above the role members
+	role, err := cd.AddRole("approvers", []identity.DID{testingidentity.GenerateRandomDID()})
+	assert.NoError(t, err)
+	err = SetApprovalPolicy(m, ApprovalPolicy{RoleKey: role.RoleKey, Threshold: 2})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApprovalPolicy, err))
+
+	// success
+	p := ApprovalPolicy{RoleKey: role.RoleKey, Threshold: 1}
+	assert.NoError(t, SetApprovalPolicy(m, p))
+	gp, ok, err := GetApprovalPolicy(m)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, p, gp)
+	for _, attr := range m.GetAttributes() {
+		assert.True(t, IsReservedAttribute(attr.KeyLabel))
+	}
+
+	err = ValidateApprovals(nil, m)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrApprovalThreshold, err))
+}
+
+func TestNewApproval(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	self, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: cd}
+	other := testingidentity.GenerateRandomDID()
+	role, err := cd.AddRole("approvers", []identity.DID{self, other})
+	assert.NoError(t, err)
+	p := ApprovalPolicy{RoleKey: role.RoleKey, Threshold: 1}
+	assert.NoError(t, SetApprovalPolicy(m, p))
+	assert.True(t, IsApprover(m, p, self, time.Now().UTC()))
+	assert.False(t, IsApprover(m, p, testingidentity.GenerateRandomDID(), time.Now().UTC()))
+
+	// approval by a non member is ignored
+	stranger := testingidentity.GenerateRandomDID()
+	attr, err := NewApproval(m, stranger, acc)
+	assert.NoError(t, err)
+	assert.NoError(t, m.AddAttributes(CollaboratorsAccess{}, false, attr))
+	assert.Error(t, ValidateApprovals(nil, m))
+
+	// approval of the member
+	root, err := ApprovalRoot(m)
+	assert.NoError(t, err)
+	attr, err = NewApproval(m, self, acc)
+	assert.NoError(t, err)
+	assert.Equal(t, root, attr.Value.Signed.Value)
+	assert.Equal(t, m.CurrentVersion(), attr.Value.Signed.DocumentVersion)
+	assert.NoError(t, m.AddAttributes(CollaboratorsAccess{}, false, attr))
+	st, err := GetApprovalStatus(m, p)
+	assert.NoError(t, err)
+	assert.Equal(t, []identity.DID{self}, st.Approvers)
+	assert.True(t, st.Approved())
+	assert.NoError(t, ValidateApprovals(nil, m))
+
+	// approvals don't change the approval root, and the model is not modified to calculate it
+	attrs := m.GetAttributes()
+	nroot, err := ApprovalRoot(m)
+	assert.NoError(t, err)
+	assert.Equal(t, root, nroot)
+	assert.ElementsMatch(t, attrs, m.GetAttributes())
+
+	// changes to the document discard the approvals
+	label, err := NewStringAttribute("status", AttrString, "approved")
+	assert.NoError(t, err)
+	assert.NoError(t, m.AddAttributes(CollaboratorsAccess{}, false, label))
+	st, err = GetApprovalStatus(m, p)
+	assert.NoError(t, err)
+	assert.Len(t, st.Approvers, 0)
+	assert.False(t, st.Approved())
+
+	// expired approvers cannot approve
+	attr, err = NewApproval(m, self, acc)
+	assert.NoError(t, err)
+	assert.NoError(t, m.AddAttributes(CollaboratorsAccess{}, false, attr))
+	assert.NoError(t, ValidateApprovals(nil, m))
+	past := time.Now().UTC().Add(-time.Hour)
+	assert.NoError(t, cd.SetRoleValidity(role.RoleKey, Validity{NotAfter: &past}))
+	assert.False(t, IsApprover(m, p, self, time.Now().UTC()))
+	err = ValidateApprovals(nil, m)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrApprovalThreshold, err))
+}
+
+func TestAddApproval(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	self, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: cd}
+	role, err := cd.AddRole("approvers", []identity.DID{self})
+	assert.NoError(t, err)
+	attr, err := NewApproval(m, self, acc)
+	assert.NoError(t, err)
+
+	// missing policy
+	err = AddApproval(m, attr)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrApprovalPolicyMissing, err))
+
+	// approval of another root
+	assert.NoError(t, SetApprovalPolicy(m, ApprovalPolicy{RoleKey: role.RoleKey, Threshold: 1}))
+	err = AddApproval(m, attr)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApproval, err))
+
+	// approval by a non member
+	attr, err = NewApproval(m, testingidentity.GenerateRandomDID(), acc)
+	assert.NoError(t, err)
+	err = AddApproval(m, attr)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApproval, err))
+
+	// success
+	attr, err = NewApproval(m, self, acc)
+	assert.NoError(t, err)
+	assert.NoError(t, AddApproval(m, attr))
+	gattr, err := GetApproval(m, self)
+	assert.NoError(t, err)
+	assert.Equal(t, attr, gattr)
+	assert.NoError(t, ValidateApprovals(nil, m))
+
+	// missing approval
+	_, err = GetApproval(m, testingidentity.GenerateRandomDID())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApproval, err))
+}
+
+func TestValidateApproval(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	self, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: cd}
+	attr, err := NewApproval(m, self, acc)
+	assert.NoError(t, err)
+	signed := attr.Value.Signed
+	payload := attributeSignaturePayload(self[:], m.ID(), signed.DocumentVersion, signed.Value)
+	tm := time.Now().UTC()
+
+	// not a signed attribute
+	label, err := NewStringAttribute("status", AttrString, "approved")
+	assert.NoError(t, err)
+	err = ValidateApproval(nil, m.ID(), label, tm)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApproval, err))
+
+	// invalid signature
+	idSrv := new(testingcommons.MockIdentityService)
+	idSrv.On("ValidateSignature", self, signed.PublicKey, signed.Signature, payload, tm).Return(errors.New("key not linked to identity")).Once()
+	err = ValidateApproval(idSrv, m.ID(), attr, tm)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidApproval, err))
+
+	// success
+	idSrv.On("ValidateSignature", self, signed.PublicKey, signed.Signature, payload, tm).Return(nil).Once()
+	assert.NoError(t, ValidateApproval(idSrv, m.ID(), attr, tm))
+	idSrv.AssertExpectations(t)
+}
+
+func TestValidateApprovals_PreviousVersion(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	old := &coreDocModel{cd: cd}
+	role, err := cd.AddRole("approvers", []identity.DID{testingidentity.GenerateRandomDID()})
+	assert.NoError(t, err)
+	assert.NoError(t, SetApprovalPolicy(old, ApprovalPolicy{RoleKey: role.RoleKey, Threshold: 1}))
+
+	// policy dropped from the new version
+	ncd, err := newCoreDocument()
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: ncd}
+	assert.NoError(t, ValidateApprovals(nil, m))
+	err = ValidateApprovals(old, m)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrApprovalThreshold, err))
+}
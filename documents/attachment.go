@@ -0,0 +1,214 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// MaxAttachmentSize is the maximum size in bytes of the content of an attachment.
+	MaxAttachmentSize = 32 << 20
+
+	// attachmentLabelPrefix is the label prefix of the attributes referencing the attachments.
+	attachmentLabelPrefix = "_attachment_"
+
+	// attachmentPrefix holds the prefix of the attachment content in the blob store.
+	attachmentPrefix = "attachment_"
+)
+
+// Attachment references a file attached to the document.
+// Content of the attachment is stored off the document and is addressed by its sha256 hash.
+type Attachment struct {
+	Name     string             `json:"name"`
+	MIMEType string             `json:"mime_type"`
+	Size     int64              `json:"size"`
+	Hash     byteutils.HexBytes `json:"hash" swaggertype:"primitive,string"`
+}
+
+// NewAttachment returns the attachment for the content.
+func NewAttachment(name, mimeType string, content []byte) (Attachment, error) {
+	hash, err := crypto.Sha256Hash(content)
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	att := Attachment{
+		Name:     name,
+		MIMEType: mimeType,
+		Size:     int64(len(content)),
+		Hash:     hash,
+	}
+
+	return att, att.Validate()
+}
+
+// Validate checks if the attachment is well formed.
+func (a Attachment) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.NewTypedError(ErrInvalidAttachment, errors.New("empty name"))
+	}
+
+	if len(a.Hash) != 32 {
+		return errors.NewTypedError(ErrInvalidAttachment, errors.New("hash must be 32 bytes"))
+	}
+
+	if a.Size < 0 || a.Size > MaxAttachmentSize {
+		return errors.NewTypedError(ErrInvalidAttachment, errors.New("size must be between 0 and %d bytes", MaxAttachmentSize))
+	}
+
+	return nil
+}
+
+// Verify returns an error if the content doesn't match the attachment.
+func (a Attachment) Verify(content []byte) error {
+	if int64(len(content)) != a.Size {
+		return errors.NewTypedError(ErrInvalidAttachment, errors.New("content size mismatch"))
+	}
+
+	hash, err := crypto.Sha256Hash(content)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash, a.Hash) {
+		return errors.NewTypedError(ErrInvalidAttachment, errors.New("content hash mismatch"))
+	}
+
+	return nil
+}
+
+// String returns the json representation of the attachment.
+func (a Attachment) String() string {
+	d, err := json.Marshal(a)
+	if err != nil {
+		return ""
+	}
+
+	return string(d)
+}
+
+// AttachmentLabel returns the attribute label of the attachment with the name.
+func AttachmentLabel(name string) string {
+	return attachmentLabelPrefix + name
+}
+
+// isAttachmentLabel returns true if the attribute label references an attachment.
+func isAttachmentLabel(label string) bool {
+	return strings.HasPrefix(label, attachmentLabelPrefix)
+}
+
+// NewAttachmentAttribute returns the attribute referencing the attachment.
+// Attachments with the same name replace each other.
+func NewAttachmentAttribute(att Attachment) (attr Attribute, err error) {
+	if err := att.Validate(); err != nil {
+		return attr, err
+	}
+
+	label := AttachmentLabel(att.Name)
+	key, err := AttrKeyFromLabel(label)
+	if err != nil {
+		return attr, err
+	}
+
+	return Attribute{
+		KeyLabel: label,
+		Key:      key,
+		Value: AttrVal{
+			Type:       AttrAttachment,
+			Attachment: att,
+		},
+	}, nil
+}
+
+// GetAttachments returns the attachments of the model.
+func GetAttachments(model Model) (atts []Attachment) {
+	for _, attr := range model.GetAttributes() {
+		if attr.Value.Type == AttrAttachment {
+			atts = append(atts, attr.Value.Attachment)
+		}
+	}
+
+	return atts
+}
+
+// FindAttachment returns the attachment of the model with the content hash.
+func FindAttachment(model Model, hash []byte) (Attachment, error) {
+	for _, att := range GetAttachments(model) {
+		if bytes.Equal(att.Hash, hash) {
+			return att, nil
+		}
+	}
+
+	return Attachment{}, ErrAttachmentNotFound
+}
+
+// AttachmentRepository stores the content of the attachments.
+// Content is addressed by its sha256 hash.
+type AttachmentRepository interface {
+	// Exists returns true if the content with the hash is present in the DB.
+	Exists(hash []byte) bool
+
+	// Get returns the content with the hash.
+	Get(hash []byte) ([]byte, error)
+
+	// Create stores the content and returns its hash.
+	// Storing existing content is a no-op.
+	Create(content []byte) ([]byte, error)
+}
+
+// NewAttachmentRepository returns an implementation of the AttachmentRepository.
+// Content is stored as raw bytes in the blob store.
+func NewAttachmentRepository(blobs storage.BlobStore) AttachmentRepository {
+	return attachmentRepo{blobs: blobs}
+}
+
+type attachmentRepo struct {
+	blobs storage.BlobStore
+}
+
+// getAttachmentKey returns attachment_+hash
+func getAttachmentKey(hash []byte) []byte {
+	return []byte(attachmentPrefix + hexutil.Encode(hash))
+}
+
+// Exists returns true if the content with the hash is present in the DB.
+func (r attachmentRepo) Exists(hash []byte) bool {
+	return r.blobs.Exists(getAttachmentKey(hash))
+}
+
+// Get returns the content with the hash.
+func (r attachmentRepo) Get(hash []byte) ([]byte, error) {
+	content, err := r.blobs.Get(getAttachmentKey(hash))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrAttachmentNotFound, err)
+	}
+
+	return content, nil
+}
+
+// Create stores the content and returns its hash.
+// Storing existing content is a no-op.
+func (r attachmentRepo) Create(content []byte) ([]byte, error) {
+	if len(content) > MaxAttachmentSize {
+		return nil, errors.NewTypedError(ErrInvalidAttachment, errors.New("content exceeds %d bytes", MaxAttachmentSize))
+	}
+
+	hash, err := crypto.Sha256Hash(content)
+	if err != nil {
+		return nil, err
+	}
+
+	key := getAttachmentKey(hash)
+	if r.blobs.Exists(key) {
+		return hash, nil
+	}
+
+	return hash, r.blobs.Put(key, content)
+}
@@ -0,0 +1,104 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAttachment(t *testing.T) {
+	content := utils.RandomSlice(64)
+
+	// empty name
+	_, err := NewAttachment(" ", "application/pdf", content)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidAttachment, err))
+
+	// too large
+	_, err = NewAttachment("invoice.pdf", "application/pdf", make([]byte, MaxAttachmentSize+1))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidAttachment, err))
+
+	// success
+	att, err := NewAttachment("invoice.pdf", "application/pdf", content)
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice.pdf", att.Name)
+	assert.Equal(t, "application/pdf", att.MIMEType)
+	assert.Equal(t, int64(len(content)), att.Size)
+	assert.Len(t, att.Hash, 32)
+	assert.NoError(t, att.Verify(content))
+
+	// size mismatch
+	err = att.Verify(content[1:])
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidAttachment, err))
+
+	// hash mismatch
+	err = att.Verify(utils.RandomSlice(64))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidAttachment, err))
+}
+
+func TestAttachmentAttribute_protocolRoundTrip(t *testing.T) {
+	att, err := NewAttachment("invoice.pdf", "application/pdf", utils.RandomSlice(64))
+	assert.NoError(t, err)
+
+	// invalid attachment
+	_, err = NewAttachmentAttribute(Attachment{Name: "invoice.pdf"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidAttachment, err))
+
+	attr, err := NewAttachmentAttribute(att)
+	assert.NoError(t, err)
+	assert.Equal(t, AttachmentLabel(att.Name), attr.KeyLabel)
+	assert.True(t, IsReservedAttribute(attr.KeyLabel))
+
+	pattrs, err := toProtocolAttributes(map[AttrKey]Attribute{attr.Key: attr})
+	assert.NoError(t, err)
+	assert.Len(t, pattrs, 1)
+
+	attrs, err := fromProtocolAttributes(pattrs)
+	assert.NoError(t, err)
+	assert.Equal(t, attr, attrs[attr.Key])
+
+	model := new(MockModel)
+	model.On("GetAttributes").Return([]Attribute{attr})
+	assert.Equal(t, []Attachment{att}, GetAttachments(model))
+	fatt, err := FindAttachment(model, att.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, att, fatt)
+	_, err = FindAttachment(model, utils.RandomSlice(32))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAttachmentNotFound, err))
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	repo := ctx[BootstrappedAttachmentRepository].(AttachmentRepository)
+	content := utils.RandomSlice(64)
+	att, err := NewAttachment("invoice.pdf", "application/pdf", content)
+	assert.NoError(t, err)
+
+	// missing content
+	assert.False(t, repo.Exists(att.Hash))
+	_, err = repo.Get(att.Hash)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAttachmentNotFound, err))
+
+	// create
+	hash, err := repo.Create(content)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(att.Hash), hash)
+	assert.True(t, repo.Exists(hash))
+
+	// create again is a no-op
+	_, err = repo.Create(content)
+	assert.NoError(t, err)
+
+	gcontent, err := repo.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, content, gcontent)
+}
@@ -52,6 +52,9 @@ const (
 	// AttrMonetary is the monetary attribute type
 	AttrMonetary AttributeType = "monetary"
 
+	// AttrAttachment is the file attachment attribute type
+	AttrAttachment AttributeType = "attachment"
+
 	// MonetaryToken is the monetary type for tokens
 	MonetaryToken MonetaryType = "token"
 )
@@ -59,7 +62,7 @@ const (
 // isAttrTypeAllowed checks if the given attribute type is implemented and returns its `reflect.Type` if allowed.
 func isAttrTypeAllowed(attr AttributeType) bool {
 	switch attr {
-	case AttrInt256, AttrDecimal, AttrString, AttrBytes, AttrTimestamp, AttrSigned, AttrMonetary:
+	case AttrInt256, AttrDecimal, AttrString, AttrBytes, AttrTimestamp, AttrSigned, AttrMonetary, AttrAttachment:
 		return true
 	default:
 		return false
@@ -83,6 +86,32 @@ func AttrKeyFromLabel(label string) (attrKey AttrKey, err error) {
 	return AttrKeyFromBytes(hashedKey)
 }
 
+// IsReservedAttribute returns true if the attribute label is used to store document metadata
+// such as value constraints, validity windows of roles, approvals, attachments, and the signature policy.
+func IsReservedAttribute(label string) bool {
+	for _, prefix := range []string{
+		constraintLabelPrefix, roleValidityLabelPrefix, readValidityLabelPrefix, approvalLabelPrefix, attachmentLabelPrefix,
+		signaturePolicyLabel} {
+		if strings.HasPrefix(label, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateUserAttributes returns ErrReservedAttribute if any of the attributes uses a reserved label.
+// Reserved attributes are only set through the APIs managing the metadata they hold.
+func ValidateUserAttributes(attrs map[AttrKey]Attribute) error {
+	for _, attr := range attrs {
+		if IsReservedAttribute(attr.KeyLabel) {
+			return errors.NewTypedError(ErrReservedAttribute, errors.New("%s", attr.KeyLabel))
+		}
+	}
+
+	return nil
+}
+
 // AttrKeyFromBytes converts bytes to AttrKey
 func AttrKeyFromBytes(b []byte) (AttrKey, error) {
 	return utils.SliceToByte32(b)
@@ -143,14 +172,15 @@ func (m Monetary) String() string {
 
 // AttrVal represents a strongly typed value of an attribute
 type AttrVal struct {
-	Type      AttributeType
-	Int256    *Int256
-	Decimal   *Decimal
-	Str       string
-	Bytes     []byte
-	Timestamp *timestamp.Timestamp
-	Signed    Signed
-	Monetary  Monetary
+	Type       AttributeType
+	Int256     *Int256
+	Decimal    *Decimal
+	Str        string
+	Bytes      []byte
+	Timestamp  *timestamp.Timestamp
+	Signed     Signed
+	Monetary   Monetary
+	Attachment Attachment
 }
 
 // AttrValFromString converts the string value to necessary type based on the attribute type.
@@ -205,6 +235,8 @@ func (attrVal AttrVal) String() (str string, err error) {
 		str = attrVal.Signed.String()
 	case AttrMonetary:
 		str = attrVal.Monetary.String()
+	case AttrAttachment:
+		str = attrVal.Attachment.String()
 	}
 
 	return str, err
@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
@@ -24,6 +25,9 @@ const (
 
 	// BootstrappedAnchorProcessor is the key to bootstrapped anchor processor
 	BootstrappedAnchorProcessor = "BootstrappedAnchorProcessor"
+
+	// BootstrappedAttachmentRepository is the key to the database repository of attachment contents
+	BootstrappedAttachmentRepository = "BootstrappedAttachmentRepository"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -64,9 +68,20 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("transaction service not initialised")
 	}
 
-	ctx[BootstrappedDocumentService] = DefaultService(cfg, repo, anchorSrv, registry, didService, queueSrv, jobManager)
+	blobs, ok := ctx[storage.BootstrappedBlobDB].(storage.BlobStore)
+	if !ok {
+		return errors.New("blob store not initialised")
+	}
+
+	events, ok := ctx[notification.BootstrappedEventBus].(notification.EventBus)
+	if !ok {
+		return errors.New("event bus not initialised")
+	}
+
+	ctx[BootstrappedDocumentService] = DefaultService(cfg, repo, anchorSrv, registry, didService, queueSrv, jobManager, events)
 	ctx[BootstrappedRegistry] = registry
 	ctx[BootstrappedDocumentRepository] = repo
+	ctx[BootstrappedAttachmentRepository] = NewAttachmentRepository(blobs)
 	return nil
 }
 
@@ -110,10 +125,15 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("identity service not initialized")
 	}
 
-	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg)
-	ctx[BootstrappedAnchorProcessor] = dp
+	events, ok := ctx[notification.BootstrappedEventBus].(notification.EventBus)
+	if !ok {
+		return errors.New("event bus not initialised")
+	}
 
 	jobManager := ctx[jobs.BootstrappedService].(jobs.Manager)
+	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg, jobManager, events)
+	ctx[BootstrappedAnchorProcessor] = dp
+
 	anchorTask := &documentAnchorTask{
 		BaseTask: jobsv1.BaseTask{
 			JobManager: jobManager,
@@ -122,6 +142,12 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		processor:     dp,
 		modelGetFunc:  repo.Get,
 		modelSaveFunc: repo.Update,
+		anchorSrv:     anchorSrv,
+		forkSaveFunc:  repo.StoreFork,
+		events:        events,
+
+		anchorStateGetFunc:  repo.GetAnchorState,
+		anchorStateSaveFunc: repo.StoreAnchorState,
 	}
 
 	queueSrv.RegisterTaskType(documentAnchorTaskName, anchorTask)
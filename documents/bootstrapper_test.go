@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
@@ -28,11 +29,13 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	repo := leveldb.NewLevelDBRepository(db)
 	ctx[bootstrap.BootstrappedConfig] = &testingconfig.MockConfig{}
 	ctx[storage.BootstrappedDB] = repo
+	ctx[storage.BootstrappedBlobDB] = leveldb.NewLevelDBBlobStore(db)
 	ctx[jobs.BootstrappedService] = jobsv1.NewManager(&testingconfig.MockConfig{}, jobsv1.NewRepository(repo))
 	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
 	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
 	ctx[jobs.BootstrappedService] = new(testingjobs.MockJobManager)
 	ctx[bootstrap.BootstrappedQueueServer] = new(queue.Server)
+	ctx[notification.BootstrappedEventBus] = notification.NewEventBus()
 
 	err = Bootstrapper{}.Bootstrap(ctx)
 	assert.Nil(t, err)
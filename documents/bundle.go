@@ -0,0 +1,402 @@
+package documents
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// BundleFormatVersion is the version of the bundle format produced by the node.
+	BundleFormatVersion = 1
+
+	// BundleDocumentFile is the name of the bundle entry holding the packed core document.
+	BundleDocumentFile = "document.pb"
+
+	// BundleManifestFile is the name of the bundle entry holding the manifest.
+	BundleManifestFile = "manifest.json"
+
+	// MaxBundleSize is the maximum size of a bundle and of each of its uncompressed entries.
+	MaxBundleSize = 16 << 20
+)
+
+// BundleSigner holds a signature of the document along with the validity of the signer key at the document timestamp.
+// Signatures don't record the time they were made at, so the keys are validated at the document timestamp.
+type BundleSigner struct {
+	SignerID            identity.DID       `json:"signer_id" swaggertype:"primitive,string"`
+	SignatureID         byteutils.HexBytes `json:"signature_id" swaggertype:"primitive,string"`
+	PublicKey           byteutils.HexBytes `json:"public_key" swaggertype:"primitive,string"`
+	Signature           byteutils.HexBytes `json:"signature" swaggertype:"primitive,string"`
+	TransitionValidated bool               `json:"transition_validated"`
+
+	// DocumentTimestamp is the timestamp of the document version, not the time of the signature.
+	DocumentTimestamp time.Time `json:"document_timestamp" swaggertype:"primitive,string"`
+
+	// KeyValidAtTimestamp is true if the key had the signing purpose and was not revoked at the document timestamp.
+	KeyValidAtTimestamp bool `json:"key_valid_at_document_timestamp"`
+
+	// KeyRevokedAtBlock is the block the key was revoked at. Zero if the key is not revoked.
+	KeyRevokedAtBlock uint32 `json:"key_revoked_at_block,omitempty"`
+}
+
+// BundleAnchor holds the anchor of the document and the chain it was anchored on.
+type BundleAnchor struct {
+	AnchorID         byteutils.HexBytes `json:"anchor_id" swaggertype:"primitive,string"`
+	DocumentRoot     byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	SigningRoot      byteutils.HexBytes `json:"signing_root" swaggertype:"primitive,string"`
+	SignaturesRoot   byteutils.HexBytes `json:"signatures_root" swaggertype:"primitive,string"`
+	AnchoredAt       time.Time          `json:"anchored_at" swaggertype:"primitive,string"`
+	NetworkID        uint32             `json:"network_id"`
+	AnchorRepository common.Address     `json:"anchor_repository" swaggertype:"primitive,string"`
+}
+
+// BundleManifest describes the anchored version packed in the bundle.
+type BundleManifest struct {
+	FormatVersion int                `json:"format_version"`
+	Scheme        string             `json:"scheme"`
+	DocumentID    byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID     byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	Signers       []BundleSigner     `json:"signers"`
+	Anchor        BundleAnchor       `json:"anchor"`
+}
+
+// Bundle holds an anchored version of a document and the data required to verify it without a node.
+type Bundle struct {
+	Document coredocumentpb.CoreDocument
+	Manifest BundleManifest
+}
+
+// MarshalBundle writes the bundle as a zip archive with the packed core document and the manifest.
+func MarshalBundle(b Bundle) ([]byte, error) {
+	doc, err := proto.Marshal(&b.Document)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{name: BundleDocumentFile, data: doc},
+		{name: BundleManifestFile, data: manifest},
+	} {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = w.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBundle reads the bundle from the zip archive.
+// Entries other than the document and the manifest are ignored. Entries larger than MaxBundleSize are rejected.
+func UnmarshalBundle(data []byte) (b Bundle, err error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return b, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if f.Name != BundleDocumentFile && f.Name != BundleManifestFile {
+			continue
+		}
+
+		d, err := readBundleEntry(f)
+		if err != nil {
+			return b, errors.NewTypedError(ErrInvalidBundle, err)
+		}
+
+		files[f.Name] = d
+	}
+
+	doc, ok := files[BundleDocumentFile]
+	if !ok {
+		return b, errors.NewTypedError(ErrInvalidBundle, errors.New("%s missing", BundleDocumentFile))
+	}
+
+	manifest, ok := files[BundleManifestFile]
+	if !ok {
+		return b, errors.NewTypedError(ErrInvalidBundle, errors.New("%s missing", BundleManifestFile))
+	}
+
+	if err := proto.Unmarshal(doc, &b.Document); err != nil {
+		return b, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	if err := json.Unmarshal(manifest, &b.Manifest); err != nil {
+		return b, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	if b.Manifest.FormatVersion != BundleFormatVersion {
+		return b, errors.NewTypedError(ErrInvalidBundle, errors.New("unsupported format version %d", b.Manifest.FormatVersion))
+	}
+
+	return b, nil
+}
+
+// readBundleEntry reads the uncompressed entry of the bundle.
+// Size in the entry header is not trusted, so the read is limited as well.
+func readBundleEntry(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > MaxBundleSize {
+		return nil, errors.New("%s exceeds %d bytes", f.Name, MaxBundleSize)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	d, err := ioutil.ReadAll(io.LimitReader(rc, MaxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d) > MaxBundleSize {
+		return nil, errors.New("%s exceeds %d bytes", f.Name, MaxBundleSize)
+	}
+
+	return d, nil
+}
+
+// ExportBundle packs the anchored version of the document into a bundle.
+// Latest version is exported if the versionID is empty.
+func (s service) ExportBundle(ctx context.Context, documentID, versionID []byte) ([]byte, error) {
+	var model Model
+	var err error
+	if len(versionID) == 0 {
+		model, err = s.GetCurrentVersion(ctx, documentID)
+	} else {
+		model, err = s.getVersion(ctx, documentID, versionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if model.GetStatus() != Committed {
+		return nil, errors.NewTypedError(ErrDocumentNotInAllowedState, errors.New("version %x is not anchored", model.CurrentVersion()))
+	}
+
+	manifest, err := s.bundleManifest(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentUnPackingCoreDocument, err)
+	}
+
+	return MarshalBundle(Bundle{Document: cd, Manifest: manifest})
+}
+
+// bundleManifest returns the manifest of the anchored model.
+func (s service) bundleManifest(ctx context.Context, model Model) (m BundleManifest, err error) {
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	if err != nil {
+		return m, err
+	}
+
+	dr, err := model.CalculateDocumentRoot()
+	if err != nil {
+		return m, errors.NewTypedError(ErrCDTree, err)
+	}
+
+	docRoot, anchoredAt, err := s.anchorSrv.GetAnchorData(anchorID)
+	if err != nil {
+		return m, errors.NewTypedError(ErrDocumentAnchoring, err)
+	}
+
+	if !utils.IsSameByteSlice(dr, docRoot[:]) {
+		return m, errors.NewTypedError(ErrDocumentAnchoring, errors.New("mismatched document roots"))
+	}
+
+	sr, err := model.CalculateSigningRoot()
+	if err != nil {
+		return m, errors.NewTypedError(ErrCDTree, err)
+	}
+
+	sigRoot, err := model.CalculateSignaturesRoot()
+	if err != nil {
+		return m, errors.NewTypedError(ErrCDTree, err)
+	}
+
+	ts, err := model.Timestamp()
+	if err != nil {
+		return m, err
+	}
+
+	m = BundleManifest{
+		FormatVersion: BundleFormatVersion,
+		Scheme:        model.Scheme(),
+		DocumentID:    model.ID(),
+		VersionID:     model.CurrentVersion(),
+		Signers:       []BundleSigner{},
+		Anchor: BundleAnchor{
+			AnchorID:         anchorID[:],
+			DocumentRoot:     dr,
+			SigningRoot:      sr,
+			SignaturesRoot:   sigRoot,
+			AnchoredAt:       anchoredAt,
+			NetworkID:        s.config.GetNetworkID(),
+			AnchorRepository: model.AnchorRepoAddress(),
+		},
+	}
+
+	for _, sig := range model.Signatures() {
+		signer, err := identity.NewDIDFromBytes(sig.SignerId)
+		if err != nil {
+			return m, err
+		}
+
+		bs := BundleSigner{
+			SignerID:            signer,
+			SignatureID:         sig.SignatureId,
+			PublicKey:           sig.PublicKey,
+			Signature:           sig.Signature,
+			TransitionValidated: sig.TransitionValidated,
+			DocumentTimestamp:   ts,
+		}
+
+		bs.KeyValidAtTimestamp = s.idService.ValidateKey(
+			ctx, signer, sig.PublicKey, &(identity.KeyPurposeSigning.Value), &ts) == nil
+		if key, err := utils.SliceToByte32(sig.PublicKey); err == nil {
+			if resp, err := s.idService.GetKey(signer, key); err == nil {
+				bs.KeyRevokedAtBlock = resp.RevokedAt
+			}
+		}
+
+		m.Signers = append(m.Signers, bs)
+	}
+
+	return m, nil
+}
+
+// ImportBundle validates the anchored version in the bundle and stores it as a committed version of the account.
+// Bundle is validated against the chain the node is connected to.
+// Document is marked read-only unless the account holds it already. Existing versions are not overwritten.
+func (s service) ImportBundle(ctx context.Context, data []byte) (Model, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	b, err := UnmarshalBundle(data)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := s.DeriveFromCoreDocument(b.Document)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	if err := s.validateBundleManifest(model, b.Manifest); err != nil {
+		return nil, errors.NewTypedError(ErrInvalidBundle, err)
+	}
+
+	if err := PostAnchoredValidator(s.idService, s.anchorSrv).Validate(nil, model); err != nil {
+		return nil, errors.NewTypedError(ErrDocumentInvalid, err)
+	}
+
+	// set the status to committed since the document is anchored already.
+	if err := model.SetStatus(Committed); err != nil {
+		return nil, err
+	}
+
+	if s.repo.Exists(did[:], model.CurrentVersion()) {
+		return nil, errors.NewTypedError(ErrDocumentPersistence, errors.New("version %x exists already", model.CurrentVersion()))
+	}
+
+	// documents held by the account already stay writable
+	_, err = s.repo.GetLatest(did[:], model.ID())
+	held := err == nil
+
+	if err := s.repo.Create(did[:], model.CurrentVersion(), model); err != nil {
+		return nil, errors.NewTypedError(ErrDocumentPersistence, err)
+	}
+
+	// marked after the version is stored so that a failed import doesn't leave a read-only document without versions
+	if !held {
+		if err := s.repo.MarkReadOnly(did[:], model.ID()); err != nil {
+			return nil, errors.NewTypedError(ErrDocumentPersistence, err)
+		}
+	}
+
+	srvLog.Infof("imported document %x with version %x", model.ID(), model.CurrentVersion())
+	return model, nil
+}
+
+// validateBundleManifest checks that the manifest describes the document and the chain of the node.
+// Signatures and anchor are validated against the chain and not the manifest.
+func (s service) validateBundleManifest(model Model, m BundleManifest) error {
+	if !utils.IsSameByteSlice(m.DocumentID, model.ID()) || !utils.IsSameByteSlice(m.VersionID, model.CurrentVersion()) {
+		return errors.New("manifest doesn't match the document")
+	}
+
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	if err != nil {
+		return err
+	}
+
+	if !utils.IsSameByteSlice(m.Anchor.AnchorID, anchorID[:]) {
+		return errors.New("manifest anchor doesn't match the document")
+	}
+
+	if m.Anchor.NetworkID != s.config.GetNetworkID() {
+		return errors.New("document is anchored on network %d", m.Anchor.NetworkID)
+	}
+
+	addr := s.config.GetContractAddress(config.AnchorRepo)
+	if m.Anchor.AnchorRepository != addr || model.AnchorRepoAddress() != addr {
+		return ErrDifferentAnchoredAddress
+	}
+
+	return nil
+}
+
+// checkWritable returns ErrDocumentReadOnly if the document, owned by the account, is imported from a bundle.
+func (s service) checkWritable(ctx context.Context, docID []byte) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return ErrDocumentConfigAccountID
+	}
+
+	if s.repo.IsReadOnly(did[:], docID) {
+		return errors.NewTypedError(ErrDocumentReadOnly, errors.New("document %x is imported from a bundle", docID))
+	}
+
+	return nil
+}
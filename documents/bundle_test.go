@@ -0,0 +1,187 @@
+// +build unit
+
+package documents
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundle_MarshalUnmarshal(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	b := Bundle{
+		Document: cd.Document,
+		Manifest: BundleManifest{
+			FormatVersion: BundleFormatVersion,
+			DocumentID:    cd.ID(),
+			VersionID:     cd.CurrentVersion(),
+			Signers:       []BundleSigner{{SignerID: did, PublicKey: utils.RandomSlice(32), KeyValidAtTimestamp: true}},
+			Anchor:        BundleAnchor{AnchorID: cd.CurrentVersion(), NetworkID: 8},
+		},
+	}
+
+	data, err := MarshalBundle(b)
+	assert.NoError(t, err)
+	got, err := UnmarshalBundle(data)
+	assert.NoError(t, err)
+	assert.Equal(t, cd.ID(), got.Document.DocumentIdentifier)
+	assert.Equal(t, cd.CurrentVersion(), got.Document.CurrentVersion)
+	assert.Equal(t, b.Manifest.VersionID, got.Manifest.VersionID)
+	assert.Equal(t, b.Manifest.Anchor.NetworkID, got.Manifest.Anchor.NetworkID)
+	assert.Len(t, got.Manifest.Signers, 1)
+	assert.Equal(t, did, got.Manifest.Signers[0].SignerID)
+	assert.True(t, got.Manifest.Signers[0].KeyValidAtTimestamp)
+
+	// not a zip
+	_, err = UnmarshalBundle(utils.RandomSlice(32))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBundle, err))
+
+	// missing manifest
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	_, err = zw.Create(BundleDocumentFile)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	_, err = UnmarshalBundle(buf.Bytes())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBundle, err))
+
+	// entry too large
+	buf = new(bytes.Buffer)
+	zw = zip.NewWriter(buf)
+	w, err := zw.Create(BundleDocumentFile)
+	assert.NoError(t, err)
+	_, err = w.Write(make([]byte, MaxBundleSize+1))
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+	_, err = UnmarshalBundle(buf.Bytes())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBundle, err))
+	assert.Contains(t, err.Error(), "exceeds")
+
+	// unsupported format
+	b.Manifest.FormatVersion = BundleFormatVersion + 1
+	data, err = MarshalBundle(b)
+	assert.NoError(t, err)
+	_, err = UnmarshalBundle(data)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBundle, err))
+}
+
+func TestService_ExportBundle(t *testing.T) {
+	s := service{repo: getRepository(ctx)}
+	s.repo.Register(new(doc))
+
+	// missing account
+	docID := utils.RandomSlice(32)
+	_, err := s.ExportBundle(context.Background(), docID, docID)
+	assert.Error(t, err)
+
+	// missing version
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	_, err = s.ExportBundle(ctxh, docID, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentVersionNotFound, err))
+
+	// version not anchored
+	d := &doc{DocID: docID, Current: docID, Next: utils.RandomSlice(32), Status: Committing}
+	assert.NoError(t, s.repo.Create(did[:], d.Current, d))
+	_, err = s.ExportBundle(ctxh, docID, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotInAllowedState, err))
+}
+
+func TestService_ImportBundle(t *testing.T) {
+	s := service{repo: getRepository(ctx)}
+
+	// missing account
+	_, err := s.ImportBundle(context.Background(), nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// invalid bundle
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	_, err = s.ImportBundle(ctxh, utils.RandomSlice(32))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBundle, err))
+}
+
+func TestRepo_ReadOnly(t *testing.T) {
+	repo := getRepository(ctx)
+	accountID, docID := utils.RandomSlice(32), utils.RandomSlice(32)
+	assert.False(t, repo.IsReadOnly(accountID, docID))
+	assert.NoError(t, repo.MarkReadOnly(accountID, docID))
+	assert.True(t, repo.IsReadOnly(accountID, docID))
+	assert.False(t, repo.IsReadOnly(utils.RandomSlice(32), docID))
+
+	// marking again is a no-op
+	assert.NoError(t, repo.MarkReadOnly(accountID, docID))
+	assert.True(t, repo.IsReadOnly(accountID, docID))
+}
+
+func TestService_checkWritable(t *testing.T) {
+	s := service{repo: getRepository(ctx)}
+	docID := utils.RandomSlice(32)
+
+	// missing account
+	err := s.checkWritable(context.Background(), docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	assert.NoError(t, s.checkWritable(ctxh, docID))
+	assert.NoError(t, s.repo.MarkReadOnly(did[:], docID))
+	err = s.checkWritable(ctxh, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentReadOnly, err))
+}
+
+func TestService_validateBundleManifest(t *testing.T) {
+	s := service{config: cfg}
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	anchorID, err := anchors.ToAnchorID(versionID)
+	assert.NoError(t, err)
+	addr := cfg.GetContractAddress(config.AnchorRepo)
+	model := new(MockModel)
+	model.On("ID").Return(docID)
+	model.On("CurrentVersion").Return(versionID)
+	model.On("AnchorRepoAddress").Return(addr)
+	m := BundleManifest{
+		DocumentID: docID,
+		VersionID:  versionID,
+		Anchor: BundleAnchor{
+			AnchorID:         anchorID[:],
+			NetworkID:        cfg.GetNetworkID(),
+			AnchorRepository: addr,
+		},
+	}
+	assert.NoError(t, s.validateBundleManifest(model, m))
+
+	// different version
+	m.VersionID = utils.RandomSlice(32)
+	assert.Error(t, s.validateBundleManifest(model, m))
+	m.VersionID = versionID
+
+	// different network
+	m.Anchor.NetworkID++
+	assert.Error(t, s.validateBundleManifest(model, m))
+	m.Anchor.NetworkID--
+
+	// different anchor repository
+	m.Anchor.AnchorRepository = common.BytesToAddress(utils.RandomSlice(20))
+	err = s.validateBundleManifest(model, m)
+	assert.Error(t, err)
+	assert.Equal(t, ErrDifferentAnchoredAddress, err)
+}
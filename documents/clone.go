@@ -0,0 +1,204 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CloneOptions defines what is left out when a document is cloned.
+type CloneOptions struct {
+	// DropCollaborators leaves out the read and write collaborators of the document.
+	// Collaborators of the custom roles are still copied along with the roles.
+	DropCollaborators bool `json:"drop_collaborators"`
+
+	// DropNFTs leaves out the read access granted to the NFT owners.
+	// NFTs minted for the document are never copied since they are bound to the document.
+	DropNFTs bool `json:"drop_nfts"`
+
+	// DropAccessTokens leaves out the access tokens.
+	// Access tokens are bound to the document, so they are issued again for the same grantees.
+	DropAccessTokens bool `json:"drop_access_tokens"`
+
+	// DropSignedAttributes leaves out the signed attributes.
+	// Signatures are bound to the document, so only the attributes signed by the account are signed again.
+	DropSignedAttributes bool `json:"drop_signed_attributes"`
+}
+
+// ClonePayload returns the payload to create a new document with the data, attributes and collaborators of the model.
+// Signed attributes are not part of the payload since they need to be signed again for the new document.
+func ClonePayload(model Model, opts CloneOptions) (payload UpdatePayload, err error) {
+	payload.Scheme = model.Scheme()
+	payload.Data, err = json.Marshal(model.GetData())
+	if err != nil {
+		return payload, err
+	}
+
+	if !opts.DropCollaborators {
+		payload.Collaborators, err = model.GetCollaborators()
+		if err != nil {
+			return payload, err
+		}
+	}
+
+	payload.Attributes = make(map[AttrKey]Attribute)
+	for _, attr := range model.GetAttributes() {
+		if attr.Value.Type == AttrSigned {
+			continue
+		}
+
+		payload.Attributes[attr.Key] = attr
+	}
+
+	return payload, nil
+}
+
+// isAttributeRule returns true if the rule grants access to an attribute.
+func isAttributeRule(rule *coredocumentpb.TransitionRule) bool {
+	if rule.MatchType != coredocumentpb.FieldMatchType_FIELD_MATCH_TYPE_PREFIX {
+		return false
+	}
+
+	prefix := append(CompactProperties(CDTreePrefix), []byte{0, 0, 0, 28}...)
+	return bytes.HasPrefix(rule.Field, prefix) && len(rule.Field) == len(prefix)+idSize
+}
+
+// CopyRules copies the custom roles, attribute transition rules, and role read rules of src to the document.
+// Default collaborator roles and rules are not copied since they are created from the collaborators.
+// Read access of the NFT owners is copied unless dropped.
+// Access tokens of src are issued again for the document unless dropped.
+func (cd *CoreDocument) CopyRules(ctx context.Context, src coredocumentpb.CoreDocument, opts CloneOptions) error {
+	copied := make(map[string]bool)
+	copyRoles := func(keys [][]byte) (roles [][]byte) {
+		for _, rk := range keys {
+			ok, done := copied[hexutil.Encode(rk)]
+			if !done {
+				ok = cd.copyRole(src.Roles, rk, opts.DropNFTs)
+				copied[hexutil.Encode(rk)] = ok
+			}
+
+			if ok {
+				roles = append(roles, rk)
+			}
+		}
+
+		return roles
+	}
+
+	for _, rule := range src.TransitionRules {
+		if !isAttributeRule(rule) {
+			continue
+		}
+
+		roles := copyRoles(rule.Roles)
+		if len(roles) < 1 {
+			continue
+		}
+
+		for _, rk := range roles {
+			cd.addDefaultRules(rk)
+		}
+
+		nr := cd.addNewTransitionRule(roles[0], rule.MatchType, copyBytes(rule.Field), rule.Action)
+		nr.Roles = roles
+	}
+
+	for _, rule := range src.ReadRules {
+		if rule.Action != coredocumentpb.Action_ACTION_READ {
+			continue
+		}
+
+		roles := copyRoles(rule.Roles)
+		if len(roles) < 1 {
+			continue
+		}
+
+		cd.addNewReadRule(roles[0], rule.Action)
+		cd.Document.ReadRules[len(cd.Document.ReadRules)-1].Roles = roles
+	}
+
+	if opts.DropAccessTokens {
+		return nil
+	}
+
+	for _, at := range src.AccessTokens {
+		grantee, err := identity.NewDIDFromBytes(at.Grantee)
+		if err != nil {
+			return err
+		}
+
+		// token keeps granting access to the document it was issued for
+		nat, err := assembleAccessToken(ctx, AccessTokenParams{
+			Grantee:            grantee.String(),
+			DocumentIdentifier: hexutil.Encode(at.DocumentIdentifier),
+		}, cd.CurrentVersion())
+		if err != nil {
+			return err
+		}
+
+		cd.Document.AccessTokens = append(cd.Document.AccessTokens, nat)
+		cd.Modified = true
+	}
+
+	return nil
+}
+
+// copyRole copies the role associated with key from roles to the document.
+// returns false if the role is missing or ends up empty.
+func (cd *CoreDocument) copyRole(roles []*coredocumentpb.Role, key []byte, dropNFTs bool) bool {
+	if _, err := cd.GetRole(key); err == nil {
+		return true
+	}
+
+	role, err := getRole(key, roles)
+	if err != nil {
+		return false
+	}
+
+	nr := &coredocumentpb.Role{
+		RoleKey:       copyBytes(role.RoleKey),
+		Collaborators: copyByteSlice(role.Collaborators),
+	}
+
+	if !dropNFTs {
+		nr.Nfts = copyByteSlice(role.Nfts)
+	}
+
+	if len(nr.Collaborators) < 1 && len(nr.Nfts) < 1 {
+		return false
+	}
+
+	cd.Document.Roles = append(cd.Document.Roles, nr)
+	cd.Modified = true
+	return true
+}
+
+// CloneSignedAttributes signs the attributes of src, that are signed by the account, again for the model.
+// Attributes signed by others are left out since they cannot be signed again.
+func CloneSignedAttributes(acc config.Account, src, model Model) (attrs []Attribute, err error) {
+	did, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attr := range src.GetAttributes() {
+		if attr.Value.Type != AttrSigned || attr.Value.Signed.Identity != did {
+			continue
+		}
+
+		// we use currentVersion here since the version is not anchored yet
+		nattr, err := NewSignedAttribute(attr.KeyLabel, did, acc, model.ID(), model.CurrentVersion(), attr.Value.Signed.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs = append(attrs, nattr)
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,147 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClonePayload(t *testing.T) {
+	str, err := NewStringAttribute("status", AttrString, "draft")
+	assert.NoError(t, err)
+	signed := Attribute{KeyLabel: "approval", Key: AttrKey(utils.RandomByte32()), Value: AttrVal{Type: AttrSigned}}
+	collabs := CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{testingidentity.GenerateRandomDID()}}
+	model := new(MockModel)
+	model.On("Scheme").Return("generic")
+	model.On("GetData").Return(map[string]string{"comment": "hello"})
+	model.On("GetAttributes").Return([]Attribute{str, signed})
+	model.On("GetCollaborators", []identity.DID(nil)).Return(collabs, nil).Once()
+
+	payload, err := ClonePayload(model, CloneOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, payload.DocumentID)
+	assert.Equal(t, "generic", payload.Scheme)
+	assert.Equal(t, collabs, payload.Collaborators)
+	assert.Len(t, payload.Attributes, 1)
+	assert.Equal(t, str, payload.Attributes[str.Key])
+	d, err := json.Marshal(map[string]string{"comment": "hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, d, payload.Data)
+
+	// drop collaborators
+	payload, err = ClonePayload(model, CloneOptions{DropCollaborators: true})
+	assert.NoError(t, err)
+	assert.Empty(t, payload.Collaborators.ReadWriteCollaborators)
+	model.AssertExpectations(t)
+}
+
+func TestCoreDocument_CopyRules(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	self, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	collabs := CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{self}}
+	src, err := NewCoreDocument(nil, collabs, nil)
+	assert.NoError(t, err)
+
+	// custom role with an attribute rule and a read rule
+	auditor := testingidentity.GenerateRandomDID()
+	role, err := src.AddRole("auditors", []identity.DID{auditor})
+	assert.NoError(t, err)
+	key, err := AttrKeyFromLabel("status")
+	assert.NoError(t, err)
+	_, err = src.AddTransitionRuleForAttribute(role.RoleKey, key)
+	assert.NoError(t, err)
+	_, err = src.AddReadRuleForRole(role.RoleKey)
+	assert.NoError(t, err)
+
+	// nft read access and an access token
+	registry := common.BytesToAddress(utils.RandomSlice(20))
+	assert.NoError(t, src.addNFTToReadRules(registry, utils.RandomSlice(32)))
+	grantee := testingidentity.GenerateRandomDID()
+	target := utils.RandomSlice(32)
+	src, err = src.AddAccessToken(ctx, AccessTokenParams{
+		Grantee:            grantee.String(),
+		DocumentIdentifier: hexutil.Encode(target),
+	})
+	assert.NoError(t, err)
+
+	// copy everything
+	dst, err := NewCoreDocument(nil, collabs, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, dst.CopyRules(ctx, src.Document, CloneOptions{}))
+	_, err = dst.GetRole(role.RoleKey)
+	assert.NoError(t, err)
+	assert.True(t, dst.AccountCanRead(auditor))
+	assert.Len(t, dst.TransitionRulesFor(auditor), len(src.TransitionRulesFor(auditor)))
+	assert.Len(t, dst.Document.Roles, len(src.Document.Roles))
+	assert.Len(t, dst.Document.AccessTokens, 1)
+	at := dst.Document.AccessTokens[0]
+	assert.Equal(t, target, at.DocumentIdentifier)
+	assert.Equal(t, grantee[:], at.Grantee)
+	assert.Empty(t, dst.Document.Nfts)
+
+	// drop nfts and access tokens
+	dst, err = NewCoreDocument(nil, collabs, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, dst.CopyRules(ctx, src.Document, CloneOptions{DropNFTs: true, DropAccessTokens: true}))
+	assert.True(t, dst.AccountCanRead(auditor))
+	assert.Len(t, dst.Document.Roles, len(src.Document.Roles)-1)
+	for _, r := range dst.Document.Roles {
+		assert.Empty(t, r.Nfts)
+	}
+	assert.Empty(t, dst.Document.AccessTokens)
+
+	// default collaborator rules are not copied
+	dst, err = NewCoreDocument(nil, CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, dst.CopyRules(context.Background(), src.Document, CloneOptions{DropNFTs: true, DropAccessTokens: true}))
+	assert.False(t, dst.AccountCanRead(self))
+	for _, r := range dst.Document.ReadRules {
+		assert.Equal(t, coredocumentpb.Action_ACTION_READ, r.Action)
+	}
+}
+
+func TestCloneSignedAttributes(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	self, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	srcID, srcVersion := utils.RandomSlice(32), utils.RandomSlice(32)
+	own, err := NewSignedAttribute("approval", self, acc, srcID, srcVersion, []byte("approved"))
+	assert.NoError(t, err)
+	others := Attribute{KeyLabel: "other", Key: AttrKey(utils.RandomByte32()), Value: AttrVal{
+		Type:   AttrSigned,
+		Signed: Signed{Identity: testingidentity.GenerateRandomDID(), Value: []byte("other")},
+	}}
+	str, err := NewStringAttribute("status", AttrString, "draft")
+	assert.NoError(t, err)
+
+	src := new(MockModel)
+	src.On("GetAttributes").Return([]Attribute{own, others, str})
+	id, version := utils.RandomSlice(32), utils.RandomSlice(32)
+	model := new(MockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(version)
+	attrs, err := CloneSignedAttributes(acc, src, model)
+	assert.NoError(t, err)
+	assert.Len(t, attrs, 1)
+	assert.Equal(t, own.Key, attrs[0].Key)
+	assert.Equal(t, []byte("approved"), attrs[0].Value.Signed.Value)
+	assert.Equal(t, version, attrs[0].Value.Signed.DocumentVersion)
+	assert.NotEqual(t, own.Value.Signed.Signature, attrs[0].Value.Signed.Signature)
+}
@@ -0,0 +1,302 @@
+package documents
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ConstraintType is the type of the value constraint of an attribute.
+type ConstraintType string
+
+const (
+	// ConstraintIncreasing allows integer, decimal, and timestamp attributes to only increase or stay the same.
+	ConstraintIncreasing ConstraintType = "increasing"
+
+	// ConstraintEnum restricts the attribute value to one of the constraint values.
+	ConstraintEnum ConstraintType = "enum"
+
+	// ConstraintStateMachine restricts the string attribute to the transitions of the constraint.
+	ConstraintStateMachine ConstraintType = "state_machine"
+
+	// ConstraintImmutable doesn't allow changes to the attribute once it has a non-empty value.
+	ConstraintImmutable ConstraintType = "immutable"
+
+	// constraintLabelPrefix is the label prefix of the attributes holding the value constraints.
+	constraintLabelPrefix = "_constraint_"
+)
+
+// ValueConstraint constrains the values an attribute can take in the next versions of the document.
+// Constraints apply to every collaborator with write access to the attribute.
+// Once set, neither the constraint nor the constrained attribute can be removed.
+type ValueConstraint struct {
+	Type ConstraintType `json:"type" enums:"increasing,enum,state_machine,immutable"`
+
+	// Values are the allowed values of an enum constraint.
+	Values []string `json:"values,omitempty"`
+
+	// Transitions maps a state of the state machine to the states it can move to.
+	// Initial value of the attribute can be any of the states.
+	Transitions map[string][]string `json:"transitions,omitempty"`
+}
+
+// Validate checks if the constraint is well formed.
+func (c ValueConstraint) Validate() error {
+	switch c.Type {
+	case ConstraintIncreasing, ConstraintImmutable:
+		return nil
+	case ConstraintEnum:
+		if len(c.Values) < 1 {
+			return errors.NewTypedError(ErrInvalidValueConstraint, errors.New("enum requires at least one value"))
+		}
+	case ConstraintStateMachine:
+		if len(c.Transitions) < 1 {
+			return errors.NewTypedError(ErrInvalidValueConstraint, errors.New("state machine requires at least one transition"))
+		}
+	default:
+		return errors.NewTypedError(ErrInvalidValueConstraint, errors.New("unknown constraint type: %s", c.Type))
+	}
+
+	return nil
+}
+
+// states returns all the states of the state machine.
+func (c ValueConstraint) states() map[string]struct{} {
+	states := make(map[string]struct{})
+	for from, tos := range c.Transitions {
+		states[from] = struct{}{}
+		for _, to := range tos {
+			states[to] = struct{}{}
+		}
+	}
+
+	return states
+}
+
+// check returns an error if the attribute cannot move from old to new.
+// old is nil when the attribute is not set in the previous version.
+func (c ValueConstraint) check(old, new *Attribute) error {
+	if new == nil {
+		if old == nil {
+			return nil
+		}
+
+		return errors.New("attribute cannot be removed")
+	}
+
+	if old != nil && old.Value.Type != new.Value.Type {
+		return errors.New("attribute type cannot change from %s to %s", old.Value.Type, new.Value.Type)
+	}
+
+	nv, err := new.Value.String()
+	if err != nil {
+		return err
+	}
+
+	switch c.Type {
+	case ConstraintIncreasing:
+		// initial value only needs to be of a comparable type
+		if old == nil {
+			old = new
+		}
+
+		ok, err := isIncreasing(old.Value, new.Value)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("value must not decrease")
+		}
+	case ConstraintEnum:
+		if !utils.ContainsString(c.Values, nv) {
+			return errors.New("value %s is not allowed", nv)
+		}
+	case ConstraintStateMachine:
+		if old == nil {
+			if _, ok := c.states()[nv]; !ok {
+				return errors.New("unknown state %s", nv)
+			}
+
+			return nil
+		}
+
+		ov, err := old.Value.String()
+		if err != nil {
+			return err
+		}
+
+		if ov == nv {
+			return nil
+		}
+
+		if !utils.ContainsString(c.Transitions[ov], nv) {
+			return errors.New("transition from %s to %s is not allowed", ov, nv)
+		}
+	case ConstraintImmutable:
+		if old == nil {
+			return nil
+		}
+
+		ov, err := old.Value.String()
+		if err != nil {
+			return err
+		}
+
+		if ov != "" && ov != nv {
+			return errors.New("value cannot change once set")
+		}
+	}
+
+	return nil
+}
+
+// isIncreasing returns true if the new value is greater than or equal to the old value.
+func isIncreasing(old, new AttrVal) (bool, error) {
+	switch new.Type {
+	case AttrInt256:
+		return new.Int256.Cmp(old.Int256) >= 0, nil
+	case AttrDecimal:
+		return new.Decimal.Cmp(old.Decimal) >= 0, nil
+	case AttrTimestamp:
+		ot, err := utils.FromTimestamp(old.Timestamp)
+		if err != nil {
+			return false, err
+		}
+
+		nt, err := utils.FromTimestamp(new.Timestamp)
+		if err != nil {
+			return false, err
+		}
+
+		return !nt.Before(ot), nil
+	default:
+		return false, errors.New("attribute of type %s cannot be increasing", new.Type)
+	}
+}
+
+// constraintLabel returns the label of the attribute holding the constraint of the attribute key.
+func constraintLabel(key AttrKey) string {
+	return constraintLabelPrefix + key.String()
+}
+
+// NewValueConstraintAttribute returns the attribute holding the value constraint of the attribute key.
+func NewValueConstraintAttribute(key AttrKey, c ValueConstraint) (attr Attribute, err error) {
+	if err := c.Validate(); err != nil {
+		return attr, err
+	}
+
+	d, err := json.Marshal(c)
+	if err != nil {
+		return attr, err
+	}
+
+	return NewStringAttribute(constraintLabel(key), AttrString, string(d))
+}
+
+// AddValueConstraint adds the value constraint for the attribute key to the model.
+// Existing constraints cannot be replaced.
+func AddValueConstraint(model Model, key AttrKey, c ValueConstraint) error {
+	attr, err := NewValueConstraintAttribute(key, c)
+	if err != nil {
+		return err
+	}
+
+	if model.AttributeExists(attr.Key) {
+		return errors.NewTypedError(ErrInvalidValueConstraint, errors.New("attribute %s is already constrained", key))
+	}
+
+	return model.AddAttributes(CollaboratorsAccess{}, false, attr)
+}
+
+// valueConstraints returns the value constraints present in the attributes mapped to the constrained attribute keys.
+func valueConstraints(attrs []Attribute) (map[AttrKey]ValueConstraint, error) {
+	cs := make(map[AttrKey]ValueConstraint)
+	for _, attr := range attrs {
+		if !strings.HasPrefix(attr.KeyLabel, constraintLabelPrefix) {
+			continue
+		}
+
+		k, err := hexutil.Decode(strings.TrimPrefix(attr.KeyLabel, constraintLabelPrefix))
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidValueConstraint, err)
+		}
+
+		key, err := AttrKeyFromBytes(k)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidValueConstraint, err)
+		}
+
+		var c ValueConstraint
+		err = json.Unmarshal([]byte(attr.Value.Str), &c)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidValueConstraint, err)
+		}
+
+		cs[key] = c
+	}
+
+	return cs, nil
+}
+
+// ValidateValueConstraints checks that the attribute changes from old to new model satisfy the value constraints
+// present in the old model. Constraints cannot be changed or removed once added.
+// Constraints added in the new model must be satisfied by the current value of the attribute.
+func ValidateValueConstraints(old, new Model) (err error) {
+	ocs, err := valueConstraints(old.GetAttributes())
+	if err != nil {
+		return err
+	}
+
+	ncs, err := valueConstraints(new.GetAttributes())
+	if err != nil {
+		return err
+	}
+
+	for key, c := range ncs {
+		if _, ok := ocs[key]; ok {
+			continue
+		}
+
+		if cerr := c.Validate(); cerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", key, cerr))
+			continue
+		}
+
+		if cerr := c.check(nil, getAttribute(new, key)); cerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", key, cerr))
+		}
+	}
+
+	for key, c := range ocs {
+		nc, ok := ncs[key]
+		if !ok || !reflect.DeepEqual(c, nc) {
+			err = errors.AppendError(err, errors.New("%s: constraint cannot be changed", key))
+			continue
+		}
+
+		if cerr := c.check(getAttribute(old, key), getAttribute(new, key)); cerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", key, cerr))
+		}
+	}
+
+	if err != nil {
+		return errors.NewTypedError(ErrValueConstraintViolation, err)
+	}
+
+	return nil
+}
+
+// getAttribute returns the attribute of the model or nil if the attribute doesn't exist.
+func getAttribute(model Model, key AttrKey) *Attribute {
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return nil
+	}
+
+	return &attr
+}
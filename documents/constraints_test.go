@@ -0,0 +1,189 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// attrsModel is a model backed only by the attributes.
+type attrsModel struct {
+	Model
+	attrs map[AttrKey]Attribute
+}
+
+func newAttrsModel(attrs ...Attribute) *attrsModel {
+	m := &attrsModel{attrs: make(map[AttrKey]Attribute)}
+	for _, attr := range attrs {
+		m.attrs[attr.Key] = attr
+	}
+
+	return m
+}
+
+func (m *attrsModel) GetAttributes() (attrs []Attribute) {
+	for _, attr := range m.attrs {
+		attrs = append(attrs, attr)
+	}
+
+	return attrs
+}
+
+func (m *attrsModel) GetAttribute(key AttrKey) (Attribute, error) {
+	attr, ok := m.attrs[key]
+	if !ok {
+		return attr, ErrCDAttribute
+	}
+
+	return attr, nil
+}
+
+func newConstraintAttributes(t *testing.T, label string, attrType AttributeType, value string, c ValueConstraint) []Attribute {
+	attr, err := NewStringAttribute(label, attrType, value)
+	assert.NoError(t, err)
+	cattr, err := NewValueConstraintAttribute(attr.Key, c)
+	assert.NoError(t, err)
+	return []Attribute{attr, cattr}
+}
+
+func TestValueConstraint_Validate(t *testing.T) {
+	tests := []struct {
+		c     ValueConstraint
+		valid bool
+	}{
+		{c: ValueConstraint{Type: ConstraintIncreasing}, valid: true},
+		{c: ValueConstraint{Type: ConstraintImmutable}, valid: true},
+		{c: ValueConstraint{Type: ConstraintEnum}},
+		{c: ValueConstraint{Type: ConstraintEnum, Values: []string{"a"}}, valid: true},
+		{c: ValueConstraint{Type: ConstraintStateMachine}},
+		{c: ValueConstraint{Type: ConstraintStateMachine, Transitions: map[string][]string{"a": {"b"}}}, valid: true},
+		{c: ValueConstraint{Type: "unknown"}},
+	}
+
+	for _, c := range tests {
+		err := c.c.Validate()
+		if c.valid {
+			assert.NoError(t, err)
+			continue
+		}
+
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidValueConstraint, err))
+	}
+}
+
+func TestValidateValueConstraints(t *testing.T) {
+	statusSM := ValueConstraint{Type: ConstraintStateMachine, Transitions: map[string][]string{
+		"draft":    {"review"},
+		"review":   {"draft", "approved"},
+		"approved": {},
+	}}
+
+	tests := []struct {
+		name     string
+		label    string
+		attrType AttributeType
+		c        ValueConstraint
+		old, new string
+		removed  bool
+		valid    bool
+	}{
+		{name: "increasing int", label: "seq", attrType: AttrInt256, c: ValueConstraint{Type: ConstraintIncreasing}, old: "1", new: "2", valid: true},
+		{name: "same int", label: "seq", attrType: AttrInt256, c: ValueConstraint{Type: ConstraintIncreasing}, old: "2", new: "2", valid: true},
+		{name: "decreasing int", label: "seq", attrType: AttrInt256, c: ValueConstraint{Type: ConstraintIncreasing}, old: "2", new: "1"},
+		{name: "decreasing decimal", label: "amount", attrType: AttrDecimal, c: ValueConstraint{Type: ConstraintIncreasing}, old: "1.5", new: "1.25"},
+		{name: "increasing timestamp", label: "due", attrType: AttrTimestamp, c: ValueConstraint{Type: ConstraintIncreasing}, old: "2019-01-01T00:00:00Z", new: "2019-02-01T00:00:00Z", valid: true},
+		{name: "decreasing timestamp", label: "due", attrType: AttrTimestamp, c: ValueConstraint{Type: ConstraintIncreasing}, old: "2019-02-01T00:00:00Z", new: "2019-01-01T00:00:00Z"},
+		{name: "increasing string", label: "name", attrType: AttrString, c: ValueConstraint{Type: ConstraintIncreasing}, old: "a", new: "b"},
+		{name: "enum", label: "currency", attrType: AttrString, c: ValueConstraint{Type: ConstraintEnum, Values: []string{"EUR", "USD"}}, old: "EUR", new: "USD", valid: true},
+		{name: "not in enum", label: "currency", attrType: AttrString, c: ValueConstraint{Type: ConstraintEnum, Values: []string{"EUR", "USD"}}, old: "EUR", new: "GBP"},
+		{name: "state transition", label: "status", attrType: AttrString, c: statusSM, old: "review", new: "approved", valid: true},
+		{name: "rewind state", label: "status", attrType: AttrString, c: statusSM, old: "approved", new: "draft"},
+		{name: "skip state", label: "status", attrType: AttrString, c: statusSM, old: "draft", new: "approved"},
+		{name: "removed state", label: "status", attrType: AttrString, c: statusSM, old: "draft", removed: true},
+		{name: "immutable", label: "ref", attrType: AttrString, c: ValueConstraint{Type: ConstraintImmutable}, old: "a", new: "a", valid: true},
+		{name: "immutable empty", label: "ref", attrType: AttrString, c: ValueConstraint{Type: ConstraintImmutable}, old: "", new: "a", valid: true},
+		{name: "immutable changed", label: "ref", attrType: AttrString, c: ValueConstraint{Type: ConstraintImmutable}, old: "a", new: "b"},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			old := newAttrsModel(newConstraintAttributes(t, c.label, c.attrType, c.old, c.c)...)
+			attrs := newConstraintAttributes(t, c.label, c.attrType, c.new, c.c)
+			if c.removed {
+				attrs = attrs[1:]
+			}
+
+			err := ValidateValueConstraints(old, newAttrsModel(attrs...))
+			if c.valid {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+			assert.True(t, errors.IsOfType(ErrValueConstraintViolation, err))
+		})
+	}
+}
+
+func TestValidateValueConstraints_constraintChanges(t *testing.T) {
+	immutable := ValueConstraint{Type: ConstraintImmutable}
+	old := newAttrsModel(newConstraintAttributes(t, "ref", AttrString, "a", immutable)...)
+
+	// constraint removed
+	attrs := newConstraintAttributes(t, "ref", AttrString, "b", immutable)
+	err := ValidateValueConstraints(old, newAttrsModel(attrs[0]))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "constraint cannot be changed")
+
+	// constraint replaced
+	attrs = newConstraintAttributes(t, "ref", AttrString, "b", ValueConstraint{Type: ConstraintEnum, Values: []string{"b"}})
+	err = ValidateValueConstraints(old, newAttrsModel(attrs...))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "constraint cannot be changed")
+
+	// new constraint must be satisfied by the current value
+	old = newAttrsModel(attrs[0])
+	attrs = newConstraintAttributes(t, "ref", AttrString, "b", ValueConstraint{Type: ConstraintEnum, Values: []string{"a"}})
+	err = ValidateValueConstraints(old, newAttrsModel(attrs...))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrValueConstraintViolation, err))
+
+	// new constraint
+	attrs = newConstraintAttributes(t, "ref", AttrString, "b", immutable)
+	assert.NoError(t, ValidateValueConstraints(old, newAttrsModel(attrs...)))
+}
+
+func TestAddValueConstraint(t *testing.T) {
+	key, err := AttrKeyFromLabel("status")
+	assert.NoError(t, err)
+
+	// invalid constraint
+	m := new(MockModel)
+	err = AddValueConstraint(m, key, ValueConstraint{Type: ConstraintEnum})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidValueConstraint, err))
+
+	// already constrained
+	c := ValueConstraint{Type: ConstraintImmutable}
+	m.On("AttributeExists", mock.Anything).Return(true).Once()
+	err = AddValueConstraint(m, key, c)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidValueConstraint, err))
+
+	// success
+	attr, err := NewValueConstraintAttribute(key, c)
+	assert.NoError(t, err)
+	m.On("AttributeExists", attr.Key).Return(false).Once()
+	m.On("AddAttributes", CollaboratorsAccess{}, false, []Attribute{attr}).Return(nil).Once()
+	assert.NoError(t, AddValueConstraint(m, key, c))
+	m.AssertExpectations(t)
+
+	cs, err := valueConstraints([]Attribute{attr})
+	assert.NoError(t, err)
+	assert.Equal(t, map[AttrKey]ValueConstraint{key: c}, cs)
+}
@@ -3,6 +3,7 @@ package documents
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -243,6 +244,12 @@ func toProtocolAttributes(attrs map[AttrKey]Attribute) (pattrs []*coredocumentpb
 					Id:    append(make([]byte, monetaryIDLength-len(monetary.ID)), monetary.ID...),
 				},
 			}
+		case AttrAttachment:
+			b, err := json.Marshal(attr.Value.Attachment)
+			if err != nil {
+				return nil, err
+			}
+			pattr.Value = &coredocumentpb.Attribute_ByteVal{ByteVal: b}
 		}
 
 		pattrs = append(pattrs, pattr)
@@ -254,6 +261,11 @@ func toProtocolAttributes(attrs map[AttrKey]Attribute) (pattrs []*coredocumentpb
 const attributeProtocolPrefix = "ATTRIBUTE_TYPE_"
 
 func getProtocolAttributeType(attrType AttributeType) coredocumentpb.AttributeType {
+	// attachments are stored as bytes in the protocol
+	if attrType == AttrAttachment {
+		attrType = AttrBytes
+	}
+
 	str := attributeProtocolPrefix + strings.ToUpper(attrType.String())
 	return coredocumentpb.AttributeType(coredocumentpb.AttributeType_value[str])
 }
@@ -289,6 +301,10 @@ func fromProtocolAttributes(pattrs []*coredocumentpb.Attribute) (map[AttrKey]Att
 		}
 
 		attrType := getAttributeTypeFromProtocolType(pattr.Type)
+		if attrType == AttrBytes && isAttachmentLabel(string(pattr.KeyLabel)) {
+			attrType = AttrAttachment
+		}
+
 		attr := Attribute{
 			Key:      attrKey,
 			KeyLabel: string(pattr.KeyLabel),
@@ -360,6 +376,8 @@ func attrValFromProtocolAttribute(attrType AttributeType, attribute *coredocumen
 			ChainID: bytes.TrimLeft(val.Chain, "\x00"),
 			ID:      bytes.TrimLeft(val.Id, "\x00"),
 		}
+	case AttrAttachment:
+		err = json.Unmarshal(attribute.GetByteVal(), &attrVal.Attachment)
 	}
 
 	return attrVal, err
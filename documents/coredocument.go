@@ -741,13 +741,19 @@ func (cd *CoreDocument) coredocTree(docType string) (tree *proofs.DocumentTree,
 
 // GetSignerCollaborators returns the collaborators excluding the filteredIDs
 // returns collaborators with Action_ACTION_READ_SIGN and TransitionAction_TRANSITION_ACTION_EDIT permissions.
+// Roles and read rules outside their validity window at the time of the document version are excluded.
 func (cd *CoreDocument) GetSignerCollaborators(filterIDs ...identity.DID) ([]identity.DID, error) {
-	sign, err := cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ_SIGN)
+	at := cd.versionTime()
+	sign, err := cd.getReadCollaborators(func(role *coredocumentpb.Role) bool {
+		return cd.readRoleActive(role, at)
+	}, coredocumentpb.Action_ACTION_READ_SIGN)
 	if err != nil {
 		return nil, err
 	}
 
-	wcs, err := cd.getWriteCollaborators(coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
+	wcs, err := cd.getWriteCollaborators(func(role *coredocumentpb.Role) bool {
+		return cd.roleActive(role, at)
+	}, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 	if err != nil {
 		return nil, err
 	}
@@ -759,12 +765,12 @@ func (cd *CoreDocument) GetSignerCollaborators(filterIDs ...identity.DID) ([]ide
 
 // GetCollaborators returns the collaborators excluding the filteredIDs
 func (cd *CoreDocument) GetCollaborators(filterIDs ...identity.DID) (CollaboratorsAccess, error) {
-	rcs, err := cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ_SIGN, coredocumentpb.Action_ACTION_READ)
+	rcs, err := cd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ_SIGN, coredocumentpb.Action_ACTION_READ)
 	if err != nil {
 		return CollaboratorsAccess{}, err
 	}
 
-	wcs, err := cd.getWriteCollaborators(coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
+	wcs, err := cd.getWriteCollaborators(nil, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 	if err != nil {
 		return CollaboratorsAccess{}, err
 	}
@@ -778,9 +784,10 @@ func (cd *CoreDocument) GetCollaborators(filterIDs ...identity.DID) (Collaborato
 }
 
 // getCollaborators returns all the collaborators which have the type of read or read/sign access passed in.
-func (cd *CoreDocument) getReadCollaborators(actions ...coredocumentpb.Action) (ids []identity.DID, err error) {
+// If active is not nil, only the roles it returns true for are considered.
+func (cd *CoreDocument) getReadCollaborators(active func(role *coredocumentpb.Role) bool, actions ...coredocumentpb.Action) (ids []identity.DID, err error) {
 	findReadRole(cd.Document, func(_, _ int, role *coredocumentpb.Role) bool {
-		if len(role.Collaborators) < 1 {
+		if len(role.Collaborators) < 1 || (active != nil && !active(role)) {
 			return false
 		}
 
@@ -800,9 +807,10 @@ func (cd *CoreDocument) getReadCollaborators(actions ...coredocumentpb.Action) (
 }
 
 // getWriteCollaborators returns all the collaborators which have access to the transition actions passed in.
-func (cd *CoreDocument) getWriteCollaborators(actions ...coredocumentpb.TransitionAction) (ids []identity.DID, err error) {
+// If active is not nil, only the roles it returns true for are considered.
+func (cd *CoreDocument) getWriteCollaborators(active func(role *coredocumentpb.Role) bool, actions ...coredocumentpb.TransitionAction) (ids []identity.DID, err error) {
 	findTransitionRole(cd.Document, func(_, _ int, role *coredocumentpb.Role) bool {
-		if len(role.Collaborators) < 1 {
+		if len(role.Collaborators) < 1 || (active != nil && !active(role)) {
 			return false
 		}
 
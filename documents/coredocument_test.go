@@ -20,6 +20,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
@@ -56,6 +57,7 @@ func TestMain(m *testing.M) {
 		jobsv1.Bootstrapper{},
 		&queue.Bootstrapper{},
 		&anchors.Bootstrapper{},
+		notification.Bootstrapper{},
 		&Bootstrapper{},
 	}
 	ctx[identity.BootstrappedDIDService] = &testingcommons.MockIdentityService{}
@@ -237,7 +239,7 @@ func TestCoreDocument_PrepareNewVersion(t *testing.T) {
 	ncd, err := cd.PrepareNewVersion(nil, CollaboratorsAccess{[]identity.DID{c1, c2}, nil}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, ncd)
-	rc, err := ncd.getReadCollaborators(coredocumentpb.Action_ACTION_READ_SIGN)
+	rc, err := ncd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ_SIGN)
 	assert.Contains(t, rc, c1)
 	assert.Contains(t, rc, c2)
 	h, err = blake2b.New256(nil)
@@ -252,14 +254,14 @@ func TestCoreDocument_PrepareNewVersion(t *testing.T) {
 	ncd, err = cd.PrepareNewVersion([]byte("inv"), CollaboratorsAccess{[]identity.DID{c1, c2}, []identity.DID{c3, c4}}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, ncd)
-	rc, err = ncd.getReadCollaborators(coredocumentpb.Action_ACTION_READ_SIGN)
+	rc, err = ncd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ_SIGN)
 	assert.NoError(t, err)
 	assert.Len(t, rc, 4)
 	assert.Contains(t, rc, c1)
 	assert.Contains(t, rc, c2)
 	assert.Contains(t, rc, c3)
 	assert.Contains(t, rc, c4)
-	wc, err := ncd.getWriteCollaborators(coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
+	wc, err := ncd.getWriteCollaborators(nil, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 	assert.NoError(t, err)
 	assert.Len(t, wc, 2)
 	assert.Contains(t, wc, c3)
@@ -730,24 +732,24 @@ func TestCoreDocument_getReadCollaborators(t *testing.T) {
 	}
 	cd, err := NewCoreDocument(nil, cas, nil)
 	assert.NoError(t, err)
-	cs, err := cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ_SIGN)
+	cs, err := cd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ_SIGN)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 1)
 	assert.Equal(t, cs[0], id1)
 
-	cs, err = cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ)
+	cs, err = cd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 0)
 	role := newRoleWithCollaborators(id2)
 	cd.Document.Roles = append(cd.Document.Roles, role)
 	cd.addNewReadRule(role.RoleKey, coredocumentpb.Action_ACTION_READ)
 
-	cs, err = cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ)
+	cs, err = cd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 1)
 	assert.Equal(t, cs[0], id2)
 
-	cs, err = cd.getReadCollaborators(coredocumentpb.Action_ACTION_READ, coredocumentpb.Action_ACTION_READ_SIGN)
+	cs, err = cd.getReadCollaborators(nil, coredocumentpb.Action_ACTION_READ, coredocumentpb.Action_ACTION_READ_SIGN)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 2)
 	assert.Contains(t, cs, id1)
@@ -760,7 +762,7 @@ func TestCoreDocument_getWriteCollaborators(t *testing.T) {
 	cas := CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{id1}}
 	cd, err := NewCoreDocument([]byte("inv"), cas, nil)
 	assert.NoError(t, err)
-	cs, err := cd.getWriteCollaborators(coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
+	cs, err := cd.getWriteCollaborators(nil, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 1)
 
@@ -768,7 +770,7 @@ func TestCoreDocument_getWriteCollaborators(t *testing.T) {
 	cd.Document.Roles = append(cd.Document.Roles, role)
 	cd.addNewTransitionRule(role.RoleKey, coredocumentpb.FieldMatchType_FIELD_MATCH_TYPE_PREFIX, nil, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 
-	cs, err = cd.getWriteCollaborators(coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
+	cs, err = cd.getWriteCollaborators(nil, coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT)
 	assert.NoError(t, err)
 	assert.Len(t, cs, 2)
 	assert.Equal(t, cs[1], id2)
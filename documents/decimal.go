@@ -77,6 +77,26 @@ func (d *Decimal) String() string {
 	return d.dec.String()
 }
 
+// Add returns d + d2.
+func (d *Decimal) Add(d2 *Decimal) *Decimal {
+	return &Decimal{dec: d.dec.Add(d2.dec)}
+}
+
+// Mul returns d * d2.
+func (d *Decimal) Mul(d2 *Decimal) *Decimal {
+	return &Decimal{dec: d.dec.Mul(d2.dec)}
+}
+
+// Equal returns true if d == d2.
+func (d *Decimal) Equal(d2 *Decimal) bool {
+	return d.dec.Equal(d2.dec)
+}
+
+// Cmp compares d and d2 and returns -1 if d < d2, 0 if d == d2, and +1 if d > d2.
+func (d *Decimal) Cmp(d2 *Decimal) int {
+	return d.dec.Cmp(d2.dec)
+}
+
 // Bytes return the decimal in bytes.
 // sign byte + upto 23 integer bytes + 8 decimal bytes
 func (d *Decimal) Bytes() (decimal []byte, err error) {
@@ -301,3 +301,25 @@ func TestDecimalsToStrings(t *testing.T) {
 		assert.Equal(t, res, c.res)
 	}
 }
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	d1, err := NewDecimal("1.5")
+	assert.NoError(t, err)
+	d2, err := NewDecimal("2.25")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "3.75", d1.Add(d2).String())
+	assert.Equal(t, "3.375", d1.Mul(d2).String())
+	assert.Equal(t, -1, d1.Cmp(d2))
+	assert.Equal(t, 1, d2.Cmp(d1))
+	assert.Equal(t, 0, d1.Cmp(d1))
+
+	d3, err := NewDecimal("1.50")
+	assert.NoError(t, err)
+	assert.True(t, d1.Equal(d3))
+	assert.False(t, d1.Equal(d2))
+
+	// operands are not modified
+	assert.Equal(t, "1.5", d1.String())
+	assert.Equal(t, "2.25", d2.String())
+}
@@ -0,0 +1,327 @@
+package documents
+
+import (
+	"bytes"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DiffAction represents the kind of change between two document versions.
+type DiffAction string
+
+const (
+	// DiffAdded is used when the item is present only in the new version.
+	DiffAdded DiffAction = "added"
+
+	// DiffRemoved is used when the item is present only in the old version.
+	DiffRemoved DiffAction = "removed"
+
+	// DiffChanged is used when the item is present in both versions with different values.
+	DiffChanged DiffAction = "changed"
+)
+
+// AttributeDiff holds the change of a single attribute with values decoded as per the attribute type.
+type AttributeDiff struct {
+	Action   DiffAction
+	KeyLabel string
+	Key      AttrKey
+	Type     AttributeType
+	Old, New string
+}
+
+// RoleDiff holds the collaborators added to or removed from a role.
+type RoleDiff struct {
+	Action         DiffAction
+	RoleKey        []byte
+	Added, Removed [][]byte
+}
+
+// ReadRuleDiff holds a read rule that is added or removed.
+type ReadRuleDiff struct {
+	Action     DiffAction
+	Roles      [][]byte
+	ReadAction coredocumentpb.Action
+}
+
+// CollaboratorDiff holds a collaborator whose access was added or removed.
+type CollaboratorDiff struct {
+	Action DiffAction
+	DID    identity.DID
+	// Write is true if the access is read and write.
+	Write bool
+}
+
+// Diff holds the changes made in the new version of the document compared to the old version.
+type Diff struct {
+	DocumentID, From, To []byte
+	Attributes           []AttributeDiff
+	Roles                []RoleDiff
+	ReadRules            []ReadRuleDiff
+	Collaborators        []CollaboratorDiff
+	NFTsAdded            []*coredocumentpb.NFT
+
+	// ChangedFields are the changed leaves of the core document tree.
+	ChangedFields []ChangedField
+}
+
+// DiffVersions returns the changes made in the new version of the document compared to the old version.
+func DiffVersions(old, new Model) (diff Diff, err error) {
+	if old == nil || new == nil {
+		return diff, ErrModelNil
+	}
+
+	if !bytes.Equal(old.ID(), new.ID()) {
+		return diff, errors.NewTypedError(ErrDocumentInvalid, errors.New("versions belong to different documents"))
+	}
+
+	ocd, err := old.PackCoreDocument()
+	if err != nil {
+		return diff, err
+	}
+
+	ncd, err := new.PackCoreDocument()
+	if err != nil {
+		return diff, err
+	}
+
+	diff = Diff{
+		DocumentID: new.ID(),
+		From:       old.CurrentVersion(),
+		To:         new.CurrentVersion(),
+		Attributes: diffAttributes(old.GetAttributes(), new.GetAttributes()),
+		Roles:      diffRoles(ocd.Roles, ncd.Roles),
+		ReadRules:  diffReadRules(ocd.ReadRules, ncd.ReadRules),
+		NFTsAdded:  diffNFTs(ocd.Nfts, ncd.Nfts),
+	}
+
+	diff.Collaborators, err = diffCollaborators(old, new)
+	if err != nil {
+		return diff, err
+	}
+
+	oldTree, err := coreDocumentTree(ocd, old.DocumentType())
+	if err != nil {
+		return diff, err
+	}
+
+	newTree, err := coreDocumentTree(ncd, new.DocumentType())
+	if err != nil {
+		return diff, err
+	}
+
+	diff.ChangedFields = GetChangedFields(oldTree, newTree)
+	return diff, nil
+}
+
+func coreDocumentTree(cdp coredocumentpb.CoreDocument, docType string) (*proofs.DocumentTree, error) {
+	cd, err := NewCoreDocumentFromProtobuf(cdp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cd.coredocTree(docType)
+}
+
+// attrValueString returns the decoded value of the attribute. Errors are ignored since diff is informational.
+func attrValueString(attr Attribute) string {
+	str, _ := attr.Value.String()
+	return str
+}
+
+func diffAttributes(oldAttrs, newAttrs []Attribute) (diffs []AttributeDiff) {
+	oldMap := make(map[AttrKey]Attribute)
+	for _, attr := range oldAttrs {
+		oldMap[attr.Key] = attr
+	}
+
+	for _, attr := range newAttrs {
+		oa, ok := oldMap[attr.Key]
+		delete(oldMap, attr.Key)
+		ad := AttributeDiff{
+			KeyLabel: attr.KeyLabel,
+			Key:      attr.Key,
+			Type:     attr.Value.Type,
+			New:      attrValueString(attr),
+		}
+
+		if !ok {
+			ad.Action = DiffAdded
+			diffs = append(diffs, ad)
+			continue
+		}
+
+		ad.Old = attrValueString(oa)
+		if ad.Old == ad.New && oa.Value.Type == attr.Value.Type {
+			continue
+		}
+
+		ad.Action = DiffChanged
+		diffs = append(diffs, ad)
+	}
+
+	// maintain the order of old attributes for removed ones
+	for _, attr := range oldAttrs {
+		if _, ok := oldMap[attr.Key]; !ok {
+			continue
+		}
+
+		diffs = append(diffs, AttributeDiff{
+			Action:   DiffRemoved,
+			KeyLabel: attr.KeyLabel,
+			Key:      attr.Key,
+			Type:     attr.Value.Type,
+			Old:      attrValueString(attr),
+		})
+	}
+
+	return diffs
+}
+
+// diffByteSlices returns the items added to and removed from the old slice.
+func diffByteSlices(old, new [][]byte) (added, removed [][]byte) {
+	for _, n := range new {
+		if !byteutils.ContainsBytesInSlice(old, n) {
+			added = append(added, n)
+		}
+	}
+
+	for _, o := range old {
+		if !byteutils.ContainsBytesInSlice(new, o) {
+			removed = append(removed, o)
+		}
+	}
+
+	return added, removed
+}
+
+func diffRoles(oldRoles, newRoles []*coredocumentpb.Role) (diffs []RoleDiff) {
+	for _, nr := range newRoles {
+		or, err := getRole(nr.RoleKey, oldRoles)
+		if err != nil {
+			diffs = append(diffs, RoleDiff{Action: DiffAdded, RoleKey: nr.RoleKey, Added: nr.Collaborators})
+			continue
+		}
+
+		added, removed := diffByteSlices(or.Collaborators, nr.Collaborators)
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		diffs = append(diffs, RoleDiff{Action: DiffChanged, RoleKey: nr.RoleKey, Added: added, Removed: removed})
+	}
+
+	for _, or := range oldRoles {
+		if _, err := getRole(or.RoleKey, newRoles); err == nil {
+			continue
+		}
+
+		diffs = append(diffs, RoleDiff{Action: DiffRemoved, RoleKey: or.RoleKey, Removed: or.Collaborators})
+	}
+
+	return diffs
+}
+
+// readRuleKey returns a comparable key of the read rule since read rules do not have identifiers.
+func readRuleKey(rule *coredocumentpb.ReadRule) string {
+	key := rule.Action.String()
+	for _, r := range rule.Roles {
+		key += hexutil.Encode(r)
+	}
+
+	return key
+}
+
+func diffReadRules(oldRules, newRules []*coredocumentpb.ReadRule) (diffs []ReadRuleDiff) {
+	oldMap := make(map[string]struct{})
+	for _, rule := range oldRules {
+		oldMap[readRuleKey(rule)] = struct{}{}
+	}
+
+	newMap := make(map[string]struct{})
+	for _, rule := range newRules {
+		key := readRuleKey(rule)
+		newMap[key] = struct{}{}
+		if _, ok := oldMap[key]; ok {
+			continue
+		}
+
+		diffs = append(diffs, ReadRuleDiff{Action: DiffAdded, Roles: rule.Roles, ReadAction: rule.Action})
+	}
+
+	for _, rule := range oldRules {
+		if _, ok := newMap[readRuleKey(rule)]; ok {
+			continue
+		}
+
+		diffs = append(diffs, ReadRuleDiff{Action: DiffRemoved, Roles: rule.Roles, ReadAction: rule.Action})
+	}
+
+	return diffs
+}
+
+func diffNFTs(oldNFTs, newNFTs []*coredocumentpb.NFT) (added []*coredocumentpb.NFT) {
+	for _, nn := range newNFTs {
+		found := false
+		for _, on := range oldNFTs {
+			if bytes.Equal(on.RegistryId, nn.RegistryId) && bytes.Equal(on.TokenId, nn.TokenId) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			added = append(added, nn)
+		}
+	}
+
+	return added
+}
+
+func diffCollaborators(old, new Model) (diffs []CollaboratorDiff, err error) {
+	oca, err := old.GetCollaborators()
+	if err != nil {
+		return nil, err
+	}
+
+	nca, err := new.GetCollaborators()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range []struct {
+		old, new []identity.DID
+		write    bool
+	}{
+		{oca.ReadCollaborators, nca.ReadCollaborators, false},
+		{oca.ReadWriteCollaborators, nca.ReadWriteCollaborators, true},
+	} {
+		for _, did := range c.new {
+			if !containsDID(c.old, did) {
+				diffs = append(diffs, CollaboratorDiff{Action: DiffAdded, DID: did, Write: c.write})
+			}
+		}
+
+		for _, did := range c.old {
+			if !containsDID(c.new, did) {
+				diffs = append(diffs, CollaboratorDiff{Action: DiffRemoved, DID: did, Write: c.write})
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+func containsDID(dids []identity.DID, did identity.DID) bool {
+	for _, d := range dids {
+		if d.Equal(did) {
+			return true
+		}
+	}
+
+	return false
+}
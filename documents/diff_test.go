@@ -0,0 +1,93 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffVersions_errors(t *testing.T) {
+	m := new(MockModel)
+
+	// nil models
+	_, err := DiffVersions(nil, m)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrModelNil, err))
+
+	_, err = DiffVersions(m, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrModelNil, err))
+
+	// different documents
+	om := new(MockModel)
+	om.On("ID").Return(utils.RandomSlice(32))
+	nm := new(MockModel)
+	nm.On("ID").Return(utils.RandomSlice(32))
+	_, err = DiffVersions(om, nm)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentInvalid, err))
+}
+
+func TestDiffAttributes(t *testing.T) {
+	a1, err := NewStringAttribute("a1", AttrString, "one")
+	assert.NoError(t, err)
+	a2, err := NewStringAttribute("a2", AttrString, "two")
+	assert.NoError(t, err)
+	na2, err := NewStringAttribute("a2", AttrString, "three")
+	assert.NoError(t, err)
+	a3, err := NewStringAttribute("a3", AttrString, "four")
+	assert.NoError(t, err)
+
+	// no changes
+	assert.Len(t, diffAttributes([]Attribute{a1, a2}, []Attribute{a2, a1}), 0)
+
+	diffs := diffAttributes([]Attribute{a1, a2}, []Attribute{na2, a3})
+	assert.Len(t, diffs, 3)
+	assert.Equal(t, AttributeDiff{Action: DiffChanged, KeyLabel: "a2", Key: a2.Key, Type: AttrString, Old: "two", New: "three"}, diffs[0])
+	assert.Equal(t, AttributeDiff{Action: DiffAdded, KeyLabel: "a3", Key: a3.Key, Type: AttrString, New: "four"}, diffs[1])
+	assert.Equal(t, AttributeDiff{Action: DiffRemoved, KeyLabel: "a1", Key: a1.Key, Type: AttrString, Old: "one"}, diffs[2])
+}
+
+func TestDiffRoles(t *testing.T) {
+	c1, c2, c3 := utils.RandomSlice(20), utils.RandomSlice(20), utils.RandomSlice(20)
+	r1 := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32), Collaborators: [][]byte{c1, c2}}
+	r2 := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32), Collaborators: [][]byte{c1}}
+	nr2 := &coredocumentpb.Role{RoleKey: r2.RoleKey, Collaborators: [][]byte{c3}}
+	r3 := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32), Collaborators: [][]byte{c2}}
+
+	// no changes
+	assert.Len(t, diffRoles([]*coredocumentpb.Role{r1}, []*coredocumentpb.Role{r1}), 0)
+
+	diffs := diffRoles([]*coredocumentpb.Role{r1, r2}, []*coredocumentpb.Role{nr2, r3})
+	assert.Len(t, diffs, 3)
+	assert.Equal(t, RoleDiff{Action: DiffChanged, RoleKey: r2.RoleKey, Added: [][]byte{c3}, Removed: [][]byte{c1}}, diffs[0])
+	assert.Equal(t, RoleDiff{Action: DiffAdded, RoleKey: r3.RoleKey, Added: [][]byte{c2}}, diffs[1])
+	assert.Equal(t, RoleDiff{Action: DiffRemoved, RoleKey: r1.RoleKey, Removed: [][]byte{c1, c2}}, diffs[2])
+}
+
+func TestDiffReadRules(t *testing.T) {
+	role1, role2 := utils.RandomSlice(32), utils.RandomSlice(32)
+	rr1 := &coredocumentpb.ReadRule{Roles: [][]byte{role1}, Action: coredocumentpb.Action_ACTION_READ_SIGN}
+	rr2 := &coredocumentpb.ReadRule{Roles: [][]byte{role2}, Action: coredocumentpb.Action_ACTION_READ}
+
+	// no changes
+	assert.Len(t, diffReadRules([]*coredocumentpb.ReadRule{rr1}, []*coredocumentpb.ReadRule{rr1}), 0)
+
+	diffs := diffReadRules([]*coredocumentpb.ReadRule{rr1}, []*coredocumentpb.ReadRule{rr2})
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, ReadRuleDiff{Action: DiffAdded, Roles: rr2.Roles, ReadAction: rr2.Action}, diffs[0])
+	assert.Equal(t, ReadRuleDiff{Action: DiffRemoved, Roles: rr1.Roles, ReadAction: rr1.Action}, diffs[1])
+}
+
+func TestDiffNFTs(t *testing.T) {
+	n1 := &coredocumentpb.NFT{RegistryId: utils.RandomSlice(32), TokenId: utils.RandomSlice(32)}
+	n2 := &coredocumentpb.NFT{RegistryId: n1.RegistryId, TokenId: utils.RandomSlice(32)}
+
+	assert.Len(t, diffNFTs([]*coredocumentpb.NFT{n1}, []*coredocumentpb.NFT{n1}), 0)
+	assert.Equal(t, []*coredocumentpb.NFT{n2}, diffNFTs([]*coredocumentpb.NFT{n1}, []*coredocumentpb.NFT{n1, n2}))
+}
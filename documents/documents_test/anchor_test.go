@@ -173,3 +173,98 @@ func TestAnchorDocument(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, model)
 }
+
+func TestResumeAnchorDocument(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	updater := func(id []byte, model documents.Model) error {
+		return nil
+	}
+
+	var stages []documents.AnchorStage
+	recorder := func(model documents.Model, stage documents.AnchorStage) error {
+		stages = append(stages, stage)
+		return nil
+	}
+
+	// fresh anchoring records every stage
+	id := utils.RandomSlice(32)
+	m := &documents.MockModel{}
+	m.On("CurrentVersion").Return(id).Once()
+	m.On("SetStatus", documents.Committed).Return(nil)
+	proc := &mockAnchorProcessor{}
+	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
+	proc.On("PreAnchorDocument", ctxh, m).Return(nil).Once()
+	proc.On("RequestSignatures", ctxh, m).Return(nil).Once()
+	proc.On("PrepareForAnchoring", m).Return(nil).Once()
+	proc.On("AnchorDocument", m).Return(nil).Once()
+	proc.On("SendDocument", ctxh, m).Return(nil).Once()
+	model, err := documents.ResumeAnchorDocument(ctxh, m, proc, updater, recorder, true, documents.AnchorStageNone)
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.NotNil(t, model)
+	assert.Equal(t, []documents.AnchorStage{
+		documents.AnchorStagePrepared,
+		documents.AnchorStagePrecommitted,
+		documents.AnchorStageSigned,
+		documents.AnchorStageAnchored,
+		documents.AnchorStageSent,
+	}, stages)
+
+	// failed stage is not recorded
+	stages = nil
+	m = &documents.MockModel{}
+	m.On("CurrentVersion").Return(id).Once()
+	proc = &mockAnchorProcessor{}
+	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
+	proc.On("PreAnchorDocument", ctxh, m).Return(nil).Once()
+	proc.On("RequestSignatures", ctxh, m).Return(errors.New("error")).Once()
+	model, err = documents.ResumeAnchorDocument(ctxh, m, proc, updater, recorder, true, documents.AnchorStageNone)
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Nil(t, model)
+	assert.Equal(t, []documents.AnchorStage{documents.AnchorStagePrepared, documents.AnchorStagePrecommitted}, stages)
+
+	// resume after the signatures are collected
+	stages = nil
+	m = &documents.MockModel{}
+	m.On("CurrentVersion").Return(id).Once()
+	m.On("SetStatus", documents.Committed).Return(nil)
+	proc = &mockAnchorProcessor{}
+	proc.On("PrepareForAnchoring", m).Return(nil).Once()
+	proc.On("AnchorDocument", m).Return(nil).Once()
+	proc.On("SendDocument", ctxh, m).Return(nil).Once()
+	model, err = documents.ResumeAnchorDocument(ctxh, m, proc, updater, recorder, true, documents.AnchorStageSigned)
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.NotNil(t, model)
+	assert.Equal(t, []documents.AnchorStage{documents.AnchorStageAnchored, documents.AnchorStageSent}, stages)
+
+	// resume sending the anchored document
+	stages = nil
+	m = &documents.MockModel{}
+	m.On("CurrentVersion").Return(id).Once()
+	proc = &mockAnchorProcessor{}
+	proc.On("SendDocument", ctxh, m).Return(nil).Once()
+	model, err = documents.ResumeAnchorDocument(ctxh, m, proc, updater, recorder, true, documents.AnchorStageAnchored)
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.NotNil(t, model)
+	assert.Equal(t, []documents.AnchorStage{documents.AnchorStageSent}, stages)
+
+	// failed to record the stage
+	m = &documents.MockModel{}
+	m.On("CurrentVersion").Return(id).Once()
+	proc = &mockAnchorProcessor{}
+	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
+	model, err = documents.ResumeAnchorDocument(ctxh, m, proc, updater, func(documents.Model, documents.AnchorStage) error {
+		return errors.New("error")
+	}, false, documents.AnchorStageNone)
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.Nil(t, model)
+}
@@ -0,0 +1,80 @@
+// +build unit
+
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_DryRun(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	g, cd := generic.CreateGenericWithEmbedCD(t, ctxh, did, []identity.DID{testingidentity.GenerateRandomDID()})
+	sr, err := g.CalculateSigningRoot()
+	assert.NoError(t, err)
+	sigs := len(g.Signatures())
+
+	// unknown scheme
+	srv := documents.DefaultService(cfg, testRepo(), nil, documents.NewServiceRegistry(), nil, nil, nil, notification.NewEventBus())
+	_, err = srv.DryRun(ctxh, g)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentSchemeUnknown, err))
+
+	copyDoc := func() documents.Model {
+		c := new(generic.Generic)
+		assert.NoError(t, c.UnpackCoreDocument(cd))
+		return c
+	}
+
+	idSrv := new(testingcommons.MockIdentityService)
+	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// valid
+	reg := documents.NewServiceRegistry()
+	genSrv := new(testingdocuments.MockService)
+	assert.NoError(t, reg.Register(generic.Scheme, genSrv))
+	genSrv.On("DeriveFromCoreDocument", mock.Anything).Return(copyDoc(), nil).Once()
+	genSrv.On("Validate", ctxh, mock.Anything, nil).Return(nil).Once()
+	anchorSrv := new(mockAnchorRepo)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("missing"))
+	srv = documents.DefaultService(cfg, testRepo(), anchorSrv, reg, idSrv, nil, nil, notification.NewEventBus())
+	failures, err := srv.DryRun(ctxh, g)
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+
+	// version anchored and scheme validation failed
+	aid, err := anchors.ToAnchorID(g.CurrentVersion())
+	assert.NoError(t, err)
+	anchorSrv = new(mockAnchorRepo)
+	anchorSrv.On("GetAnchorData", aid).Return(anchors.DocumentRoot{}, time.Now(), nil)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("missing"))
+	genSrv.On("DeriveFromCoreDocument", mock.Anything).Return(copyDoc(), nil).Once()
+	genSrv.On("Validate", ctxh, mock.Anything, nil).Return(errors.AppendError(errors.New("invalid data"), errors.New("invalid attribute"))).Once()
+	srv = documents.DefaultService(cfg, testRepo(), anchorSrv, reg, idSrv, nil, nil, notification.NewEventBus())
+	failures, err = srv.DryRun(ctxh, g)
+	assert.NoError(t, err)
+	assert.Len(t, failures, 3)
+	assert.Equal(t, documents.CheckVersionNotAnchored, failures[0].Check)
+	assert.Equal(t, documents.ValidationFailure{Check: documents.CheckScheme, Error: "invalid data"}, failures[1])
+	assert.Equal(t, documents.ValidationFailure{Check: documents.CheckScheme, Error: "invalid attribute"}, failures[2])
+	genSrv.AssertExpectations(t)
+
+	// model is not modified
+	gsr, err := g.CalculateSigningRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, sr, gsr)
+	assert.Len(t, g.Signatures(), sigs)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -57,7 +58,7 @@ func TestMain(m *testing.M) {
 }
 
 func TestService_ReceiveAnchoredDocument(t *testing.T) {
-	srv := documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), nil, nil, nil, notification.NewEventBus())
 
 	// self failed
 	err := srv.ReceiveAnchoredDocument(context.Background(), nil, did)
@@ -88,7 +89,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	nextAid, err := anchors.ToAnchorID(doc.NextVersion())
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, notification.NewEventBus())
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(documents.ErrDocumentPersistence, err))
@@ -108,11 +109,16 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	assert.NoError(t, err)
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, notification.NewEventBus())
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.NoError(t, err)
 	ar.AssertExpectations(t)
 	idSrv.AssertExpectations(t)
+	items, _, err := srv.ListInbox(ctxh, documents.InboxFilter{DocumentID: doc.ID()})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, doc.CurrentVersion(), []byte(items[0].VersionID))
+	assert.Equal(t, did, items[0].Sender)
 
 	// prepare a new version
 	err = doc.AddNFT(true, testingidentity.GenerateRandomDID().ToAddress(), utils.RandomSlice(32))
@@ -147,7 +153,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
 
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, notification.NewEventBus())
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, id2)
 	assert.NoError(t, err)
 	ar.AssertExpectations(t)
@@ -159,7 +165,7 @@ func getServiceWithMockedLayers() (documents.Service, testingcommons.MockIdentit
 	idService := testingcommons.MockIdentityService{}
 	idService.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	mockAnchor = &mockAnchorRepo{}
-	return documents.DefaultService(cfg, repo, mockAnchor, documents.NewServiceRegistry(), &idService, nil, nil), idService
+	return documents.DefaultService(cfg, repo, mockAnchor, documents.NewServiceRegistry(), &idService, nil, nil, notification.NewEventBus()), idService
 }
 
 type mockAnchorRepo struct {
@@ -268,7 +274,7 @@ func TestService_RequestDocumentSignature(t *testing.T) {
 	doc, _ = createCDWithEmbeddedDocument(t, ctxh, []identity.DID{id}, false)
 	idSrv := new(testingcommons.MockIdentityService)
 	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	srv = documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idSrv, nil, nil, notification.NewEventBus())
 
 	// prepare a new version
 	err = doc.AddNFT(true, testingidentity.GenerateRandomDID().ToAddress(), utils.RandomSlice(32))
@@ -485,7 +491,7 @@ func TestService_CreateModel(t *testing.T) {
 	invSrv.On("CreateModel", mock.Anything, mock.Anything).Return(m, jobs.NewJobID(), nil).Once()
 	err := reg.Register("generic", invSrv)
 	assert.NoError(t, err)
-	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil, notification.NewEventBus())
 
 	// unknown scheme
 	payload := documents.CreatePayload{Scheme: "invalid_scheme"}
@@ -508,7 +514,7 @@ func TestService_UpdateModel(t *testing.T) {
 	invSrv.On("UpdateModel", mock.Anything, mock.Anything).Return(m, jobs.NewJobID(), nil).Once()
 	err := reg.Register("generic", invSrv)
 	assert.NoError(t, err)
-	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil, notification.NewEventBus())
 
 	// unknown scheme
 	payload := documents.UpdatePayload{CreatePayload: documents.CreatePayload{Scheme: "unknown_service"}}
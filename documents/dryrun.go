@@ -0,0 +1,139 @@
+package documents
+
+import (
+	"context"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/golang/protobuf/proto"
+)
+
+// Names of the checks run by the dry run validation.
+const (
+	CheckApprovals          = "approvals"
+	CheckVersion            = "version"
+	CheckVersionNotAnchored = "version_not_anchored"
+	CheckTransition         = "transition"
+	CheckScheme             = "scheme"
+	CheckPreAnchor          = "pre_anchor"
+	CheckPrecommitInputs    = "precommit_inputs"
+)
+
+// ValidationFailure is a failure of a check run against the document.
+type ValidationFailure struct {
+	Check string `json:"check"`
+	Error string `json:"error"`
+}
+
+// NewValidationFailures returns a failure of the check for each error in err.
+func NewValidationFailures(check string, err error) []ValidationFailure {
+	var failures []ValidationFailure
+	for _, e := range errors.GetErrs(err) {
+		failures = append(failures, ValidationFailure{Check: check, Error: e.Error()})
+	}
+
+	return failures
+}
+
+// precommitInputsValidator checks that the anchor ID and the signing root of the model are well formed for the pre-commit.
+// Pre-commits already submitted to the chain are not checked since the anchor service doesn't expose them.
+func precommitInputsValidator() Validator {
+	return ValidatorFunc(func(_, model Model) error {
+		if model == nil {
+			return ErrModelNil
+		}
+
+		if _, err := anchors.ToAnchorID(model.CurrentVersion()); err != nil {
+			return errors.New("failed to get anchorID: %v", err)
+		}
+
+		sr, err := model.CalculateSigningRoot()
+		if err != nil {
+			return errors.New("failed to get signing root: %v", err)
+		}
+
+		if _, err := anchors.ToDocumentRoot(sr); err != nil {
+			return errors.New("failed to get signing root: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// copyModel returns a deep copy of the model.
+func (s service) copyModel(model Model) (Model, error) {
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.DeriveFromCoreDocument(*proto.Clone(&cd).(*coredocumentpb.CoreDocument))
+}
+
+// DryRun runs the checks applied on commit, by the account and its collaborators, against a copy of the model
+// and the latest committed version of the document. The copy is signed by the account to run the pre anchor validations.
+// Returns every failure. Neither the model nor the repository is modified.
+func (s service) DryRun(ctx context.Context, model Model) ([]ValidationFailure, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	srv, err := s.registry.LocateService(model.Scheme())
+	if err != nil {
+		return nil, errors.NewTypedError(ErrDocumentSchemeUnknown, err)
+	}
+
+	// Get latest committed version
+	old, err := s.GetCurrentVersion(ctx, model.ID())
+	if err != nil && !errors.IsOfType(ErrDocumentNotFound, err) {
+		return nil, err
+	}
+
+	doc, err := s.copyModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	versionValidator := baseValidator()
+	if old != nil {
+		versionValidator = versionIDsValidator()
+	}
+
+	checks := []struct {
+		name string
+		v    Validator
+	}{
+		{CheckVersion, versionValidator},
+		{CheckVersionNotAnchored, ValidatorGroup{currentVersionValidator(s.anchorSrv), LatestVersionValidator(s.anchorSrv)}},
+		{CheckTransition, transitionValidator(did)},
+		{CheckScheme, ValidatorFunc(func(old, new Model) error {
+			return srv.Validate(ctx, new, old)
+		})},
+	}
+
+	var failures []ValidationFailure
+	for _, c := range checks {
+		failures = append(failures, NewValidationFailures(c.name, c.v.Validate(old, doc))...)
+	}
+
+	err = DefaultProcessor(s.idService, nil, s.anchorSrv, s.config, nil, s.events).PrepareForSignatureRequests(ctx, doc)
+	if err != nil {
+		failures = append(failures, NewValidationFailures(CheckPreAnchor, errors.New("failed to sign the document: %v", err))...)
+		return failures, nil
+	}
+
+	failures = append(failures, NewValidationFailures(CheckPreAnchor, PreAnchorValidator(s.idService, s.anchorSrv).Validate(nil, doc))...)
+	if acc.GetPrecommitEnabled() {
+		failures = append(failures, NewValidationFailures(CheckPrecommitInputs, precommitInputsValidator().Validate(nil, doc))...)
+	}
+
+	return failures, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/testingutils/anchors"
@@ -40,7 +41,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, *testingc
 	repo := testRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
-	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil)
+	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, notification.NewEventBus())
 	return idService, idFactory, DefaultService(
 		docSrv,
 		repo,
@@ -280,7 +281,7 @@ func setupRelationshipTesting(t *testing.T) (context.Context, documents.Model, *
 //	mockAnchor := &mockAnchorSrv{}
 //	docSrv := testingdocuments.MockService{}
 //	mockedERSrv := &MockEntityRelationService{}
-//	mockProcessor := &testingcommons.MockRequestProcessor{}
+//	mockProcessor := &testingdocuments.MockRequestProcessor{}
 //
 //	docSrv.On("GetCurrentVersion", eID).Return(entity, nil)
 //	docSrv.On("Exists").Return(true)
@@ -313,7 +314,7 @@ func TestService_GetEntityByRelationship_fail(t *testing.T) {
 	mockAnchor := &mockAnchorSrv{}
 	docSrv := testingdocuments.MockService{}
 	mockedERSrv := &MockEntityRelationService{}
-	mockProcessor := &testingcommons.MockRequestProcessor{}
+	mockProcessor := &testingdocuments.MockRequestProcessor{}
 
 	mockedERSrv.On("GetCurrentVersion", er.ID()).Return(er, entityrelationship.ErrERNotFound)
 
@@ -361,7 +362,7 @@ func TestService_GetEntityByRelationship_requestP2P(t *testing.T) {
 	mockAnchor := &mockAnchorSrv{}
 	docSrv := testingdocuments.MockService{}
 	mockedERSrv := &MockEntityRelationService{}
-	mockProcessor := &testingcommons.MockRequestProcessor{}
+	mockProcessor := &testingdocuments.MockRequestProcessor{}
 
 	docSrv.On("GetCurrentVersion", eID).Return(entity, nil)
 	docSrv.On("Exists").Return(true).Once()
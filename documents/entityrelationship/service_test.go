@@ -11,6 +11,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/testingutils/anchors"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
@@ -35,7 +36,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, *testingc
 	entityRepo := testEntityRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
-	docSrv := documents.DefaultService(cfg, entityRepo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil)
+	docSrv := documents.DefaultService(cfg, entityRepo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, notification.NewEventBus())
 	return idService, idFactory, DefaultService(
 		docSrv,
 		entityRepo,
@@ -163,6 +163,72 @@ const (
 
 	// ErrTransitionRuleMissing is a sentinel error used when transition rule is missing from the document.
 	ErrTransitionRuleMissing = errors.Error("transition rule missing")
+
+	// ErrInvalidValueConstraint must be used when the value constraint of an attribute is malformed.
+	ErrInvalidValueConstraint = errors.Error("invalid value constraint")
+
+	// ErrValueConstraintViolation must be used when an attribute change doesn't satisfy its value constraint.
+	ErrValueConstraintViolation = errors.Error("value constraint violation")
+
+	// ErrInvalidValidity must be used when the validity window of a role or read rule is malformed.
+	ErrInvalidValidity = errors.Error("invalid validity window")
+
+	// ErrValidityChangeNotAllowed must be used when a collaborator outside the authoring role changes a validity window.
+	ErrValidityChangeNotAllowed = errors.Error("validity window change not allowed")
+
+	// ErrInvalidApprovalPolicy must be used when the approval policy of a document is malformed.
+	ErrInvalidApprovalPolicy = errors.Error("invalid approval policy")
+
+	// ErrApprovalThreshold must be used when a document doesn't have the approvals required by its policy.
+	ErrApprovalThreshold = errors.Error("approval threshold not met")
+
+	// ErrApprovalPolicyMissing must be used when a document doesn't have an approval policy.
+	ErrApprovalPolicyMissing = errors.Error("approval policy missing")
+
+	// ErrInvalidApproval must be used when an approval is not of an approver or not bound to the current version.
+	ErrInvalidApproval = errors.Error("invalid approval")
+
+	// ErrReservedAttribute must be used when an attribute provided by the user uses a label reserved for the document metadata.
+	ErrReservedAttribute = errors.Error("attribute label is reserved")
+
+	// ErrInvalidBundle must be used when a document bundle is malformed or doesn't match its document.
+	ErrInvalidBundle = errors.Error("invalid document bundle")
+
+	// ErrDocumentReadOnly must be used when a new version of a document imported from a bundle is created.
+	ErrDocumentReadOnly = errors.Error("document is read-only")
+
+	// ErrInvalidProofLabel must be used when a proof label can't be resolved to a document field.
+	ErrInvalidProofLabel = errors.Error("invalid proof label")
+
+	// ErrInvalidAttachment must be used when an attachment is malformed or doesn't match its content.
+	ErrInvalidAttachment = errors.Error("invalid attachment")
+
+	// ErrAttachmentNotFound must be used when an attachment or its content is not found.
+	ErrAttachmentNotFound = errors.Error("attachment not found")
+
+	// ErrDocumentForked must be used when the version is anchored by another collaborator.
+	ErrDocumentForked = errors.Error("document version is anchored by another collaborator")
+
+	// ErrForkNotFound must be used when a fork of the document version is not found.
+	ErrForkNotFound = errors.Error("fork not found")
+
+	// ErrInboxItemNotFound must be used when an inbox item is not found.
+	ErrInboxItemNotFound = errors.Error("inbox item not found")
+
+	// ErrInvalidSignaturePolicy must be used when the signature policy of a document is malformed.
+	ErrInvalidSignaturePolicy = errors.Error("invalid signature policy")
+
+	// ErrSignaturePolicyNotMet must be used when the collected signatures don't satisfy the signature policy.
+	ErrSignaturePolicyNotMet = errors.Error("signature policy not met")
+
+	// ErrSignatureRejected must be used when a collaborator refuses to sign the document.
+	ErrSignatureRejected = errors.Error("collaborator rejected the signature request")
+
+	// ErrAnchorStateNotFound must be used when the anchoring stage of a document version is not found.
+	ErrAnchorStateNotFound = errors.Error("anchor state not found")
+
+	// ErrCommitNotRetryable must be used when the anchoring of a document version cannot be resumed.
+	ErrCommitNotRetryable = errors.Error("commit cannot be retried")
 )
 
 // Error wraps an error with specific key
@@ -0,0 +1,65 @@
+package documents
+
+import (
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// publishVersion publishes the event of the anchored version along with the NFTs added since the old version.
+// old can be nil if the previous version is not known.
+func publishVersion(bus notification.EventBus, eventType notification.DocumentEventType, accountID identity.DID, collaborator *identity.DID, old, model Model) {
+	if bus == nil {
+		return
+	}
+
+	event := notification.DocumentEvent{
+		Type:         eventType,
+		AccountID:    accountID,
+		DocumentID:   model.ID(),
+		VersionID:    model.CurrentVersion(),
+		Scheme:       model.Scheme(),
+		Collaborator: collaborator,
+	}
+	bus.Publish(event)
+
+	var oldNFTs []*coredocumentpb.NFT
+	if old != nil {
+		oldNFTs = old.NFTs()
+	}
+
+	for _, nft := range diffNFTs(oldNFTs, model.NFTs()) {
+		registry := nft.RegistryId
+		if len(registry) > common.AddressLength {
+			registry = registry[:common.AddressLength]
+		}
+
+		event.Type = notification.NFTAdded
+		event.Registry = common.BytesToAddress(registry).Hex()
+		event.TokenID = nft.TokenId
+		bus.Publish(event)
+	}
+}
+
+// publishSignatures publishes the signatures of the version collected from the collaborators.
+func publishSignatures(bus notification.EventBus, accountID identity.DID, model Model, sigs []*coredocumentpb.Signature) {
+	if bus == nil {
+		return
+	}
+
+	for _, sig := range sigs {
+		signer, err := identity.NewDIDFromBytes(sig.SignerId)
+		if err != nil {
+			continue
+		}
+
+		bus.Publish(notification.DocumentEvent{
+			Type:         notification.SignatureReceived,
+			AccountID:    accountID,
+			DocumentID:   model.ID(),
+			VersionID:    model.CurrentVersion(),
+			Collaborator: &signer,
+		})
+	}
+}
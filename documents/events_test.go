@@ -0,0 +1,50 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishVersion(t *testing.T) {
+	bus := notification.NewEventBus()
+	accountID, collaborator := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	docID := utils.RandomSlice(32)
+	sub := bus.Subscribe(accountID, docID)
+	defer sub.Close()
+
+	oldNFT := &coredocumentpb.NFT{RegistryId: utils.RandomSlice(32), TokenId: utils.RandomSlice(32)}
+	newNFT := &coredocumentpb.NFT{RegistryId: utils.RandomSlice(32), TokenId: utils.RandomSlice(32)}
+	old := new(MockModel)
+	old.On("NFTs").Return([]*coredocumentpb.NFT{oldNFT})
+	model := new(MockModel)
+	model.On("ID").Return(docID)
+	model.On("CurrentVersion").Return(utils.RandomSlice(32))
+	model.On("Scheme").Return("generic")
+	model.On("NFTs").Return([]*coredocumentpb.NFT{oldNFT, newNFT})
+
+	// no event bus
+	publishVersion(nil, notification.VersionReceived, accountID, &collaborator, old, model)
+	assert.Len(t, sub.Events(), 0)
+
+	publishVersion(bus, notification.VersionReceived, accountID, &collaborator, old, model)
+	event := <-sub.Events()
+	assert.Equal(t, notification.VersionReceived, event.Type)
+	assert.Equal(t, collaborator, *event.Collaborator)
+	event = <-sub.Events()
+	assert.Equal(t, notification.NFTAdded, event.Type)
+	assert.Equal(t, common.BytesToAddress(newNFT.RegistryId[:common.AddressLength]).Hex(), event.Registry)
+	assert.Equal(t, newNFT.TokenId, []byte(event.TokenID))
+	assert.Len(t, sub.Events(), 0)
+
+	// unknown previous version
+	publishVersion(bus, notification.VersionCommitted, accountID, nil, nil, model)
+	assert.Len(t, sub.Events(), 3)
+}
@@ -0,0 +1,295 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/golang/protobuf/proto"
+)
+
+// Fork records a local version of the document that lost against a version, with the same version ID,
+// derived from the same previous version and anchored by another collaborator.
+type Fork struct {
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID       byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	PreviousVersion byteutils.HexBytes `json:"previous_version" swaggertype:"primitive,string"`
+	Scheme          string             `json:"scheme"`
+
+	// LocalSigningRoot is the signing root of the local version.
+	LocalSigningRoot byteutils.HexBytes `json:"local_signing_root" swaggertype:"primitive,string"`
+
+	// AnchoredDocumentRoot is the document root anchored by the other collaborator.
+	AnchoredDocumentRoot byteutils.HexBytes `json:"anchored_document_root" swaggertype:"primitive,string"`
+
+	DetectedAt time.Time `json:"detected_at" swaggertype:"primitive,string"`
+
+	// LocalDocument is the marshalled core document of the local version.
+	LocalDocument []byte `json:"local_document"`
+}
+
+// JSON marshals Fork to json bytes.
+func (f *Fork) JSON() ([]byte, error) {
+	return json.Marshal(f)
+}
+
+// Type returns the type of Fork.
+func (f *Fork) Type() reflect.Type {
+	return reflect.TypeOf(f)
+}
+
+// FromJSON loads json bytes to Fork.
+func (f *Fork) FromJSON(data []byte) error {
+	return json.Unmarshal(data, f)
+}
+
+// CoreDocument returns the core document of the local version.
+func (f *Fork) CoreDocument() (cd coredocumentpb.CoreDocument, err error) {
+	return cd, proto.Unmarshal(f.LocalDocument, &cd)
+}
+
+// NewFork returns the fork of the local version against the anchored document root.
+func NewFork(local Model, anchoredRoot []byte) (*Fork, error) {
+	cd, err := local.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&cd)
+	if err != nil {
+		return nil, err
+	}
+
+	// signing root is not available if the local version was never prepared for anchoring
+	sr, _ := local.CalculateSigningRoot()
+	return &Fork{
+		DocumentID:           local.ID(),
+		VersionID:            local.CurrentVersion(),
+		PreviousVersion:      local.PreviousVersion(),
+		Scheme:               local.Scheme(),
+		LocalSigningRoot:     sr,
+		AnchoredDocumentRoot: anchoredRoot,
+		DetectedAt:           time.Now().UTC(),
+		LocalDocument:        data,
+	}, nil
+}
+
+// DetectFork returns the fork if the version ID of the local version is anchored, by another collaborator,
+// with a different document root. That is, the version fails versionNotAnchoredValidator without being ours.
+// Returns nil if the local version is not forked.
+func DetectFork(anchorSrv anchors.Service, local Model) (*Fork, error) {
+	if local.GetStatus() == Committed || versionNotAnchoredValidator(anchorSrv, local.CurrentVersion()) == nil {
+		return nil, nil
+	}
+
+	anchorID, err := anchors.ToAnchorID(local.CurrentVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	root, _, err := anchorSrv.GetAnchorData(anchorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// local version might have been anchored without the status being updated
+	if lr, err := local.CalculateDocumentRoot(); err == nil && bytes.Equal(lr, root[:]) {
+		return nil, nil
+	}
+
+	return NewFork(local, root[:])
+}
+
+// detectReceivedFork returns the fork if the local version, with the same version ID as the received anchored version,
+// is not the received version. Returns nil if the local version is not forked.
+func detectReceivedFork(local, anchored Model) (*Fork, error) {
+	if local.GetStatus() == Committed {
+		return nil, nil
+	}
+
+	ar, err := anchored.CalculateSigningRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	// local version is the copy signed for the anchored version
+	if lr, err := local.CalculateSigningRoot(); err == nil && bytes.Equal(lr, ar) {
+		return nil, nil
+	}
+
+	root, err := anchored.CalculateDocumentRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFork(local, root)
+}
+
+// Rebase holds the changes made in the local version of a fork, since the common previous version,
+// to be applied on the winning version.
+// Signed attributes, roles, rules, and NFTs are not rebased since they are bound to the local version.
+type Rebase struct {
+	// Payload derives the next version of the winning version with the added and changed attributes,
+	// added collaborators, and data of the local version.
+	Payload UpdatePayload
+
+	// RemovedAttributes are the attributes removed in the local version and still present in the winning version.
+	RemovedAttributes []AttrKey
+
+	// ChangedFields are the changed leaves of the local version compared to the previous version.
+	ChangedFields []ChangedField
+}
+
+// NewRebase computes the changes made in the local version since the base version to be applied on the winning version.
+func NewRebase(base, local, winning Model) (rb Rebase, err error) {
+	diff, err := DiffVersions(base, local)
+	if err != nil {
+		return rb, err
+	}
+
+	rb.ChangedFields = diff.ChangedFields
+	rb.Payload.DocumentID = winning.ID()
+	rb.Payload.Scheme = winning.Scheme()
+	rb.Payload.Attributes = make(map[AttrKey]Attribute)
+	for _, ad := range diff.Attributes {
+		if ad.Action == DiffRemoved {
+			if winning.AttributeExists(ad.Key) {
+				rb.RemovedAttributes = append(rb.RemovedAttributes, ad.Key)
+			}
+
+			continue
+		}
+
+		attr, err := local.GetAttribute(ad.Key)
+		if err != nil {
+			return rb, err
+		}
+
+		if attr.Value.Type == AttrSigned {
+			continue
+		}
+
+		rb.Payload.Attributes[ad.Key] = attr
+	}
+
+	for _, cd := range diff.Collaborators {
+		if cd.Action != DiffAdded {
+			continue
+		}
+
+		if cd.Write {
+			rb.Payload.Collaborators.ReadWriteCollaborators = append(rb.Payload.Collaborators.ReadWriteCollaborators, cd.DID)
+			continue
+		}
+
+		rb.Payload.Collaborators.ReadCollaborators = append(rb.Payload.Collaborators.ReadCollaborators, cd.DID)
+	}
+
+	rb.Payload.Data, err = rebaseData(base, local, winning)
+	return rb, err
+}
+
+// rebaseData returns the data of the local version if changed since the base version, else the data of the winning version.
+func rebaseData(base, local, winning Model) ([]byte, error) {
+	bd, err := json.Marshal(base.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	ld, err := json.Marshal(local.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(bd, ld) {
+		return ld, nil
+	}
+
+	return json.Marshal(winning.GetData())
+}
+
+// Apply removes the attributes removed in the local version from the rebased version.
+func (rb Rebase) Apply(rebased Model) error {
+	for _, key := range rb.RemovedAttributes {
+		if err := rebased.DeleteAttribute(key, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetForks returns the forked versions of the document recorded for the account.
+func (s service) GetForks(ctx context.Context, documentID []byte) ([]*Fork, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.GetForks(acc.GetIdentityID(), documentID)
+}
+
+// Rebase computes the changes made in the forked version since its previous version
+// to be applied on the latest committed version of the document.
+func (s service) Rebase(ctx context.Context, documentID, versionID []byte) (rb Rebase, err error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return rb, ErrDocumentConfigAccountID
+	}
+
+	fork, err := s.repo.GetFork(acc.GetIdentityID(), documentID, versionID)
+	if err != nil {
+		return rb, err
+	}
+
+	cd, err := fork.CoreDocument()
+	if err != nil {
+		return rb, err
+	}
+
+	local, err := s.DeriveFromCoreDocument(cd)
+	if err != nil {
+		return rb, err
+	}
+
+	base, err := s.GetVersion(ctx, documentID, fork.PreviousVersion)
+	if err != nil {
+		return rb, err
+	}
+
+	winning, err := s.GetCurrentVersion(ctx, documentID)
+	if err != nil {
+		return rb, err
+	}
+
+	// the forked version is the latest until the anchored version is received
+	if winning.GetStatus() != Committed {
+		return rb, errors.NewTypedError(ErrDocumentNotFound, errors.New("anchored version %s is not received yet", fork.VersionID.String()))
+	}
+
+	return NewRebase(base, local, winning)
+}
+
+// recordReceivedFork records the local version, with the same version ID as the received anchored version, if forked.
+func (s service) recordReceivedFork(accountID []byte, anchored Model) error {
+	local, err := s.repo.Get(accountID, anchored.CurrentVersion())
+	if err != nil {
+		// no local version
+		return nil
+	}
+
+	fork, err := detectReceivedFork(local, anchored)
+	if err != nil || fork == nil {
+		return err
+	}
+
+	srvLog.Warningf("local version %x of document %x lost against the received anchored version", fork.VersionID, fork.DocumentID)
+	return s.repo.StoreFork(accountID, fork)
+}
@@ -0,0 +1,148 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockForkedModel(docID, versionID []byte) *MockModel {
+	local := new(MockModel)
+	local.On("GetStatus").Return(Pending)
+	local.On("ID").Return(docID)
+	local.On("CurrentVersion").Return(versionID)
+	local.On("PreviousVersion").Return(utils.RandomSlice(32))
+	local.On("Scheme").Return("generic")
+	local.On("PackCoreDocument").Return(coredocumentpb.CoreDocument{DocumentIdentifier: docID, CurrentVersion: versionID}, nil)
+	local.On("CalculateSigningRoot").Return(utils.RandomSlice(32), nil)
+	return local
+}
+
+func TestDetectFork(t *testing.T) {
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	aid, err := anchors.ToAnchorID(versionID)
+	assert.NoError(t, err)
+
+	// committed version
+	local := new(MockModel)
+	local.On("GetStatus").Return(Committed).Once()
+	fork, err := DetectFork(mockAnchorService{}, local)
+	assert.NoError(t, err)
+	assert.Nil(t, fork)
+
+	// version not anchored
+	local = mockForkedModel(docID, versionID)
+	anchorSrv := mockAnchorService{}
+	anchorSrv.On("GetAnchorData", aid).Return(nil, time.Now(), errors.New("missing")).Once()
+	fork, err = DetectFork(anchorSrv, local)
+	assert.NoError(t, err)
+	assert.Nil(t, fork)
+
+	// version anchored with the local document root
+	root := utils.RandomSlice(32)
+	dr, err := anchors.ToDocumentRoot(root)
+	assert.NoError(t, err)
+	anchorSrv = mockAnchorService{}
+	anchorSrv.On("GetAnchorData", aid).Return(dr, time.Now(), nil)
+	local.On("CalculateDocumentRoot").Return(root, nil).Once()
+	fork, err = DetectFork(anchorSrv, local)
+	assert.NoError(t, err)
+	assert.Nil(t, fork)
+
+	// version anchored by another collaborator
+	local.On("CalculateDocumentRoot").Return(utils.RandomSlice(32), nil).Once()
+	fork, err = DetectFork(anchorSrv, local)
+	assert.NoError(t, err)
+	assert.NotNil(t, fork)
+	assert.Equal(t, docID, []byte(fork.DocumentID))
+	assert.Equal(t, versionID, []byte(fork.VersionID))
+	assert.Equal(t, root, []byte(fork.AnchoredDocumentRoot))
+	cd, err := fork.CoreDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, docID, cd.DocumentIdentifier)
+}
+
+func TestDetectReceivedFork(t *testing.T) {
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// same version
+	local := mockForkedModel(docID, versionID)
+	sr, err := local.CalculateSigningRoot()
+	assert.NoError(t, err)
+	anchored := new(MockModel)
+	anchored.On("CalculateSigningRoot").Return(sr, nil).Once()
+	fork, err := detectReceivedFork(local, anchored)
+	assert.NoError(t, err)
+	assert.Nil(t, fork)
+
+	// forked version
+	root := utils.RandomSlice(32)
+	anchored.On("CalculateSigningRoot").Return(utils.RandomSlice(32), nil).Once()
+	anchored.On("CalculateDocumentRoot").Return(root, nil).Once()
+	fork, err = detectReceivedFork(local, anchored)
+	assert.NoError(t, err)
+	assert.NotNil(t, fork)
+	assert.Equal(t, root, []byte(fork.AnchoredDocumentRoot))
+	assert.Equal(t, sr, []byte(fork.LocalSigningRoot))
+	anchored.AssertExpectations(t)
+}
+
+func TestRebase_Apply(t *testing.T) {
+	key, err := AttrKeyFromLabel("some key")
+	assert.NoError(t, err)
+	rb := Rebase{RemovedAttributes: []AttrKey{key}}
+	rebased := new(MockModel)
+	rebased.On("DeleteAttribute", key, false).Return(errors.New("failed")).Once()
+	assert.Error(t, rb.Apply(rebased))
+
+	rebased.On("DeleteAttribute", key, false).Return(nil).Once()
+	assert.NoError(t, rb.Apply(rebased))
+	rebased.AssertExpectations(t)
+}
+
+func TestRepo_Forks(t *testing.T) {
+	repo := getRepository(ctx)
+	accountID, docID := utils.RandomSlice(32), utils.RandomSlice(32)
+	fork := &Fork{
+		DocumentID:    docID,
+		VersionID:     utils.RandomSlice(32),
+		Scheme:        "generic",
+		DetectedAt:    time.Now().UTC(),
+		LocalDocument: utils.RandomSlice(64),
+	}
+
+	// missing fork
+	_, err := repo.GetFork(accountID, docID, fork.VersionID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrForkNotFound, err))
+	forks, err := repo.GetForks(accountID, docID)
+	assert.NoError(t, err)
+	assert.Empty(t, forks)
+
+	// store
+	assert.NoError(t, repo.StoreFork(accountID, fork))
+	gfork, err := repo.GetFork(accountID, docID, fork.VersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, fork.LocalDocument, gfork.LocalDocument)
+
+	// overwrite
+	fork.AnchoredDocumentRoot = utils.RandomSlice(32)
+	assert.NoError(t, repo.StoreFork(accountID, fork))
+	gfork, err = repo.GetFork(accountID, docID, fork.VersionID)
+	assert.NoError(t, err)
+	assert.Equal(t, fork.AnchoredDocumentRoot, gfork.AnchoredDocumentRoot)
+
+	// another fork of the document
+	fork2 := &Fork{DocumentID: docID, VersionID: utils.RandomSlice(32)}
+	assert.NoError(t, repo.StoreFork(accountID, fork2))
+	forks, err = repo.GetForks(accountID, docID)
+	assert.NoError(t, err)
+	assert.Len(t, forks, 2)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/schemas"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -46,8 +47,13 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return anchors.ErrAnchorRepoNotInitialised
 	}
 
+	schemaSrv, ok := ctx[schemas.BootstrappedService].(schemas.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", schemas.BootstrappedService)
+	}
+
 	// register service
-	srv := DefaultService(docSrv, repo, queueSrv, jobManager, anchorSrv)
+	srv := DefaultService(docSrv, repo, queueSrv, jobManager, anchorSrv, schemaSrv)
 	err := registry.Register(documenttypes.GenericDataTypeUrl, srv)
 	if err != nil {
 		return errors.New("failed to register generic doc service: %v", err)
@@ -23,8 +23,10 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/schemas"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -62,9 +64,11 @@ func TestMain(m *testing.M) {
 		&ideth.Bootstrapper{},
 		&configstore.Bootstrapper{},
 		anchors.Bootstrapper{},
+		notification.Bootstrapper{},
 		documents.Bootstrapper{},
 		p2p.Bootstrapper{},
 		documents.PostBootstrapper{},
+		schemas.Bootstrapper{},
 		&Bootstrapper{},
 		&queue.Starter{},
 	}
@@ -407,6 +411,36 @@ func TestGeneric_DeleteAttribute(t *testing.T) {
 	assert.False(t, g.AttributeExists(attr.Key))
 }
 
+func TestGeneric_DiffVersions(t *testing.T) {
+	old, _ := createCDWithEmbeddedGeneric(t)
+	attr, err := documents.NewStringAttribute("some key", documents.AttrString, "some value")
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+
+	g := new(Generic)
+	err = g.PrepareNewVersion(old, documents.CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{collab}}, map[documents.AttrKey]documents.Attribute{attr.Key: attr})
+	assert.NoError(t, err)
+
+	diff, err := documents.DiffVersions(old, g)
+	assert.NoError(t, err)
+	assert.Equal(t, old.ID(), diff.DocumentID)
+	assert.Equal(t, old.CurrentVersion(), diff.From)
+	assert.Equal(t, g.CurrentVersion(), diff.To)
+	assert.Len(t, diff.Attributes, 1)
+	assert.Equal(t, documents.DiffAdded, diff.Attributes[0].Action)
+	assert.Equal(t, "some value", diff.Attributes[0].New)
+	assert.Len(t, diff.Collaborators, 1)
+	assert.Equal(t, documents.CollaboratorDiff{Action: documents.DiffAdded, DID: collab, Write: true}, diff.Collaborators[0])
+	assert.NotEmpty(t, diff.ChangedFields)
+
+	// same version
+	diff, err = documents.DiffVersions(g, g)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Attributes)
+	assert.Empty(t, diff.Collaborators)
+	assert.Empty(t, diff.ChangedFields)
+}
+
 func TestGeneric_GetData(t *testing.T) {
 	g, _ := createCDWithEmbeddedGeneric(t)
 	data := g.GetData()
@@ -499,3 +533,41 @@ func TestGeneric_unpackFromUpdatePayload(t *testing.T) {
 	err = g.unpackFromUpdatePayloadOld(old.(*Generic), payload)
 	assert.NoError(t, err)
 }
+
+func TestGeneric_Rebase(t *testing.T) {
+	base, _ := createCDWithEmbeddedGeneric(t)
+	attr, err := documents.NewStringAttribute("some key", documents.AttrString, "some value")
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+
+	// local version adds an attribute and a collaborator
+	local := new(Generic)
+	err = local.PrepareNewVersion(base, documents.CollaboratorsAccess{ReadCollaborators: []identity.DID{collab}}, map[documents.AttrKey]documents.Attribute{attr.Key: attr})
+	assert.NoError(t, err)
+
+	// winning version adds another attribute
+	wattr, err := documents.NewStringAttribute("other key", documents.AttrString, "other value")
+	assert.NoError(t, err)
+	winning := new(Generic)
+	err = winning.PrepareNewVersion(base, documents.CollaboratorsAccess{}, map[documents.AttrKey]documents.Attribute{wattr.Key: wattr})
+	assert.NoError(t, err)
+
+	rb, err := documents.NewRebase(base, local, winning)
+	assert.NoError(t, err)
+	assert.Equal(t, winning.ID(), rb.Payload.DocumentID)
+	assert.Equal(t, Scheme, rb.Payload.Scheme)
+	assert.Len(t, rb.Payload.Attributes, 1)
+	assert.Equal(t, attr, rb.Payload.Attributes[attr.Key])
+	assert.Equal(t, []identity.DID{collab}, rb.Payload.Collaborators.ReadCollaborators)
+	assert.Empty(t, rb.RemovedAttributes)
+	assert.NotEmpty(t, rb.ChangedFields)
+	assert.Equal(t, marshallData(t, map[string]interface{}{}), rb.Payload.Data)
+
+	// rebased version keeps the attribute of the winning version
+	rebased, err := winning.DeriveFromUpdatePayload(context.Background(), rb.Payload)
+	assert.NoError(t, err)
+	assert.NoError(t, rb.Apply(rebased))
+	assert.Equal(t, winning.CurrentVersion(), rebased.PreviousVersion())
+	assert.True(t, rebased.AttributeExists(attr.Key))
+	assert.True(t, rebased.AttributeExists(wattr.Key))
+}
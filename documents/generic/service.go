@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/schemas"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -21,6 +22,7 @@ type service struct {
 	queueSrv   queue.TaskQueuer
 	jobManager jobs.Manager
 	anchorSrv  anchors.Service
+	schemaSrv  schemas.Service
 }
 
 // DefaultService returns the default implementation of the service.
@@ -30,6 +32,7 @@ func DefaultService(
 	queueSrv queue.TaskQueuer,
 	jobManager jobs.Manager,
 	anchorSrv anchors.Service,
+	schemaSrv schemas.Service,
 ) documents.Service {
 	return service{
 		repo:       repo,
@@ -37,6 +40,7 @@ func DefaultService(
 		jobManager: jobManager,
 		Service:    srv,
 		anchorSrv:  anchorSrv,
+		schemaSrv:  schemaSrv,
 	}
 }
 
@@ -152,6 +156,7 @@ func (s service) New(_ string) (documents.Model, error) {
 }
 
 // Validate takes care of document validation
+// Attributes of the document are validated against the schema the document is tagged with, if any.
 func (s service) Validate(ctx context.Context, model documents.Model, old documents.Model) error {
-	return nil
+	return s.schemaSrv.Validate(ctx, model)
 }
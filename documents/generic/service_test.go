@@ -10,6 +10,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/schemas"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
 	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
@@ -54,12 +56,12 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, documents
 	repo := testRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
-	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil)
+	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, notification.NewEventBus())
 	return idService, DefaultService(
 		docSrv,
 		repo,
 		queueSrv,
-		ctx[jobs.BootstrappedService].(jobs.Manager), anchorSrv)
+		ctx[jobs.BootstrappedService].(jobs.Manager), anchorSrv, new(schemas.MockService))
 }
 
 func TestService_UpdateModel(t *testing.T) {
@@ -154,7 +156,20 @@ func TestService_Update(t *testing.T) {
 }
 
 func TestService_Validate(t *testing.T) {
-	srv := service{}
-	err := srv.Validate(context.Background(), nil, nil)
+	schemaSrv := new(schemas.MockService)
+	srv := service{schemaSrv: schemaSrv}
+	ctx := context.Background()
+	g := new(Generic)
+
+	// conforms to the schema
+	schemaSrv.On("Validate", ctx, g).Return(nil).Once()
+	err := srv.Validate(ctx, g, nil)
 	assert.NoError(t, err)
+
+	// doesn't conform to the schema
+	schemaSrv.On("Validate", ctx, g).Return(schemas.ErrSchemaValidation).Once()
+	err = srv.Validate(ctx, g, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(schemas.ErrSchemaValidation, err))
+	schemaSrv.AssertExpectations(t)
 }
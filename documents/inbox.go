@@ -0,0 +1,200 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+)
+
+// InboxItem records an anchored version of a document received from a collaborator.
+type InboxItem struct {
+	// ID orders the items by the time they were received.
+	ID         byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID  byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	Sender     identity.DID       `json:"sender" swaggertype:"primitive,string"`
+	Scheme     string             `json:"scheme"`
+	ReceivedAt time.Time          `json:"received_at" swaggertype:"primitive,string"`
+
+	// Read is set once the item is marked as read.
+	Read bool `json:"read"`
+
+	// Acknowledged is set once the item is acknowledged as processed.
+	Acknowledged   bool       `json:"acknowledged"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" swaggertype:"primitive,string"`
+}
+
+// NewInboxItem returns the inbox item of the version received from the sender.
+func NewInboxItem(model Model, sender identity.DID) *InboxItem {
+	now := time.Now().UTC()
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(now.UnixNano()))
+	return &InboxItem{
+		ID:         append(ts, model.CurrentVersion()...),
+		DocumentID: model.ID(),
+		VersionID:  model.CurrentVersion(),
+		Sender:     sender,
+		Scheme:     model.Scheme(),
+		ReceivedAt: now,
+	}
+}
+
+// JSON marshals InboxItem to json bytes.
+func (i *InboxItem) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// Type returns the type of InboxItem.
+func (i *InboxItem) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+// FromJSON loads json bytes to InboxItem.
+func (i *InboxItem) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
+}
+
+// inboxVersion indexes the inbox item by the version received.
+type inboxVersion struct {
+	ID []byte `json:"id"`
+}
+
+// JSON marshals inboxVersion to json bytes.
+func (i *inboxVersion) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// Type returns the type of inboxVersion.
+func (i *inboxVersion) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+// FromJSON loads json bytes to inboxVersion.
+func (i *inboxVersion) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
+}
+
+// InboxFilter holds the filters and the pagination options to list the inbox.
+type InboxFilter struct {
+	// Scheme of the received versions. Ignored if empty.
+	Scheme string
+
+	// Sender of the received versions. Ignored if nil.
+	Sender *identity.DID
+
+	// DocumentID of the received versions. Ignored if empty.
+	DocumentID []byte
+
+	// Read and Acknowledged flags of the items. Ignored if nil.
+	Read, Acknowledged *bool
+
+	// From and To are the inclusive range of the time the versions were received.
+	// Ignored if zero.
+	From, To time.Time
+
+	// Cursor is the item ID to start listing from.
+	Cursor []byte
+
+	// Limit is the maximum number of items to return. DefaultListLimit is used if not provided.
+	Limit int
+}
+
+// Matches returns true if the item passes all the filters.
+func (f InboxFilter) Matches(item *InboxItem) bool {
+	if f.Scheme != "" && f.Scheme != item.Scheme {
+		return false
+	}
+
+	if f.Sender != nil && !f.Sender.Equal(item.Sender) {
+		return false
+	}
+
+	if len(f.DocumentID) > 0 && !bytes.Equal(f.DocumentID, item.DocumentID) {
+		return false
+	}
+
+	if f.Read != nil && *f.Read != item.Read {
+		return false
+	}
+
+	if f.Acknowledged != nil && *f.Acknowledged != item.Acknowledged {
+		return false
+	}
+
+	return ListFilter{From: f.From, To: f.To}.InRange(item.ReceivedAt)
+}
+
+// GetLimit returns the limit of the filter or DefaultListLimit if not set.
+func (f InboxFilter) GetLimit() int {
+	return ListFilter{Limit: f.Limit}.GetLimit()
+}
+
+// recordInboxItem records the anchored version received from the collaborator in the inbox of the account.
+// Version received again is not recorded.
+func (s service) recordInboxItem(accountID []byte, model Model, collaborator identity.DID) error {
+	return s.repo.CreateInboxItem(accountID, NewInboxItem(model, collaborator))
+}
+
+// ListInbox returns the items of the account inbox, oldest first, that match the filter.
+func (s service) ListInbox(ctx context.Context, filter InboxFilter) ([]*InboxItem, []byte, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, nil, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.ListInbox(acc.GetIdentityID(), filter)
+}
+
+// MarkInboxItemRead marks the item of the account inbox as read.
+// Marking an item again is a no-op.
+func (s service) MarkInboxItemRead(ctx context.Context, id []byte) (*InboxItem, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	accID := acc.GetIdentityID()
+	item, err := s.repo.GetInboxItem(accID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Read {
+		return item, nil
+	}
+
+	item.Read = true
+	return item, s.repo.UpdateInboxItem(accID, item)
+}
+
+// AckInboxItem marks the item of the account inbox as read and acknowledged.
+// Acknowledging an item again is a no-op.
+func (s service) AckInboxItem(ctx context.Context, id []byte) (*InboxItem, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	accID := acc.GetIdentityID()
+	item, err := s.repo.GetInboxItem(accID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Acknowledged {
+		return item, nil
+	}
+
+	now := time.Now().UTC()
+	item.Read = true
+	item.Acknowledged = true
+	item.AcknowledgedAt = &now
+	return item, s.repo.UpdateInboxItem(accID, item)
+}
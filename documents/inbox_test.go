@@ -0,0 +1,150 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestInboxItem(docID []byte, scheme string) *InboxItem {
+	model := new(MockModel)
+	model.On("ID").Return(docID)
+	model.On("CurrentVersion").Return(utils.RandomSlice(32))
+	model.On("Scheme").Return(scheme)
+	return NewInboxItem(model, testingidentity.GenerateRandomDID())
+}
+
+func TestInboxFilter_Matches(t *testing.T) {
+	item := newTestInboxItem(utils.RandomSlice(32), "generic")
+	yes, no := true, false
+	sender := item.Sender
+	other := testingidentity.GenerateRandomDID()
+	tests := []struct {
+		filter  InboxFilter
+		matches bool
+	}{
+		{InboxFilter{}, true},
+		{InboxFilter{Scheme: "generic", Sender: &sender, DocumentID: item.DocumentID, Read: &no, Acknowledged: &no}, true},
+		{InboxFilter{Scheme: "entity"}, false},
+		{InboxFilter{Sender: &other}, false},
+		{InboxFilter{DocumentID: utils.RandomSlice(32)}, false},
+		{InboxFilter{Read: &yes}, false},
+		{InboxFilter{Acknowledged: &yes}, false},
+		{InboxFilter{From: item.ReceivedAt.Add(time.Second)}, false},
+		{InboxFilter{To: item.ReceivedAt.Add(-time.Second)}, false},
+	}
+
+	for _, c := range tests {
+		assert.Equal(t, c.matches, c.filter.Matches(item))
+	}
+}
+
+func TestRepo_Inbox(t *testing.T) {
+	repo := getRepository(ctx)
+	accountID, docID := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing item
+	_, err := repo.GetInboxItem(accountID, utils.RandomSlice(40))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInboxItemNotFound, err))
+
+	var items []*InboxItem
+	for i := 0; i < 3; i++ {
+		item := newTestInboxItem(docID, "generic")
+		assert.NoError(t, repo.CreateInboxItem(accountID, item))
+		items = append(items, item)
+	}
+
+	other := newTestInboxItem(utils.RandomSlice(32), "entity")
+	assert.NoError(t, repo.CreateInboxItem(accountID, other))
+
+	// version received again is not recorded
+	again := *other
+	again.ID = append([]byte{0xff}, other.ID...)
+	assert.NoError(t, repo.CreateInboxItem(accountID, &again))
+	litems, _, err := repo.ListInbox(accountID, InboxFilter{DocumentID: other.DocumentID})
+	assert.NoError(t, err)
+	assert.Len(t, litems, 1)
+	assert.Equal(t, other.ID, litems[0].ID)
+
+	// first page, oldest first
+	litems, next, err := repo.ListInbox(accountID, InboxFilter{DocumentID: docID, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, litems, 2)
+	assert.Equal(t, items[0].ID, litems[0].ID)
+	assert.Equal(t, items[1].ID, litems[1].ID)
+	assert.Equal(t, []byte(items[2].ID), next)
+
+	// last page
+	litems, next, err = repo.ListInbox(accountID, InboxFilter{DocumentID: docID, Cursor: next, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, litems, 1)
+	assert.Equal(t, items[2].ID, litems[0].ID)
+	assert.Empty(t, next)
+
+	// update
+	items[0].Acknowledged = true
+	assert.NoError(t, repo.UpdateInboxItem(accountID, items[0]))
+	item, err := repo.GetInboxItem(accountID, items[0].ID)
+	assert.NoError(t, err)
+	assert.True(t, item.Acknowledged)
+
+	// other account
+	litems, _, err = repo.ListInbox(utils.RandomSlice(32), InboxFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, litems)
+}
+
+func TestService_Inbox(t *testing.T) {
+	srv := service{repo: getRepository(ctx)}
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	accountID := did[:]
+	docID := utils.RandomSlice(32)
+	item := newTestInboxItem(docID, "generic")
+	assert.NoError(t, srv.repo.CreateInboxItem(accountID, item))
+
+	// listing doesn't mark the items as read
+	unread := false
+	items, _, err := srv.ListInbox(ctxh, InboxFilter{DocumentID: docID, Read: &unread})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	items, _, err = srv.ListInbox(ctxh, InboxFilter{DocumentID: docID, Read: &unread})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	// mark as read
+	_, err = srv.MarkInboxItemRead(ctxh, utils.RandomSlice(40))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInboxItemNotFound, err))
+	read, err := srv.MarkInboxItemRead(ctxh, item.ID)
+	assert.NoError(t, err)
+	assert.True(t, read.Read)
+	assert.False(t, read.Acknowledged)
+	items, _, err = srv.ListInbox(ctxh, InboxFilter{DocumentID: docID, Read: &unread})
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	// missing item
+	_, err = srv.AckInboxItem(ctxh, utils.RandomSlice(40))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInboxItemNotFound, err))
+
+	// ack
+	acked, err := srv.AckInboxItem(ctxh, item.ID)
+	assert.NoError(t, err)
+	assert.True(t, acked.Read)
+	assert.True(t, acked.Acknowledged)
+	assert.NotNil(t, acked.AcknowledgedAt)
+
+	// ack again
+	again, err := srv.AckInboxItem(ctxh, item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, acked.AcknowledgedAt.Unix(), again.AcknowledgedAt.Unix())
+}
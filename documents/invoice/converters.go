@@ -0,0 +1,44 @@
+package invoice
+
+import (
+	invoicepb "github.com/centrifuge/centrifuge-protobufs/gen/go/invoice"
+	"github.com/centrifuge/go-centrifuge/documents"
+)
+
+func toProtoLineItems(items []LineItem) (pitems []*invoicepb.LineItem, err error) {
+	for _, item := range items {
+		decs, err := documents.DecimalsToBytes(item.Quantity, item.PricePerUnit, item.TotalAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		pitems = append(pitems, &invoicepb.LineItem{
+			ItemNumber:   item.ItemNumber,
+			Description:  item.Description,
+			Quantity:     decs[0],
+			PricePerUnit: decs[1],
+			TotalAmount:  decs[2],
+		})
+	}
+
+	return pitems, nil
+}
+
+func fromProtoLineItems(pitems []*invoicepb.LineItem) (items []LineItem, err error) {
+	for _, item := range pitems {
+		decs, err := documents.BytesToDecimals(item.Quantity, item.PricePerUnit, item.TotalAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, LineItem{
+			ItemNumber:   item.ItemNumber,
+			Description:  item.Description,
+			Quantity:     decs[0],
+			PricePerUnit: decs[1],
+			TotalAmount:  decs[2],
+		})
+	}
+
+	return items, nil
+}
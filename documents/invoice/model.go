@@ -0,0 +1,477 @@
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/invoice"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/timeutils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	prefix string = "invoice"
+
+	// Scheme is invoice scheme.
+	Scheme = prefix
+
+	// ErrInvoiceInvalidData sentinel error when data unmarshal is failed.
+	ErrInvoiceInvalidData = errors.Error("invalid invoice data")
+)
+
+// tree prefixes for specific to documents use the second byte of a 4 byte slice by convention
+func compactPrefix() []byte { return []byte{0, 1, 0, 0} }
+
+// LineItem represents a single line item of the invoice.
+type LineItem struct {
+	ItemNumber   string             `json:"item_number"`
+	Description  string             `json:"description"`
+	Quantity     *documents.Decimal `json:"quantity" swaggertype:"primitive,string"`
+	PricePerUnit *documents.Decimal `json:"price_per_unit" swaggertype:"primitive,string"`
+	TotalAmount  *documents.Decimal `json:"total_amount" swaggertype:"primitive,string"`
+}
+
+// Data represents the invoice data.
+type Data struct {
+	Number      string             `json:"number"`
+	Status      string             `json:"status" enums:"unpaid,paid"`
+	Sender      *identity.DID      `json:"sender" swaggertype:"primitive,string"`
+	Recipient   *identity.DID      `json:"recipient" swaggertype:"primitive,string"`
+	Payee       *identity.DID      `json:"payee" swaggertype:"primitive,string"`
+	Comment     string             `json:"comment"`
+	Currency    string             `json:"currency"`
+	GrossAmount *documents.Decimal `json:"gross_amount" swaggertype:"primitive,string"`
+	NetAmount   *documents.Decimal `json:"net_amount" swaggertype:"primitive,string"`
+	TaxAmount   *documents.Decimal `json:"tax_amount" swaggertype:"primitive,string"`
+	TaxRate     *documents.Decimal `json:"tax_rate" swaggertype:"primitive,string"`
+	DateCreated *time.Time         `json:"date_created" swaggertype:"primitive,string"`
+	DateDue     *time.Time         `json:"date_due" swaggertype:"primitive,string"`
+	LineItems   []LineItem         `json:"line_items"`
+}
+
+// Invoice implements the documents.Model keeps track of invoice related fields and state
+type Invoice struct {
+	*documents.CoreDocument
+
+	Data Data
+}
+
+// createP2PProtobuf returns centrifuge protobuf specific invoiceData
+func (i *Invoice) createP2PProtobuf() (*invoicepb.InvoiceData, error) {
+	d := i.Data
+	decs, err := documents.DecimalsToBytes(d.GrossAmount, d.NetAmount, d.TaxAmount, d.TaxRate)
+	if err != nil {
+		return nil, err
+	}
+
+	pts, err := timeutils.ToProtoTimestamps(d.DateCreated, d.DateDue)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItems, err := toProtoLineItems(d.LineItems)
+	if err != nil {
+		return nil, err
+	}
+
+	dids := identity.DIDsToBytes(d.Sender, d.Recipient, d.Payee)
+	return &invoicepb.InvoiceData{
+		InvoiceNumber: d.Number,
+		InvoiceStatus: d.Status,
+		Sender:        dids[0],
+		Recipient:     dids[1],
+		Payee:         dids[2],
+		Comment:       d.Comment,
+		Currency:      d.Currency,
+		GrossAmount:   decs[0],
+		NetAmount:     decs[1],
+		TaxAmount:     decs[2],
+		TaxRate:       decs[3],
+		DateCreated:   pts[0],
+		DateDue:       pts[1],
+		LineItems:     lineItems,
+	}, nil
+}
+
+// loadFromP2PProtobuf loads the invoice from centrifuge protobuf invoice data
+func (i *Invoice) loadFromP2PProtobuf(data *invoicepb.InvoiceData) error {
+	dids, err := identity.BytesToDIDs(data.Sender, data.Recipient, data.Payee)
+	if err != nil {
+		return err
+	}
+
+	decs, err := documents.BytesToDecimals(data.GrossAmount, data.NetAmount, data.TaxAmount, data.TaxRate)
+	if err != nil {
+		return err
+	}
+
+	tms, err := timeutils.FromProtoTimestamps(data.DateCreated, data.DateDue)
+	if err != nil {
+		return err
+	}
+
+	lineItems, err := fromProtoLineItems(data.LineItems)
+	if err != nil {
+		return err
+	}
+
+	var d Data
+	d.Number = data.InvoiceNumber
+	d.Status = data.InvoiceStatus
+	d.Sender = dids[0]
+	d.Recipient = dids[1]
+	d.Payee = dids[2]
+	d.Comment = data.Comment
+	d.Currency = data.Currency
+	d.GrossAmount = decs[0]
+	d.NetAmount = decs[1]
+	d.TaxAmount = decs[2]
+	d.TaxRate = decs[3]
+	d.DateCreated = tms[0]
+	d.DateDue = tms[1]
+	d.LineItems = lineItems
+	i.Data = d
+	return nil
+}
+
+// PackCoreDocument packs the Invoice into a CoreDocument.
+func (i *Invoice) PackCoreDocument() (cd coredocumentpb.CoreDocument, err error) {
+	invoiceData, err := i.createP2PProtobuf()
+	if err != nil {
+		return cd, err
+	}
+
+	data, err := proto.Marshal(invoiceData)
+	if err != nil {
+		return cd, errors.New("couldn't serialise InvoiceData: %v", err)
+	}
+
+	embedData := &any.Any{
+		TypeUrl: i.DocumentType(),
+		Value:   data,
+	}
+
+	return i.CoreDocument.PackCoreDocument(embedData), nil
+}
+
+// UnpackCoreDocument unpacks the core document into Invoice.
+func (i *Invoice) UnpackCoreDocument(cd coredocumentpb.CoreDocument) error {
+	if cd.EmbeddedData == nil ||
+		cd.EmbeddedData.TypeUrl != i.DocumentType() {
+		return errors.New("trying to convert document with incorrect schema")
+	}
+
+	invoiceData := new(invoicepb.InvoiceData)
+	err := proto.Unmarshal(cd.EmbeddedData.Value, invoiceData)
+	if err != nil {
+		return err
+	}
+
+	err = i.loadFromP2PProtobuf(invoiceData)
+	if err != nil {
+		return err
+	}
+
+	i.CoreDocument, err = documents.NewCoreDocumentFromProtobuf(cd)
+	return err
+}
+
+// JSON marshals Invoice into a json bytes
+func (i *Invoice) JSON() ([]byte, error) {
+	return i.CoreDocument.MarshalJSON(i)
+}
+
+// FromJSON unmarshals the json bytes into Invoice
+func (i *Invoice) FromJSON(jsonData []byte) error {
+	if i.CoreDocument == nil {
+		i.CoreDocument = new(documents.CoreDocument)
+	}
+
+	return i.CoreDocument.UnmarshalJSON(jsonData, i)
+}
+
+// Type gives the Invoice type
+func (i *Invoice) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+func (i *Invoice) getDataLeaves() ([]proofs.LeafNode, error) {
+	t, err := i.getRawDataTree()
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDataTree, err)
+	}
+	return t.GetLeaves(), nil
+}
+
+func (i *Invoice) getRawDataTree() (*proofs.DocumentTree, error) {
+	if i.CoreDocument == nil {
+		return nil, errors.New("getDataTree error CoreDocument not set")
+	}
+	invProto, err := i.createP2PProtobuf()
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDataTree, err)
+	}
+	t, err := i.CoreDocument.DefaultTreeWithPrefix(prefix, compactPrefix())
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDataTree, err)
+	}
+	err = t.AddLeavesFromDocument(invProto)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDataTree, err)
+	}
+	return t, nil
+}
+
+// getDocumentDataTree creates precise-proofs data tree for the model
+func (i *Invoice) getDocumentDataTree() (tree *proofs.DocumentTree, err error) {
+	if i.CoreDocument == nil {
+		return nil, errors.New("getDocumentDataTree error CoreDocument not set")
+	}
+	invProto, err := i.createP2PProtobuf()
+	if err != nil {
+		return nil, errors.New("getDocumentDataTree error %v", err)
+	}
+	t, err := i.CoreDocument.DefaultTreeWithPrefix(prefix, compactPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.AddLeavesFromDocument(invProto)
+	if err != nil {
+		return nil, errors.New("getDocumentDataTree error %v", err)
+	}
+	err = t.Generate()
+	if err != nil {
+		return nil, errors.New("getDocumentDataTree error %v", err)
+	}
+
+	return t, nil
+}
+
+// CreateNFTProofs creates proofs specific to NFT minting.
+func (i *Invoice) CreateNFTProofs(
+	account identity.DID,
+	registry common.Address,
+	tokenID []byte,
+	nftUniqueProof, readAccessProof bool) (prf *documents.DocumentProof, err error) {
+
+	dataLeaves, err := i.getDataLeaves()
+	if err != nil {
+		return nil, err
+	}
+
+	return i.CoreDocument.CreateNFTProofs(
+		i.DocumentType(),
+		dataLeaves,
+		account, registry, tokenID, nftUniqueProof, readAccessProof)
+}
+
+// CreateProofs generates proofs for given fields.
+func (i *Invoice) CreateProofs(fields []string) (prf *documents.DocumentProof, err error) {
+	dataLeaves, err := i.getDataLeaves()
+	if err != nil {
+		return nil, errors.New("createProofs error %v", err)
+	}
+
+	return i.CoreDocument.CreateProofs(i.DocumentType(), dataLeaves, fields)
+}
+
+// DocumentType returns the invoice document type.
+func (*Invoice) DocumentType() string {
+	return documenttypes.InvoiceDataTypeUrl
+}
+
+// AddNFT adds NFT to the Invoice.
+func (i *Invoice) AddNFT(grantReadAccess bool, registry common.Address, tokenID []byte) error {
+	cd, err := i.CoreDocument.AddNFT(grantReadAccess, registry, tokenID)
+	if err != nil {
+		return err
+	}
+
+	i.CoreDocument = cd
+	return nil
+}
+
+// CalculateSigningRoot calculates the signing root of the document.
+func (i *Invoice) CalculateSigningRoot() ([]byte, error) {
+	dataLeaves, err := i.getDataLeaves()
+	if err != nil {
+		return nil, err
+	}
+	return i.CoreDocument.CalculateSigningRoot(i.DocumentType(), dataLeaves)
+}
+
+// CalculateDocumentRoot calculates the document root
+func (i *Invoice) CalculateDocumentRoot() ([]byte, error) {
+	dataLeaves, err := i.getDataLeaves()
+	if err != nil {
+		return nil, err
+	}
+	return i.CoreDocument.CalculateDocumentRoot(i.DocumentType(), dataLeaves)
+}
+
+// CollaboratorCanUpdate checks if the collaborator can update the document.
+func (i *Invoice) CollaboratorCanUpdate(updated documents.Model, collaborator identity.DID) error {
+	newInv, ok := updated.(*Invoice)
+	if !ok {
+		return errors.NewTypedError(documents.ErrDocumentInvalidType, errors.New("expecting an invoice but got %T", updated))
+	}
+
+	// check the core document changes
+	err := i.CoreDocument.CollaboratorCanUpdate(newInv.CoreDocument, collaborator, i.DocumentType())
+	if err != nil {
+		return err
+	}
+
+	// check invoice specific changes
+	oldTree, err := i.getDocumentDataTree()
+	if err != nil {
+		return err
+	}
+
+	newTree, err := newInv.getDocumentDataTree()
+	if err != nil {
+		return err
+	}
+
+	rules := i.CoreDocument.TransitionRulesFor(collaborator)
+	cf := documents.GetChangedFields(oldTree, newTree)
+	return documents.ValidateTransitions(rules, cf)
+}
+
+// AddAttributes adds attributes to the Invoice model.
+func (i *Invoice) AddAttributes(ca documents.CollaboratorsAccess, prepareNewVersion bool, attrs ...documents.Attribute) error {
+	ncd, err := i.CoreDocument.AddAttributes(ca, prepareNewVersion, compactPrefix(), attrs...)
+	if err != nil {
+		return errors.NewTypedError(documents.ErrCDAttribute, err)
+	}
+
+	i.CoreDocument = ncd
+	return nil
+}
+
+// DeleteAttribute deletes the attribute from the model.
+func (i *Invoice) DeleteAttribute(key documents.AttrKey, prepareNewVersion bool) error {
+	ncd, err := i.CoreDocument.DeleteAttribute(key, prepareNewVersion, compactPrefix())
+	if err != nil {
+		return errors.NewTypedError(documents.ErrCDAttribute, err)
+	}
+
+	i.CoreDocument = ncd
+	return nil
+}
+
+// GetData returns invoice data
+func (i *Invoice) GetData() interface{} {
+	return i.Data
+}
+
+// loadData unmarshals json blob to Data.
+func loadData(data []byte, d *Data) error {
+	return json.Unmarshal(data, d)
+}
+
+// DeriveFromCreatePayload unpacks the invoice data from the Payload.
+func (i *Invoice) DeriveFromCreatePayload(_ context.Context, payload documents.CreatePayload) error {
+	var d Data
+	if err := loadData(payload.Data, &d); err != nil {
+		return errors.NewTypedError(ErrInvoiceInvalidData, err)
+	}
+
+	cd, err := documents.NewCoreDocument(compactPrefix(), payload.Collaborators, payload.Attributes)
+	if err != nil {
+		return errors.NewTypedError(documents.ErrCDCreate, err)
+	}
+
+	i.Data = d
+	i.CoreDocument = cd
+	return nil
+}
+
+// unpackFromUpdatePayload unpacks the update payload and prepares a new version.
+func (i *Invoice) unpackFromUpdatePayload(old *Invoice, payload documents.UpdatePayload) error {
+	var d Data
+	if err := loadData(payload.Data, &d); err != nil {
+		return errors.NewTypedError(ErrInvoiceInvalidData, err)
+	}
+
+	ncd, err := old.CoreDocument.PrepareNewVersion(compactPrefix(), payload.Collaborators, payload.Attributes)
+	if err != nil {
+		return err
+	}
+
+	i.Data = d
+	i.CoreDocument = ncd
+	return nil
+}
+
+// DeriveFromUpdatePayload unpacks the update payload and prepares a new version.
+func (i *Invoice) DeriveFromUpdatePayload(_ context.Context, payload documents.UpdatePayload) (documents.Model, error) {
+	d, err := i.patch(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ncd, err := i.CoreDocument.PrepareNewVersion(compactPrefix(), payload.Collaborators, payload.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invoice{
+		Data:         d,
+		CoreDocument: ncd,
+	}, nil
+}
+
+// patch merges the payload data on top of a copy of the invoice data.
+// Data is copied through json since the decimals, dids and timestamps are pointers
+// and unmarshalling the payload into them would modify the current version as well.
+func (i *Invoice) patch(payload documents.UpdatePayload) (Data, error) {
+	var d Data
+	data, err := json.Marshal(i.Data)
+	if err != nil {
+		return d, err
+	}
+
+	if err := loadData(data, &d); err != nil {
+		return d, err
+	}
+
+	if err := loadData(payload.Data, &d); err != nil {
+		return d, errors.NewTypedError(ErrInvoiceInvalidData, err)
+	}
+
+	return d, nil
+}
+
+// Patch merges payload data into model
+func (i *Invoice) Patch(payload documents.UpdatePayload) error {
+	d, err := i.patch(payload)
+	if err != nil {
+		return err
+	}
+
+	ncd, err := i.CoreDocument.Patch(compactPrefix(), payload.Collaborators, payload.Attributes)
+	if err != nil {
+		return err
+	}
+
+	i.Data = d
+	i.CoreDocument = ncd
+	return nil
+}
+
+// Scheme returns the invoice scheme.
+func (i *Invoice) Scheme() string {
+	return Scheme
+}
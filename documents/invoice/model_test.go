@@ -0,0 +1,273 @@
+// +build unit
+
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity/ideth"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/p2p"
+	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+var ctx = map[string]interface{}{}
+var cfg config.Configuration
+var did = testingidentity.GenerateRandomDID()
+
+func TestMain(m *testing.M) {
+	ethClient := &ethereum.MockEthClient{}
+	ethClient.On("GetEthClient").Return(nil)
+	ctx[ethereum.BootstrappedEthereumClient] = ethClient
+	centChainClient := &centchain.MockAPI{}
+	ctx[centchain.BootstrappedCentChainClient] = centChainClient
+	jobMan := &testingjobs.MockJobManager{}
+	ctx[jobs.BootstrappedService] = jobMan
+	done := make(chan error)
+	jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(jobs.NilJobID(), done, nil)
+	ctx[bootstrap.BootstrappedNFTService] = new(testingdocuments.MockRegistry)
+	ibootstrappers := []bootstrap.TestBootstrapper{
+		&testlogging.TestLoggingBootstrapper{},
+		&config.Bootstrapper{},
+		&leveldb.Bootstrapper{},
+		&queue.Bootstrapper{},
+		&ideth.Bootstrapper{},
+		&configstore.Bootstrapper{},
+		anchors.Bootstrapper{},
+		notification.Bootstrapper{},
+		documents.Bootstrapper{},
+		p2p.Bootstrapper{},
+		documents.PostBootstrapper{},
+		&Bootstrapper{},
+		&queue.Starter{},
+	}
+	bootstrap.RunTestBootstrappers(ibootstrappers, ctx)
+	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	cfg.Set("identityId", did.String())
+	result := m.Run()
+	bootstrap.RunTestTeardown(ibootstrappers)
+	os.Exit(result)
+}
+
+type mockModel struct {
+	documents.Model
+	mock.Mock
+	CoreDocument *coredocumentpb.CoreDocument
+}
+
+var testRepoGlobal documents.Repository
+
+func testRepo() documents.Repository {
+	if testRepoGlobal != nil {
+		return testRepoGlobal
+	}
+
+	ldb, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	if err != nil {
+		panic(err)
+	}
+	testRepoGlobal = documents.NewDBRepository(leveldb.NewLevelDBRepository(ldb))
+	testRepoGlobal.Register(&Invoice{})
+	return testRepoGlobal
+}
+
+func TestInvoice_PackUnpackCoreDocument(t *testing.T) {
+	var err error
+
+	// embed data missing
+	err = new(Invoice).UnpackCoreDocument(coredocumentpb.CoreDocument{})
+	assert.Error(t, err)
+
+	// embed data type is wrong
+	err = new(Invoice).UnpackCoreDocument(coredocumentpb.CoreDocument{EmbeddedData: &any.Any{
+		TypeUrl: documenttypes.GenericDataTypeUrl,
+	}})
+	assert.Error(t, err)
+
+	// successful
+	inv, cd := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	assert.NotNil(t, cd.EmbeddedData)
+	ninv := new(Invoice)
+	err = ninv.UnpackCoreDocument(cd)
+	assert.NoError(t, err)
+	assert.Equal(t, inv.ID(), ninv.ID())
+	assert.Equal(t, inv.CurrentVersion(), ninv.CurrentVersion())
+	assert.Equal(t, inv.Data.Number, ninv.Data.Number)
+	assert.Equal(t, inv.Data.Sender, ninv.Data.Sender)
+	assert.Equal(t, inv.Data.GrossAmount.String(), ninv.Data.GrossAmount.String())
+	assert.True(t, inv.Data.DateDue.Equal(*ninv.Data.DateDue))
+	assert.Len(t, ninv.Data.LineItems, 2)
+	assert.Equal(t, inv.Data.LineItems[1].TotalAmount.String(), ninv.Data.LineItems[1].TotalAmount.String())
+	assert.Empty(t, ninv.Data.Comment)
+}
+
+func TestInvoice_JSON(t *testing.T) {
+	inv, cd := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	jsonBytes, err := inv.JSON()
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(jsonBytes))
+
+	ninv := new(Invoice)
+	err = ninv.FromJSON(jsonBytes)
+	assert.NoError(t, err)
+
+	ncd, err := ninv.PackCoreDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, cd, ncd)
+}
+
+func TestInvoice_CreateProofs(t *testing.T) {
+	inv, _ := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	proof, err := inv.CreateProofs([]string{"invoice.net_amount", "invoice.currency", documents.CDTreePrefix + ".document_type"})
+	assert.NoError(t, err)
+	assert.NotNil(t, proof)
+	assert.Len(t, proof.FieldProofs, 3)
+
+	dataLeaves, err := inv.getDataLeaves()
+	assert.NoError(t, err)
+	trees, _, err := inv.CoreDocument.SigningDataTrees(inv.DocumentType(), dataLeaves)
+	assert.NoError(t, err)
+	dataRoot := trees[0].RootHash()
+
+	nodeHash, err := blake2b.New256(nil)
+	assert.NoError(t, err)
+	for _, fp := range proof.FieldProofs {
+		valid, err := documents.ValidateProof(fp, dataRoot, nodeHash, sha3.NewKeccak256())
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// unknown field
+	_, err = inv.CreateProofs([]string{"invoice.unknown"})
+	assert.Error(t, err)
+}
+
+func TestInvoice_CollaboratorCanUpdate(t *testing.T) {
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	inv, _ := CreateInvoiceWithEmbedCD(t, ctxh, did, nil)
+	id1 := did
+	id2 := testingidentity.GenerateRandomDID()
+
+	// wrong type
+	err := inv.CollaboratorCanUpdate(new(mockModel), id1)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalidType, err))
+	assert.NoError(t, testRepo().Create(id1[:], inv.CurrentVersion(), inv))
+
+	// update the document
+	model, err := testRepo().Get(id1[:], inv.CurrentVersion())
+	assert.NoError(t, err)
+	oldInv := model.(*Invoice)
+	d, err := json.Marshal(map[string]string{"status": "paid"})
+	assert.NoError(t, err)
+	ninv, err := oldInv.DeriveFromUpdatePayload(context.Background(), documents.UpdatePayload{
+		DocumentID:    inv.ID(),
+		CreatePayload: documents.CreatePayload{Data: d},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "paid", ninv.(*Invoice).Data.Status)
+
+	// id1 should have permission
+	assert.NoError(t, oldInv.CollaboratorCanUpdate(ninv, id1))
+
+	// id2 should fail since it doesn't have the permission to update
+	assert.Error(t, oldInv.CollaboratorCanUpdate(ninv, id2))
+}
+
+func TestInvoice_DeriveFromCreatePayload(t *testing.T) {
+	// invalid data
+	payload := CreateInvoicePayload(t, nil)
+	payload.Data = []byte("invalid")
+	err := new(Invoice).DeriveFromCreatePayload(context.Background(), payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvoiceInvalidData, err))
+
+	// success
+	payload = CreateInvoicePayload(t, nil)
+	inv := new(Invoice)
+	err = inv.DeriveFromCreatePayload(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "INV-1", inv.Data.Number)
+	assert.Equal(t, "EUR", inv.Data.Currency)
+	assert.Equal(t, Scheme, inv.Scheme())
+	assert.Equal(t, documenttypes.InvoiceDataTypeUrl, inv.DocumentType())
+}
+
+func TestInvoice_Patch(t *testing.T) {
+	inv, _ := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	net := inv.Data.NetAmount.String()
+
+	// invalid data
+	err := inv.Patch(documents.UpdatePayload{CreatePayload: documents.CreatePayload{Data: []byte("invalid")}})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvoiceInvalidData, err))
+
+	// derived version doesn't modify the current version
+	d, err := json.Marshal(map[string]string{"net_amount": "10", "comment": "patched"})
+	assert.NoError(t, err)
+	payload := documents.UpdatePayload{DocumentID: inv.ID(), CreatePayload: documents.CreatePayload{Data: d}}
+	ninv, err := inv.DeriveFromUpdatePayload(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", ninv.(*Invoice).Data.NetAmount.String())
+	assert.Equal(t, "patched", ninv.(*Invoice).Data.Comment)
+	assert.Equal(t, inv.Data.Number, ninv.(*Invoice).Data.Number)
+	assert.Equal(t, net, inv.Data.NetAmount.String())
+	assert.Empty(t, inv.Data.Comment)
+
+	// patch
+	err = inv.Patch(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "10", inv.Data.NetAmount.String())
+	assert.Equal(t, "patched", inv.Data.Comment)
+	assert.Len(t, inv.Data.LineItems, 2)
+}
+
+func TestInvoice_AddAttributes(t *testing.T) {
+	inv, _ := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	label := "some key"
+	value := "some value"
+	attr, err := documents.NewStringAttribute(label, documents.AttrString, value)
+	assert.NoError(t, err)
+
+	// success
+	err = inv.AddAttributes(documents.CollaboratorsAccess{}, true, attr)
+	assert.NoError(t, err)
+	assert.True(t, inv.AttributeExists(attr.Key))
+
+	// delete
+	err = inv.DeleteAttribute(attr.Key, true)
+	assert.NoError(t, err)
+	assert.False(t, inv.AttributeExists(attr.Key))
+
+	// missing attribute
+	err = inv.DeleteAttribute(attr.Key, true)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrCDAttribute, err))
+
+}
@@ -0,0 +1,208 @@
+// +build unit
+
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getServiceWithMockedLayers() documents.Service {
+	idService := testingcommons.MockIdentityService{}
+	idService.On("IsSignedWithPurpose", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+	queueSrv := new(testingutils.MockQueue)
+	queueSrv.On("EnqueueJob", mock.Anything, mock.Anything).Return(&gocelery.AsyncResult{}, nil)
+
+	repo := testRepo()
+	anchorSrv := &testinganchors.MockAnchorService{}
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
+	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, notification.NewEventBus())
+	return DefaultService(
+		docSrv,
+		repo,
+		queueSrv,
+		ctx[jobs.BootstrappedService].(jobs.Manager), anchorSrv)
+}
+
+func TestService_DeriveFromCoreDocument(t *testing.T) {
+	srv := service{}
+	inv, cd := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+
+	// wrong type
+	cd.EmbeddedData.TypeUrl = "wrong"
+	_, err := srv.DeriveFromCoreDocument(cd)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentUnPackingCoreDocument, err))
+
+	// success
+	_, cd = CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	m, err := srv.DeriveFromCoreDocument(cd)
+	assert.NoError(t, err)
+	assert.Equal(t, inv.Data.Number, m.(*Invoice).Data.Number)
+}
+
+func TestService_CreateModel(t *testing.T) {
+	payload := documents.CreatePayload{}
+	srv := service{}
+
+	// nil  model
+	_, _, err := srv.CreateModel(context.Background(), payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNil, err))
+
+	// empty context
+	payload.Data = utils.RandomSlice(32)
+	_, _, err = srv.CreateModel(context.Background(), payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentConfigAccountID, err))
+
+	// invalid data
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	_, _, err = srv.CreateModel(ctxh, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// validator failed
+	data := invoiceData(t)
+	data.Currency = ""
+	payload.Data, err = json.Marshal(data)
+	assert.NoError(t, err)
+	_, _, err = srv.CreateModel(ctxh, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// success
+	payload = CreateInvoicePayload(t, nil)
+	srv.repo = testRepo()
+	jm := testingjobs.MockJobManager{}
+	jm.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), nil)
+	srv.jobManager = jm
+	m, _, err := srv.CreateModel(ctxh, payload)
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+	jm.AssertExpectations(t)
+}
+
+func TestService_UpdateModel(t *testing.T) {
+	payload := documents.UpdatePayload{}
+	srv := service{}
+
+	// nil  model
+	_, _, err := srv.UpdateModel(context.Background(), payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNil, err))
+
+	// empty context
+	payload.Data = utils.RandomSlice(32)
+	_, _, err = srv.UpdateModel(context.Background(), payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentConfigAccountID, err))
+
+	// missing id
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	srv = getServiceWithMockedLayers().(service)
+	payload.DocumentID = utils.RandomSlice(32)
+	_, _, err = srv.UpdateModel(ctxh, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// payload invalid
+	inv, _ := CreateInvoiceWithEmbedCD(t, ctxh, did, nil)
+	err = testRepo().Create(did[:], inv.ID(), inv)
+	assert.NoError(t, err)
+	payload.DocumentID = inv.ID()
+	_, _, err = srv.UpdateModel(ctxh, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// validator failed
+	data := invoiceData(t)
+	data.NetAmount = newDecimal(t, "1")
+	payload.Data, err = json.Marshal(data)
+	assert.NoError(t, err)
+	_, _, err = srv.UpdateModel(ctxh, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// Success
+	payload.Data, err = json.Marshal(invoiceData(t))
+	assert.NoError(t, err)
+	jm := testingjobs.MockJobManager{}
+	jm.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), nil)
+	srv.jobManager = jm
+	m, _, err := srv.UpdateModel(ctxh, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, m.ID(), inv.ID())
+	assert.Equal(t, m.CurrentVersion(), inv.NextVersion())
+	jm.AssertExpectations(t)
+}
+
+func TestService_Update(t *testing.T) {
+	srv := getServiceWithMockedLayers()
+	isrv := srv.(service)
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+
+	// empty context
+	_, _, _, err := srv.Update(context.Background(), nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentConfigAccountID, err))
+
+	// missing last version
+	inv, _ := CreateInvoiceWithEmbedCD(t, ctxh, did, nil)
+	_, _, _, err = isrv.Update(ctxh, inv)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+	assert.NoError(t, testRepo().Create(did[:], inv.CurrentVersion(), inv))
+
+	// validations failed
+	d, err := json.Marshal(invoiceData(t))
+	assert.NoError(t, err)
+	ninv := new(Invoice)
+	err = ninv.unpackFromUpdatePayload(inv, documents.UpdatePayload{CreatePayload: documents.CreatePayload{Data: d}})
+	assert.NoError(t, err)
+	dr := anchors.RandomDocumentRoot()
+	anchorSrv := new(testinganchors.MockAnchorService)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(dr, nil)
+	oldAnchorSrv := isrv.anchorSrv
+	isrv.anchorSrv = anchorSrv
+	_, _, _, err = isrv.Update(ctxh, ninv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.ErrDocumentIDReused.Error())
+	anchorSrv.AssertExpectations(t)
+	isrv.anchorSrv = oldAnchorSrv
+
+	// success
+	jm := testingjobs.MockJobManager{}
+	jm.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), nil)
+	isrv.jobManager = jm
+	m, _, _, err := isrv.Update(ctxh, ninv)
+	assert.NoError(t, err)
+	assert.Equal(t, m.ID(), inv.ID())
+	assert.Equal(t, m.CurrentVersion(), inv.NextVersion())
+	jm.AssertExpectations(t)
+}
+
+func TestService_Validate(t *testing.T) {
+	srv := service{}
+	err := srv.Validate(context.Background(), nil, nil)
+	assert.Error(t, err)
+
+	inv, _ := CreateInvoiceWithEmbedCD(t, nil, did, nil)
+	assert.NoError(t, srv.Validate(context.Background(), inv, nil))
+}
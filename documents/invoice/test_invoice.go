@@ -0,0 +1,110 @@
+// +build integration unit testworld
+
+package invoice
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+func newDecimal(t *testing.T, s string) *documents.Decimal {
+	d, err := documents.NewDecimal(s)
+	assert.NoError(t, err)
+	return d
+}
+
+func invoiceData(t *testing.T) Data {
+	sender := testingidentity.GenerateRandomDID()
+	recipient := testingidentity.GenerateRandomDID()
+	created := time.Now().UTC()
+	due := created.Add(30 * 24 * time.Hour)
+	return Data{
+		Number:      "INV-1",
+		Status:      "unpaid",
+		Sender:      &sender,
+		Recipient:   &recipient,
+		Payee:       &sender,
+		Currency:    "EUR",
+		NetAmount:   newDecimal(t, "130.5"),
+		TaxAmount:   newDecimal(t, "24.795"),
+		GrossAmount: newDecimal(t, "155.295"),
+		TaxRate:     newDecimal(t, "0.19"),
+		DateCreated: &created,
+		DateDue:     &due,
+		LineItems: []LineItem{
+			{
+				ItemNumber:   "1",
+				Description:  "Apples",
+				Quantity:     newDecimal(t, "10"),
+				PricePerUnit: newDecimal(t, "1.05"),
+				TotalAmount:  newDecimal(t, "10.5"),
+			},
+			{
+				ItemNumber:   "2",
+				Description:  "Pears",
+				Quantity:     newDecimal(t, "100"),
+				PricePerUnit: newDecimal(t, "1.2"),
+				TotalAmount:  newDecimal(t, "120"),
+			},
+		},
+	}
+}
+
+func CreateInvoicePayload(t *testing.T, collaborators []identity.DID) documents.CreatePayload {
+	if collaborators == nil {
+		collaborators = []identity.DID{testingidentity.GenerateRandomDID()}
+	}
+
+	d, err := json.Marshal(invoiceData(t))
+	assert.NoError(t, err)
+	return documents.CreatePayload{
+		Scheme: Scheme,
+		Collaborators: documents.CollaboratorsAccess{
+			ReadWriteCollaborators: collaborators,
+		},
+		Data: d,
+	}
+}
+
+func CreateInvoiceWithEmbedCD(t *testing.T, ctx context.Context, did identity.DID, collaborators []identity.DID) (*Invoice, coredocumentpb.CoreDocument) {
+	payload := CreateInvoicePayload(t, collaborators)
+	inv := new(Invoice)
+	payload.Collaborators.ReadWriteCollaborators = append(payload.Collaborators.ReadWriteCollaborators, did)
+	err := inv.DeriveFromCreatePayload(ctx, payload)
+	assert.NoError(t, err)
+	inv.GetTestCoreDocWithReset()
+	sr, err := inv.CalculateSigningRoot()
+	assert.NoError(t, err)
+	// if acc errors out, just skip it
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	acc, err := contextutil.Account(ctx)
+	if err == nil {
+		sig, err := acc.SignMsg(sr)
+		assert.NoError(t, err)
+		inv.AppendSignatures(sig)
+	}
+	_, err = inv.CalculateDocumentRoot()
+	assert.NoError(t, err)
+	cd, err := inv.PackCoreDocument()
+	assert.NoError(t, err)
+	return inv, cd
+}
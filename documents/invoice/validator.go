@@ -0,0 +1,88 @@
+package invoice
+
+import (
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// fieldValidator validates the fields of the invoice model
+func fieldValidator() documents.Validator {
+	return documents.ValidatorFunc(func(_, new documents.Model) error {
+		if new == nil {
+			return documents.ErrDocumentNil
+		}
+
+		inv, ok := new.(*Invoice)
+		if !ok {
+			return documents.ErrDocumentInvalidType
+		}
+
+		var err error
+		d := inv.Data
+		if !documents.IsCurrencyValid(d.Currency) {
+			err = errors.AppendError(err, errors.New("currency is invalid: %s", d.Currency))
+		}
+
+		if d.DateCreated != nil && d.DateDue != nil && d.DateDue.Before(*d.DateCreated) {
+			err = errors.AppendError(err, errors.New("due date must not be before the creation date"))
+		}
+
+		return errors.AppendError(err, validateAmounts(d))
+	})
+}
+
+// validateAmounts checks that quantity * price per unit equals the total amount of every line item,
+// sum of the line item totals equals the net amount, and net amount + tax amount equals the gross amount.
+// Checks are skipped when the amounts are not set.
+func validateAmounts(d Data) (err error) {
+	var sum *documents.Decimal
+	for idx, item := range d.LineItems {
+		if item.TotalAmount == nil {
+			err = errors.AppendError(err, errors.New("line item %d: total amount is missing", idx))
+			continue
+		}
+
+		if sum == nil {
+			sum = item.TotalAmount
+		} else {
+			sum = sum.Add(item.TotalAmount)
+		}
+
+		if item.Quantity == nil || item.PricePerUnit == nil {
+			continue
+		}
+
+		if total := item.Quantity.Mul(item.PricePerUnit); !total.Equal(item.TotalAmount) {
+			err = errors.AppendError(err, errors.New(
+				"line item %d: total amount %s doesn't match quantity * price per unit %s", idx, item.TotalAmount, total))
+		}
+	}
+
+	if sum != nil && d.NetAmount != nil && !sum.Equal(d.NetAmount) {
+		err = errors.AppendError(err, errors.New("net amount %s doesn't match the line items total %s", d.NetAmount, sum))
+	}
+
+	if d.NetAmount != nil && d.TaxAmount != nil && d.GrossAmount != nil {
+		if gross := d.NetAmount.Add(d.TaxAmount); !gross.Equal(d.GrossAmount) {
+			err = errors.AppendError(err, errors.New("gross amount %s doesn't match net amount + tax amount %s", d.GrossAmount, gross))
+		}
+	}
+
+	return err
+}
+
+// CreateValidator returns a validator group that should be run before creating the invoice and persisting it to DB
+func CreateValidator() documents.ValidatorGroup {
+	return documents.ValidatorGroup{
+		fieldValidator(),
+	}
+}
+
+// UpdateValidator returns a validator group that should be run before updating the invoice
+func UpdateValidator(anchorSrv anchors.Service) documents.ValidatorGroup {
+	return documents.ValidatorGroup{
+		fieldValidator(),
+		documents.UpdateVersionValidator(anchorSrv),
+	}
+}
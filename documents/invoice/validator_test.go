@@ -0,0 +1,84 @@
+// +build unit
+
+package invoice
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldValidator_Validate(t *testing.T) {
+	fv := fieldValidator()
+
+	//  nil error
+	err := fv.Validate(nil, nil)
+	assert.Error(t, err)
+	errs := errors.GetErrs(err)
+	assert.Len(t, errs, 1, "errors length must be one")
+	assert.Contains(t, errs[0].Error(), "no(nil) document provided")
+
+	// unknown type
+	err = fv.Validate(nil, &mockModel{})
+	assert.Error(t, err)
+	errs = errors.GetErrs(err)
+	assert.Len(t, errs, 1, "errors length must be one")
+	assert.Contains(t, errs[0].Error(), "document is of invalid type")
+
+	// success
+	inv := &Invoice{Data: invoiceData(t)}
+	assert.NoError(t, fv.Validate(nil, inv))
+
+	// invalid currency and due date
+	inv.Data.Currency = "EURO"
+	due := inv.Data.DateCreated.Add(-1)
+	inv.Data.DateDue = &due
+	err = fv.Validate(nil, inv)
+	assert.Error(t, err)
+	assert.Equal(t, 2, errors.Len(err))
+	assert.Contains(t, err.Error(), "currency is invalid: EURO")
+	assert.Contains(t, err.Error(), "due date must not be before the creation date")
+}
+
+func TestValidateAmounts(t *testing.T) {
+	// valid
+	d := invoiceData(t)
+	assert.NoError(t, validateAmounts(d))
+
+	// amounts are optional
+	assert.NoError(t, validateAmounts(Data{}))
+
+	// line item total doesn't match
+	d.LineItems[0].TotalAmount = newDecimal(t, "11.5")
+	err := validateAmounts(d)
+	assert.Error(t, err)
+	assert.Equal(t, 2, errors.Len(err))
+	assert.Contains(t, err.Error(), "line item 0: total amount 11.5 doesn't match quantity * price per unit 10.5")
+	assert.Contains(t, err.Error(), "net amount 130.5 doesn't match the line items total 131.5")
+
+	// missing line item total
+	d = invoiceData(t)
+	d.LineItems[1].TotalAmount = nil
+	err = validateAmounts(d)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line item 1: total amount is missing")
+
+	// gross amount doesn't match
+	d = invoiceData(t)
+	d.GrossAmount = newDecimal(t, "130.5")
+	err = validateAmounts(d)
+	assert.Error(t, err)
+	assert.Equal(t, 1, errors.Len(err))
+	assert.Contains(t, err.Error(), "gross amount 130.5 doesn't match net amount + tax amount 155.295")
+}
+
+func TestCreateValidator(t *testing.T) {
+	cv := CreateValidator()
+	assert.Len(t, cv, 1)
+}
+
+func TestUpdateValidator(t *testing.T) {
+	uv := UpdateValidator(nil)
+	assert.Len(t, uv, 2)
+}
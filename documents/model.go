@@ -131,6 +131,12 @@ type Model interface {
 	// GetAccessTokens returns the access tokens of a core document
 	GetAccessTokens() ([]*coredocumentpb.AccessToken, error)
 
+	// GrantAccessToken adds an access token to the current version of the document.
+	GrantAccessToken(ctx context.Context, payload AccessTokenParams) (*coredocumentpb.AccessToken, error)
+
+	// RevokeAccessToken removes the access token from the current version of the document.
+	RevokeAccessToken(tokenID []byte) error
+
 	// SetUsedAnchorRepoAddress sets the anchor repository address to which document is anchored to.
 	SetUsedAnchorRepoAddress(addr common.Address)
 
@@ -158,6 +164,22 @@ type Model interface {
 	// UpdateRole updates existing role with provided collaborators
 	UpdateRole(rk []byte, collabs []identity.DID) (*coredocumentpb.Role, error)
 
+	// AddReadRuleForRole creates a new read rule that grants read access to the role.
+	// The role is expected to be present already.
+	AddReadRuleForRole(roleID []byte) (*coredocumentpb.ReadRule, error)
+
+	// SetRoleValidity restricts the role to the validity window.
+	SetRoleValidity(roleID []byte, v Validity) error
+
+	// SetReadRuleValidity restricts the read rules of the role to the validity window.
+	SetReadRuleValidity(roleID []byte, v Validity) error
+
+	// RoleValidity returns the validity windows of the role and its read rules.
+	RoleValidity(roleID []byte) (role, read Validity, err error)
+
+	// IsAuthoringCollaborator returns true if the did is a collaborator of the role created along with the document.
+	IsAuthoringCollaborator(did identity.DID) bool
+
 	// AddTransitionRules creates a new transition rule to edit an attribute.
 	// The access is only given to the roleKey which is expected to be present already.
 	AddTransitionRuleForAttribute(roleID []byte, key AttrKey) (*coredocumentpb.TransitionRule, error)
@@ -167,6 +189,9 @@ type Model interface {
 
 	// DeleteTransitionRule deletes the rule associated with ruleID.
 	DeleteTransitionRule(ruleID []byte) error
+
+	// CopyRules copies the custom roles, attribute transition rules, read rules and access tokens of src to the document.
+	CopyRules(ctx context.Context, src coredocumentpb.CoreDocument, opts CloneOptions) error
 }
 
 // TokenRegistry defines NFT related functions.
@@ -11,6 +11,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -27,6 +30,12 @@ type Config interface {
 // DocumentRequestProcessor offers methods to interact with the p2p layer to request documents.
 type DocumentRequestProcessor interface {
 	RequestDocumentWithAccessToken(ctx context.Context, granterDID identity.DID, tokenIdentifier, documentIdentifier, delegatingDocumentIdentifier []byte) (*p2ppb.GetDocumentResponse, error)
+
+	// RequestApprovals sends the pending version to the approvers so that they can approve it.
+	RequestApprovals(ctx context.Context, model Model, approvers []identity.DID) error
+
+	// SendApproval sends the pending version, with the approval of the account added, to the owner of the version.
+	SendApproval(ctx context.Context, owner identity.DID, model Model) error
 }
 
 // Client defines methods that can be implemented by any type handling p2p communications.
@@ -40,6 +49,15 @@ type Client interface {
 
 	// GetDocumentRequest requests a document from a collaborator
 	GetDocumentRequest(ctx context.Context, requesterID identity.DID, in *p2ppb.GetDocumentRequest) (*p2ppb.GetDocumentResponse, error)
+
+	// GetAttachmentRequest requests the content of a document attachment from a collaborator
+	GetAttachmentRequest(ctx context.Context, requesterID identity.DID, in *p2pcommon.GetAttachmentRequest) (*p2pcommon.GetAttachmentResponse, error)
+
+	// RequestApproval sends the pending version to the approver to request its approval
+	RequestApproval(ctx context.Context, approverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error)
+
+	// SendApproval sends the pending version, approved by the account, back to its owner
+	SendApproval(ctx context.Context, ownerID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error)
 }
 
 // defaultProcessor implements AnchorProcessor interface
@@ -48,15 +66,19 @@ type defaultProcessor struct {
 	p2pClient       Client
 	anchorSrv       anchors.Service
 	config          Config
+	jobManager      jobs.Manager
+	events          notification.EventBus
 }
 
 // DefaultProcessor returns the default implementation of CoreDocument AnchorProcessor
-func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config) AnchorProcessor {
+func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config, jobManager jobs.Manager, events notification.EventBus) AnchorProcessor {
 	return defaultProcessor{
 		identityService: idService,
 		p2pClient:       p2pClient,
 		anchorSrv:       anchorSrv,
 		config:          config,
+		jobManager:      jobManager,
+		events:          events,
 	}
 }
 
@@ -112,7 +134,9 @@ func (dp defaultProcessor) PrepareForSignatureRequests(ctx context.Context, mode
 }
 
 // RequestSignatures gets the core document from the model, validates pre signature requirements,
-// collects signatures, and validates the signatures,
+// collects signatures, and validates the signatures.
+// The status of the signature of each collaborator is recorded on the job.
+// Collection errors are ignored unless the signature policy of the model requires the failed signers.
 func (dp defaultProcessor) RequestSignatures(ctx context.Context, model Model) error {
 	psv := SignatureValidator(dp.identityService, dp.anchorSrv)
 	err := psv.Validate(nil, model)
@@ -120,16 +144,51 @@ func (dp defaultProcessor) RequestSignatures(ctx context.Context, model Model) e
 		return errors.New("failed to validate model for signature request: %v", err)
 	}
 
-	// we ignore signature collection errors and anchor anyways
-	signs, _, err := dp.p2pClient.GetSignaturesForDocument(ctx, model)
+	self, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return ErrDocumentConfigAccountID
+	}
+
+	policy, err := GetSignaturePolicy(model)
+	if err != nil {
+		return err
+	}
+
+	signers, err := model.GetSignerCollaborators(self)
+	if err != nil {
+		return errors.New("failed to get external collaborators: %v", err)
+	}
+
+	dp.recordSignatureStatus(ctx, self, requestedSignatures(signers))
+	signs, errs, err := dp.p2pClient.GetSignaturesForDocument(ctx, model)
 	if err != nil {
 		return errors.New("failed to collect signatures from the collaborators: %v", err)
 	}
 
+	statuses := CollectSignatureStatus(signers, signs, errs)
+	dp.recordSignatureStatus(ctx, self, statuses)
+	if err := policy.Check(self, statuses); err != nil {
+		return err
+	}
+
 	model.AppendSignatures(signs...)
+	publishSignatures(dp.events, self, model, signs)
 	return nil
 }
 
+// recordSignatureStatus persists the status of the signatures on the job in the context, if any.
+// Status is informational, so the errors are only logged.
+func (dp defaultProcessor) recordSignatureStatus(ctx context.Context, self identity.DID, statuses []CollaboratorSignature) {
+	jobID := contextutil.Job(ctx)
+	if dp.jobManager == nil || jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		return
+	}
+
+	if err := saveSignatureStatus(dp.jobManager, self, jobID, statuses); err != nil {
+		log.Error(err)
+	}
+}
+
 // PrepareForAnchoring validates the signatures and generates the document root
 func (dp defaultProcessor) PrepareForAnchoring(model Model) error {
 	psv := SignatureValidator(dp.identityService, dp.anchorSrv)
@@ -238,6 +297,42 @@ func (dp defaultProcessor) RequestDocumentWithAccessToken(ctx context.Context, g
 	return response, nil
 }
 
+// RequestApprovals sends the pending version to the approvers so that they can approve it.
+func (dp defaultProcessor) RequestApprovals(ctx context.Context, model Model, approvers []identity.DID) (err error) {
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return errors.New("failed to pack core document: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dp.config.GetP2PConnectionTimeout())
+	defer cancel()
+	for _, approver := range approvers {
+		resp, erri := dp.p2pClient.RequestApproval(ctx, approver, &p2ppb.AnchorDocumentRequest{Document: &cd})
+		if erri != nil || !resp.Accepted {
+			err = errors.AppendError(err, errors.New("failed to request the approval of %s: %v", approver.String(), erri))
+		}
+	}
+
+	return err
+}
+
+// SendApproval sends the pending version, with the approval of the account added, to the owner of the version.
+func (dp defaultProcessor) SendApproval(ctx context.Context, owner identity.DID, model Model) error {
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return errors.New("failed to pack core document: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dp.config.GetP2PConnectionTimeout())
+	defer cancel()
+	resp, err := dp.p2pClient.SendApproval(ctx, owner, &p2ppb.AnchorDocumentRequest{Document: &cd})
+	if err != nil || !resp.Accepted {
+		return errors.New("failed to send the approval to %s: %v", owner.String(), err)
+	}
+
+	return nil
+}
+
 // SendDocument does post anchor validations and sends the document to collaborators
 func (dp defaultProcessor) SendDocument(ctx context.Context, model Model) error {
 	av := PostAnchoredValidator(dp.identityService, dp.anchorSrv)
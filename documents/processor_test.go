@@ -15,9 +15,12 @@ import (
 	"github.com/centrifuge/go-centrifuge/crypto"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -169,9 +172,15 @@ func (m *mockModel) GetAttributes() []Attribute {
 	return attrs
 }
 
+func (m *mockModel) GetAttribute(key AttrKey) (Attribute, error) {
+	args := m.Called(key)
+	attr, _ := args.Get(0).(Attribute)
+	return attr, args.Error(1)
+}
+
 func TestDefaultProcessor_PrepareForSignatureRequests(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, notification.NewEventBus()).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
@@ -229,7 +238,7 @@ func (p *p2pClient) SendAnchoredDocument(ctx context.Context, receiverID identit
 
 func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, notification.NewEventBus()).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
 	self, err := contextutil.Account(ctxh)
@@ -287,6 +296,7 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.On("Timestamp").Return(time.Now(), nil)
 	model.On("GetAttributes").Return(nil)
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
+	model.On("GetAttribute", mock.Anything).Return(nil, ErrCDAttribute)
 	model.sigs = append(model.sigs, sig)
 	c = new(p2pClient)
 	srv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -308,6 +318,7 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.On("AppendSignatures", []*coredocumentpb.Signature{sig}).Return().Once()
 	model.On("Author").Return(did1, nil)
 	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, testingidentity.GenerateRandomDID()}, nil)
+	model.On("GetAttribute", mock.Anything).Return(nil, ErrCDAttribute)
 	model.On("Timestamp").Return(time.Now(), nil)
 	model.On("GetAttributes").Return(nil)
 	model.sigs = append(model.sigs, sig)
@@ -318,11 +329,43 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	model.AssertExpectations(t)
 	c.AssertExpectations(t)
 	assert.Nil(t, err)
+
+	// signature policy not met
+	signer := testingidentity.GenerateRandomDID()
+	policy, err := NewStringAttribute(signaturePolicyLabel, AttrString,
+		`{"mode":"required","required_signers":["`+signer.String()+`"]}`)
+	assert.NoError(t, err)
+	model = new(mockModel)
+	model.On("ID").Return(id)
+	model.On("CurrentVersion").Return(id)
+	model.On("NextVersion").Return(next)
+	model.On("CalculateSigningRoot").Return(sr, nil)
+	model.On("Signatures").Return()
+	model.On("Author").Return(did1, nil)
+	model.On("GetSignerCollaborators", mock.Anything).Return([]identity.DID{did1, signer}, nil)
+	model.On("GetAttribute", policy.Key).Return(policy, nil)
+	model.On("Timestamp").Return(time.Now(), nil)
+	model.On("GetAttributes").Return(nil)
+	model.sigs = append(model.sigs, sig)
+	c = new(p2pClient)
+	c.On("GetSignaturesForDocument", mock.Anything, model).Return([]*coredocumentpb.Signature{sig}, nil).Once()
+	jobID := jobs.NewJobID()
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("UpdateJobWithValue", did1, jobID, signaturesJobKey, mock.Anything).Return(nil).Twice()
+	dp.p2pClient = c
+	dp.jobManager = jobMan
+	err = dp.RequestSignatures(contextutil.WithJob(ctxh, jobID), model)
+	model.AssertExpectations(t)
+	c.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+	assert.Contains(t, err.Error(), signer.String())
 }
 
 func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, notification.NewEventBus()).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
@@ -400,7 +443,7 @@ func (m mockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anc
 
 func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, notification.NewEventBus()).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -498,7 +541,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 func TestDefaultProcessor_SendDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", mock.Anything, mock.Anything).Return(nil).Once()
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil, notification.NewEventBus()).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -0,0 +1,102 @@
+package documents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nftLabelPrefix is the prefix of the proof label that resolves to the unique NFT field of a registry.
+const nftLabelPrefix = "nft:"
+
+// proofFieldAliases maps the well known proof labels to the property names of the document fields.
+var proofFieldAliases = map[string]string{
+	SigningRootField:      DRTreePrefix + "." + SigningRootField,
+	SignaturesRootField:   DRTreePrefix + "." + SignaturesRootField,
+	"author":              CDTreePrefix + ".author",
+	"timestamp":           CDTreePrefix + ".timestamp",
+	"document_identifier": CDTreePrefix + ".document_identifier",
+	"current_version":     CDTreePrefix + ".current_version",
+	"previous_version":    CDTreePrefix + ".previous_version",
+	"next_version":        CDTreePrefix + ".next_version",
+}
+
+// ProofLabel is a proof label and the property name it resolves to.
+type ProofLabel struct {
+	Label    string `json:"label"`
+	Property string `json:"property"`
+}
+
+// ResolveProofLabels resolves the proof labels to the property names of the fields in the model.
+// A label is either a well known field alias, "nft:<registry>" for the NFT minted in the registry,
+// or the label of a custom attribute.
+// Labels are resolved in the order they are given.
+func ResolveProofLabels(model Model, labels []string) (resolved []ProofLabel, err error) {
+	for _, label := range labels {
+		prop, err := resolveProofLabel(model, label)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrInvalidProofLabel, errors.New("%s: %v", label, err))
+		}
+
+		resolved = append(resolved, ProofLabel{Label: label, Property: prop})
+	}
+
+	return resolved, nil
+}
+
+// ProofLabelFields returns the property names of the resolved labels.
+func ProofLabelFields(labels []ProofLabel) (fields []string) {
+	for _, l := range labels {
+		fields = append(fields, l.Property)
+	}
+
+	return fields
+}
+
+func resolveProofLabel(model Model, label string) (string, error) {
+	if prop, ok := proofFieldAliases[label]; ok {
+		return prop, nil
+	}
+
+	if strings.HasPrefix(label, nftLabelPrefix) {
+		registry := strings.TrimPrefix(label, nftLabelPrefix)
+		if !common.IsHexAddress(registry) {
+			return "", errors.New("invalid registry address")
+		}
+
+		return getNFTUniqueProofKey(model.NFTs(), common.HexToAddress(registry))
+	}
+
+	key, err := AttrKeyFromLabel(label)
+	if err != nil {
+		return "", err
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return "", err
+	}
+
+	return attributeProofField(attr)
+}
+
+// attributeProofField returns the property name of the attribute value field.
+func attributeProofField(attr Attribute) (string, error) {
+	var field string
+	switch attr.Value.Type {
+	case AttrInt256, AttrDecimal, AttrBytes, AttrTimestamp, AttrAttachment:
+		field = "byte_val"
+	case AttrString:
+		field = "str_val"
+	case AttrSigned:
+		field = "signed_val.value"
+	case AttrMonetary:
+		field = "monetary_val.value"
+	default:
+		return "", ErrNotValidAttrType
+	}
+
+	return fmt.Sprintf("%s.attributes[%s].%s", CDTreePrefix, attr.Key.String(), field), nil
+}
@@ -0,0 +1,66 @@
+// +build unit
+
+package documents
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveProofLabels(t *testing.T) {
+	strAttr, err := NewStringAttribute("loan_status", AttrString, "approved")
+	assert.NoError(t, err)
+	decAttr, err := NewStringAttribute("loan_amount", AttrDecimal, "100.001")
+	assert.NoError(t, err)
+	registry := common.BytesToAddress(utils.RandomSlice(common.AddressLength))
+	registryID := append(registry.Bytes(), make([]byte, 12)...)
+
+	model := new(MockModel)
+	model.On("GetAttribute", strAttr.Key).Return(strAttr, nil)
+	model.On("GetAttribute", decAttr.Key).Return(decAttr, nil)
+	model.On("GetAttribute", mock.Anything).Return(nil, ErrCDAttribute)
+	model.On("NFTs").Return([]*coredocumentpb.NFT{{RegistryId: registryID, TokenId: utils.RandomSlice(32)}})
+
+	// success
+	resolved, err := ResolveProofLabels(model, []string{
+		"loan_status", "signing_root", "author", "nft:" + registry.Hex(), "loan_amount"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProofLabel{
+		{Label: "loan_status", Property: fmt.Sprintf("cd_tree.attributes[%s].str_val", strAttr.Key.String())},
+		{Label: "signing_root", Property: "dr_tree.signing_root"},
+		{Label: "author", Property: "cd_tree.author"},
+		{Label: "nft:" + registry.Hex(), Property: fmt.Sprintf("cd_tree.nfts[%s]", hexutil.Encode(registryID))},
+		{Label: "loan_amount", Property: fmt.Sprintf("cd_tree.attributes[%s].byte_val", decAttr.Key.String())},
+	}, resolved)
+	assert.Equal(t, []string{
+		resolved[0].Property, resolved[1].Property, resolved[2].Property, resolved[3].Property, resolved[4].Property,
+	}, ProofLabelFields(resolved))
+
+	// missing attribute
+	_, err = ResolveProofLabels(model, []string{"signing_root", "missing"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidProofLabel, err))
+
+	// empty label
+	_, err = ResolveProofLabels(model, []string{" "})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidProofLabel, err))
+
+	// invalid registry
+	_, err = ResolveProofLabels(model, []string{"nft:0x1234"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidProofLabel, err))
+
+	// missing nft
+	_, err = ResolveProofLabels(model, []string{"nft:" + common.BytesToAddress(utils.RandomSlice(common.AddressLength)).Hex()})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidProofLabel, err))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/contextutil"
@@ -55,6 +56,18 @@ func (cd *CoreDocument) addNewReadRule(roleKey []byte, action coredocumentpb.Act
 	cd.Modified = true
 }
 
+// AddReadRuleForRole adds a new read rule that grants read access to the role.
+// Role must be present to create a rule.
+func (cd *CoreDocument) AddReadRuleForRole(roleID []byte) (*coredocumentpb.ReadRule, error) {
+	_, err := cd.GetRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	cd.addNewReadRule(roleID, coredocumentpb.Action_ACTION_READ)
+	return cd.Document.ReadRules[len(cd.Document.ReadRules)-1], nil
+}
+
 // findRole calls OnRole for every role that matches the actions passed in
 func findReadRole(cd coredocumentpb.CoreDocument, onRole func(rridx, ridx int, role *coredocumentpb.Role) bool, actions ...coredocumentpb.Action) bool {
 	am := make(map[int32]struct{})
@@ -146,10 +159,15 @@ func (cd *CoreDocument) NFTOwnerCanRead(tokenRegistry TokenRegistry, registry co
 }
 
 // AccountCanRead validate if the core document can be read by the account .
-// Returns an error if not.
+// Roles and read rules outside their validity window are ignored.
 func (cd *CoreDocument) AccountCanRead(account identity.DID) bool {
+	now := time.Now().UTC()
 	// loop though read rules, check all the rules
 	return findReadRole(cd.Document, func(_, _ int, role *coredocumentpb.Role) bool {
+		if !cd.readRoleActive(role, now) {
+			return false
+		}
+
 		_, found := isDIDInRole(role, account)
 		return found
 	}, coredocumentpb.Action_ACTION_READ, coredocumentpb.Action_ACTION_READ_SIGN)
@@ -473,6 +491,31 @@ func (cd *CoreDocument) DeleteAccessToken(granteeID identity.DID) (*CoreDocument
 	return nil, ErrAccessTokenNotFound
 }
 
+// GrantAccessToken adds an access token, granting the grantee read access to the document in the payload, to the current version.
+func (cd *CoreDocument) GrantAccessToken(ctx context.Context, payload AccessTokenParams) (*coredocumentpb.AccessToken, error) {
+	at, err := assembleAccessToken(ctx, payload, cd.CurrentVersion())
+	if err != nil {
+		return nil, errors.NewTypedError(ErrAccessTokenInvalid, err)
+	}
+
+	cd.Document.AccessTokens = append(cd.Document.AccessTokens, at)
+	cd.Modified = true
+	return at, nil
+}
+
+// RevokeAccessToken removes the access token from the current version.
+func (cd *CoreDocument) RevokeAccessToken(tokenID []byte) error {
+	for i, at := range cd.Document.AccessTokens {
+		if bytes.Equal(at.Identifier, tokenID) {
+			cd.Document.AccessTokens = append(cd.Document.AccessTokens[:i], cd.Document.AccessTokens[i+1:]...)
+			cd.Modified = true
+			return nil
+		}
+	}
+
+	return ErrAccessTokenNotFound
+}
+
 // RemoveTokenAtIndex removes the access token at index i from slice a
 // Note: changes the order of the slice elements
 func removeTokenAtIndex(idx int, tokens []*coredocumentpb.AccessToken) []*coredocumentpb.AccessToken {
@@ -102,6 +102,34 @@ func TestCoreDocument_addNFTToReadRules(t *testing.T) {
 	assert.Equal(t, enft, cd.Document.Roles[0].Nfts[0])
 }
 
+func TestCoreDocument_AddReadRuleForRole(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+
+	// invalid role key
+	_, err = cd.AddReadRuleForRole(utils.RandomSlice(30))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidRoleKey, err))
+
+	// missing role
+	_, err = cd.AddReadRuleForRole(utils.RandomSlice(32))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrRoleNotExist, err))
+	assert.Nil(t, cd.Document.ReadRules)
+
+	// success
+	did := testingidentity.GenerateRandomDID()
+	role, err := cd.AddRole("auditors", []identity.DID{did})
+	assert.NoError(t, err)
+	assert.False(t, cd.AccountCanRead(did))
+	rule, err := cd.AddReadRuleForRole(role.RoleKey)
+	assert.NoError(t, err)
+	assert.Equal(t, coredocumentpb.Action_ACTION_READ, rule.Action)
+	assert.Equal(t, [][]byte{role.RoleKey}, rule.Roles)
+	assert.Len(t, cd.Document.ReadRules, 1)
+	assert.True(t, cd.AccountCanRead(did))
+}
+
 func TestCoreDocument_NFTOwnerCanRead(t *testing.T) {
 	account := testingidentity.GenerateRandomDID()
 	cd, err := NewCoreDocument(nil, CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{account}}, nil)
@@ -478,6 +506,41 @@ func TestCoreDocumentModel_DeleteAccessToken(t *testing.T) {
 	assert.Equal(t, final.Document.AccessTokens[0].Grantee, did[:])
 }
 
+func TestCoreDocument_GrantAccessToken(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	grantee := testingidentity.GenerateRandomDID()
+	docID := utils.RandomSlice(32)
+
+	// invalid grantee
+	_, err = cd.GrantAccessToken(ctx, AccessTokenParams{Grantee: "grantee", DocumentIdentifier: hexutil.Encode(docID)})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAccessTokenInvalid, err))
+
+	// success
+	at, err := cd.GrantAccessToken(ctx, AccessTokenParams{Grantee: grantee.String(), DocumentIdentifier: hexutil.Encode(docID)})
+	assert.NoError(t, err)
+	assert.Equal(t, grantee[:], at.Grantee)
+	assert.Equal(t, docID, at.DocumentIdentifier)
+	assert.Equal(t, cd.CurrentVersion(), at.DocumentVersion)
+	assert.True(t, cd.Modified)
+	ats, err := cd.GetAccessTokens()
+	assert.NoError(t, err)
+	assert.Equal(t, []*coredocumentpb.AccessToken{at}, ats)
+
+	// missing token
+	err = cd.RevokeAccessToken(utils.RandomSlice(32))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAccessTokenNotFound, err))
+
+	// revoke
+	assert.NoError(t, cd.RevokeAccessToken(at.Identifier))
+	ats, err = cd.GetAccessTokens()
+	assert.NoError(t, err)
+	assert.Len(t, ats, 0)
+}
+
 func calculateBasicDataRoot(t *testing.T, cd *CoreDocument, docType string, dataLeaves []proofs.LeafNode) []byte {
 	trees, _, err := cd.SigningDataTrees(docType, dataLeaves)
 	assert.NoError(t, err)
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -17,6 +20,30 @@ const (
 
 	// LatestPrefix is used to index latest version of the document.
 	LatestPrefix string = "latest_document_"
+
+	// IndexPrefix is used to index the documents owned by an account for listing.
+	IndexPrefix string = "index_document_"
+
+	// ForkPrefix holds the prefix of the forked versions of a document in DB.
+	ForkPrefix string = "fork_document_"
+
+	// InboxPrefix holds the prefix of the inbox items of an account in DB.
+	InboxPrefix string = "inbox_document_"
+
+	// InboxVersionPrefix holds the prefix of the index of the inbox items of an account by version in DB.
+	InboxVersionPrefix string = "inbox_version_document_"
+
+	// AnchorStatePrefix holds the prefix of the anchoring stage of the document versions in DB.
+	AnchorStatePrefix string = "anchor_state_document_"
+
+	// IndexBackfillPrefix holds the prefix of the accounts whose documents, stored before the index, are indexed in DB.
+	IndexBackfillPrefix string = "index_backfill_document_"
+
+	// ReadOnlyPrefix holds the prefix of the documents, imported from bundles, that are read-only in DB.
+	ReadOnlyPrefix string = "read_only_document_"
+
+	// DefaultListLimit is the number of documents returned by List when no limit is provided.
+	DefaultListLimit = 20
 )
 
 type latestVersion struct {
@@ -46,6 +73,117 @@ func (l *latestVersion) FromJSON(data []byte) error {
 	return json.Unmarshal(data, l)
 }
 
+// documentIndex holds the metadata of the latest version of a document.
+// Used to filter documents while listing without loading every model.
+type documentIndex struct {
+	DocumentID     []byte    `json:"document_id"`
+	CurrentVersion []byte    `json:"current_version"`
+	Scheme         string    `json:"scheme"`
+	Status         Status    `json:"status"`
+	Author         []byte    `json:"author"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+// JSON marshals documentIndex to json bytes.
+func (d *documentIndex) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Type returns the type of documentIndex.
+func (d *documentIndex) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+// FromJSON loads json bytes to documentIndex.
+func (d *documentIndex) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// newDocumentIndex returns the document index of the model.
+// Note: anchor timestamp is not available immediately, so current time is used until then.
+func newDocumentIndex(model Model) *documentIndex {
+	idx := &documentIndex{
+		DocumentID:     model.ID(),
+		CurrentVersion: model.CurrentVersion(),
+		Scheme:         model.Scheme(),
+		Status:         model.GetStatus(),
+	}
+
+	author, err := model.Author()
+	if err == nil {
+		idx.Author = author[:]
+	}
+
+	tm, err := model.Timestamp()
+	if err != nil {
+		tm = time.Now().UTC()
+	}
+	idx.Timestamp = tm
+	return idx
+}
+
+// matches returns true if the document index passes all the filters.
+func (d *documentIndex) matches(filter ListFilter) bool {
+	if filter.Scheme != "" && d.Scheme != filter.Scheme {
+		return false
+	}
+
+	if filter.Status != "" && d.Status != filter.Status {
+		return false
+	}
+
+	if filter.Author != nil && !bytes.Equal(d.Author, filter.Author[:]) {
+		return false
+	}
+
+	return filter.InRange(d.Timestamp)
+}
+
+// ListFilter holds the filters and the pagination options to list documents.
+type ListFilter struct {
+	// Scheme of the documents. Ignored if empty.
+	Scheme string
+
+	// Status of the latest version of the documents. Ignored if empty.
+	Status Status
+
+	// Author of the latest version of the documents. Ignored if nil.
+	Author *identity.DID
+
+	// From and To are the inclusive timestamp range of the latest version of the documents.
+	// Ignored if zero.
+	From, To time.Time
+
+	// Cursor is the document ID to start listing from.
+	Cursor []byte
+
+	// Limit is the maximum number of documents to return. DefaultListLimit is used if not provided.
+	Limit int
+}
+
+// Matches returns true if the model passes all the filters.
+func (f ListFilter) Matches(model Model) bool {
+	return newDocumentIndex(model).matches(f)
+}
+
+// InRange returns true if the timestamp falls between From and To.
+func (f ListFilter) InRange(tm time.Time) bool {
+	if !f.From.IsZero() && tm.Before(f.From) {
+		return false
+	}
+
+	return f.To.IsZero() || !tm.After(f.To)
+}
+
+// GetLimit returns the limit of the filter or DefaultListLimit if not set.
+func (f ListFilter) GetLimit() int {
+	if f.Limit <= 0 {
+		return DefaultListLimit
+	}
+
+	return f.Limit
+}
+
 // Repository defines the required methods for a document repository.
 // Can be implemented by any type that stores the documents. Ex: levelDB, sql etc...
 type Repository interface {
@@ -68,16 +206,105 @@ type Repository interface {
 
 	// GetLatest returns the latest version of the document.
 	GetLatest(accountID, docID []byte) (Model, error)
+
+	// List returns the latest versions of the documents, owned by accountID, that match the filter.
+	// next is the cursor to the next page and is empty when there are no more documents.
+	List(accountID []byte, filter ListFilter) (models []Model, next []byte, err error)
+
+	// StoreFork creates or overwrites the fork of the document version, owned by accountID.
+	StoreFork(accountID []byte, fork *Fork) error
+
+	// GetFork returns the fork of the document version, owned by accountID.
+	GetFork(accountID, docID, versionID []byte) (*Fork, error)
+
+	// GetForks returns the forks of the document, owned by accountID.
+	GetForks(accountID, docID []byte) ([]*Fork, error)
+
+	// CreateInboxItem records the item in the inbox of accountID.
+	// Item is not recorded if the inbox already holds an item of the same version.
+	CreateInboxItem(accountID []byte, item *InboxItem) error
+
+	// UpdateInboxItem updates the item in the inbox of accountID.
+	UpdateInboxItem(accountID []byte, item *InboxItem) error
+
+	// GetInboxItem returns the item from the inbox of accountID.
+	GetInboxItem(accountID, id []byte) (*InboxItem, error)
+
+	// ListInbox returns the items of the inbox of accountID, oldest first, that match the filter.
+	// next is the cursor to the next page and is empty when there are no more items.
+	ListInbox(accountID []byte, filter InboxFilter) (items []*InboxItem, next []byte, err error)
+
+	// StoreAnchorState creates or overwrites the anchoring stage of the document version, owned by accountID.
+	StoreAnchorState(accountID []byte, st *AnchorState) error
+
+	// GetAnchorState returns the anchoring stage of the document version, owned by accountID.
+	GetAnchorState(accountID, docID, versionID []byte) (*AnchorState, error)
+
+	// MarkReadOnly marks the document, owned by accountID, as read-only.
+	MarkReadOnly(accountID, docID []byte) error
+
+	// IsReadOnly returns true if the document, owned by accountID, is read-only.
+	IsReadOnly(accountID, docID []byte) bool
+}
+
+// readOnly marks a document, imported from a bundle, as read-only.
+type readOnly struct {
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// JSON marshals readOnly to json bytes.
+func (r *readOnly) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Type returns the type of readOnly.
+func (r *readOnly) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// FromJSON loads json bytes to readOnly.
+func (r *readOnly) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// indexBackfill records that the documents of an account, stored before the index, are indexed.
+type indexBackfill struct {
+	Time time.Time `json:"time"`
+}
+
+// JSON marshals indexBackfill to json bytes.
+func (i *indexBackfill) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// Type returns the type of indexBackfill.
+func (i *indexBackfill) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+// FromJSON loads json bytes to indexBackfill.
+func (i *indexBackfill) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
 }
 
 // NewDBRepository creates an instance of the documents Repository
 func NewDBRepository(db storage.Repository) Repository {
 	db.Register(new(latestVersion))
+	db.Register(new(documentIndex))
+	db.Register(new(Fork))
+	db.Register(new(InboxItem))
+	db.Register(new(inboxVersion))
+	db.Register(new(AnchorState))
+	db.Register(new(readOnly))
+	db.Register(new(indexBackfill))
 	return &repo{db: db}
 }
 
 type repo struct {
 	db storage.Repository
+
+	// backfillMu serialises the backfill of the document indexes.
+	backfillMu sync.Mutex
 }
 
 // getKey returns document_+accountID+id
@@ -144,6 +371,108 @@ func (r *repo) GetLatest(accountID, docID []byte) (Model, error) {
 	return r.Get(accountID, lv.CurrentVersion)
 }
 
+// List returns the latest versions of the documents, owned by accountID, that match the filter.
+// Filters are applied on the document index so only the matched models are loaded.
+// Documents stored before the index are indexed on the first listing of the account.
+func (r *repo) List(accountID []byte, filter ListFilter) (models []Model, next []byte, err error) {
+	err = r.backfillIndexes(accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var start []byte
+	if len(filter.Cursor) > 0 {
+		start = r.getIndexKey(accountID, filter.Cursor)
+	}
+
+	limit := filter.GetLimit()
+	var idxs []*documentIndex
+	err = r.db.Iterate(IndexPrefix+hexutil.Encode(accountID), start, func(key []byte, model storage.Model) bool {
+		idx, ok := model.(*documentIndex)
+		if !ok || !idx.matches(filter) {
+			return true
+		}
+
+		if len(idxs) == limit {
+			next = idx.DocumentID
+			return false
+		}
+
+		idxs = append(idxs, idx)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, idx := range idxs {
+		m, err := r.Get(accountID, idx.CurrentVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		models = append(models, m)
+	}
+
+	return models, next, nil
+}
+
+// getIndexBackfillKey constructs the key to the index backfill of the account.
+func (r *repo) getIndexBackfillKey(accountID []byte) []byte {
+	return append([]byte(IndexBackfillPrefix), []byte(hexutil.Encode(accountID))...)
+}
+
+// backfillIndexes stores the document index of the documents, owned by accountID, stored before the index.
+// Index is stored from the latest version of every document without one.
+// Backfill runs once per account since every document stored afterwards is indexed on store.
+func (r *repo) backfillIndexes(accountID []byte) error {
+	r.backfillMu.Lock()
+	defer r.backfillMu.Unlock()
+	key := r.getIndexBackfillKey(accountID)
+	if r.db.Exists(key) {
+		return nil
+	}
+
+	var docIDs, versions [][]byte
+	err := r.db.Iterate(LatestPrefix+hexutil.Encode(accountID), nil, func(key []byte, model storage.Model) bool {
+		lv, ok := model.(*latestVersion)
+		if !ok {
+			return true
+		}
+
+		id, err := hexutil.Decode(strings.TrimPrefix(string(key), LatestPrefix))
+		if err != nil || len(id) <= len(accountID) {
+			return true
+		}
+
+		docIDs = append(docIDs, id[len(accountID):])
+		versions = append(versions, lv.CurrentVersion)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, docID := range docIDs {
+		if r.db.Exists(r.getIndexKey(accountID, docID)) {
+			continue
+		}
+
+		m, err := r.Get(accountID, versions[i])
+		if err != nil {
+			log.Warningf("failed to backfill the index of document %s: %v", hexutil.Encode(docID), err)
+			continue
+		}
+
+		err = r.storeDocumentIndex(accountID, m)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.db.Create(key, &indexBackfill{Time: time.Now().UTC()})
+}
+
 func (r *repo) getLatest(key []byte) (*latestVersion, error) {
 	val, err := r.db.Get(key)
 	if err != nil {
@@ -171,6 +500,33 @@ func (r *repo) getLatestKey(accountID, docID []byte) []byte {
 	return append([]byte(LatestPrefix), []byte(hexKey)...)
 }
 
+// getIndexKey constructs the key to the document index.
+// Note: DocumentIdentifier needs to be passed here not the versionID.
+func (r *repo) getIndexKey(accountID, docID []byte) []byte {
+	hexKey := hexutil.Encode(append(accountID, docID...))
+	return append([]byte(IndexPrefix), []byte(hexKey)...)
+}
+
+// storeDocumentIndex creates or overwrites the document index with the model metadata.
+func (r *repo) storeDocumentIndex(accID []byte, model Model) error {
+	idx := newDocumentIndex(model)
+	key := r.getIndexKey(accID, model.ID())
+	if r.db.Exists(key) {
+		return r.db.Update(key, idx)
+	}
+
+	return r.db.Create(key, idx)
+}
+
+// storeIndexes stores the latestVersion and the document index of the model.
+func (r *repo) storeIndexes(accID, key []byte, model Model, update bool) error {
+	if err := r.storeLatestIndex(key, model, update); err != nil {
+		return err
+	}
+
+	return r.storeDocumentIndex(accID, model)
+}
+
 // storeLatestIndex stores the latestVersion to db.
 // If update is true, it is assumed that index is overwritten
 // else, index is created first time.
@@ -200,6 +556,7 @@ func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
 // Note: anchor timestamp is not available immediately, so don't error out if the timestamp is empty
 // If found, check if the next version matches the current version of the passed model.
 // If matches, update the timestamp of anchor and return.
+// If the current version is same as the model version, update the index since status might have changed.
 // If not matches, check the model timestamp is greater than stored timestamp.
 // If greater update the latestVersion and return
 // If not, skip update and return.
@@ -208,11 +565,11 @@ func (r *repo) updateLatestIndex(accID []byte, model Model) error {
 	lv, err := r.getLatest(key)
 	if err != nil {
 		// no index is created yet. create one
-		return r.storeLatestIndex(key, model, false)
+		return r.storeIndexes(accID, key, model, false)
 	}
 
-	if bytes.Equal(lv.NextVersion, model.CurrentVersion()) {
-		return r.storeLatestIndex(key, model, true)
+	if bytes.Equal(lv.NextVersion, model.CurrentVersion()) || bytes.Equal(lv.CurrentVersion, model.CurrentVersion()) {
+		return r.storeIndexes(accID, key, model, true)
 	}
 
 	// compare timestamps
@@ -223,9 +580,180 @@ func (r *repo) updateLatestIndex(accID []byte, model Model) error {
 
 	if lv.Timestamp.Before(ts) {
 		// newer version found. so update
-		return r.storeLatestIndex(key, model, true)
+		return r.storeIndexes(accID, key, model, true)
 	}
 
 	// must be an old version.
 	return nil
 }
+
+// getForkKey constructs the key to the fork of the document version.
+func (r *repo) getForkKey(accountID, docID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(append(accountID, docID...), versionID...))
+	return append([]byte(ForkPrefix), []byte(hexKey)...)
+}
+
+// StoreFork creates or overwrites the fork of the document version, owned by accountID.
+func (r *repo) StoreFork(accountID []byte, fork *Fork) error {
+	key := r.getForkKey(accountID, fork.DocumentID, fork.VersionID)
+	if r.db.Exists(key) {
+		return r.db.Update(key, fork)
+	}
+
+	return r.db.Create(key, fork)
+}
+
+// GetFork returns the fork of the document version, owned by accountID.
+func (r *repo) GetFork(accountID, docID, versionID []byte) (*Fork, error) {
+	m, err := r.db.Get(r.getForkKey(accountID, docID, versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrForkNotFound, err)
+	}
+
+	f, ok := m.(*Fork)
+	if !ok {
+		return nil, errors.New("version %s of document %s is not a fork object", hexutil.Encode(versionID), hexutil.Encode(docID))
+	}
+
+	return f, nil
+}
+
+// GetForks returns the forks of the document, owned by accountID.
+func (r *repo) GetForks(accountID, docID []byte) (forks []*Fork, err error) {
+	prefix := ForkPrefix + hexutil.Encode(append(accountID, docID...))
+	err = r.db.Iterate(prefix, nil, func(key []byte, model storage.Model) bool {
+		if f, ok := model.(*Fork); ok {
+			forks = append(forks, f)
+		}
+
+		return true
+	})
+
+	return forks, err
+}
+
+// getInboxKey constructs the key to the inbox item of the account.
+func (r *repo) getInboxKey(accountID, id []byte) []byte {
+	hexKey := hexutil.Encode(append(append([]byte{}, accountID...), id...))
+	return append([]byte(InboxPrefix), []byte(hexKey)...)
+}
+
+// getInboxVersionKey constructs the key to the inbox item index of the version.
+func (r *repo) getInboxVersionKey(accountID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(append([]byte{}, accountID...), versionID...))
+	return append([]byte(InboxVersionPrefix), []byte(hexKey)...)
+}
+
+// CreateInboxItem records the item in the inbox of the account.
+// Item is not recorded if the inbox already holds an item of the same version.
+func (r *repo) CreateInboxItem(accountID []byte, item *InboxItem) error {
+	vkey := r.getInboxVersionKey(accountID, item.VersionID)
+	if r.db.Exists(vkey) {
+		return nil
+	}
+
+	err := r.db.Create(r.getInboxKey(accountID, item.ID), item)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Create(vkey, &inboxVersion{ID: item.ID})
+}
+
+// UpdateInboxItem updates the item in the inbox of the account.
+func (r *repo) UpdateInboxItem(accountID []byte, item *InboxItem) error {
+	return r.db.Update(r.getInboxKey(accountID, item.ID), item)
+}
+
+// GetInboxItem returns the item from the inbox of the account.
+func (r *repo) GetInboxItem(accountID, id []byte) (*InboxItem, error) {
+	m, err := r.db.Get(r.getInboxKey(accountID, id))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInboxItemNotFound, err)
+	}
+
+	item, ok := m.(*InboxItem)
+	if !ok {
+		return nil, errors.NewTypedError(ErrInboxItemNotFound, errors.New("%s is not an inbox item", hexutil.Encode(id)))
+	}
+
+	return item, nil
+}
+
+// ListInbox returns the items of the account inbox, oldest first, that match the filter.
+func (r *repo) ListInbox(accountID []byte, filter InboxFilter) (items []*InboxItem, next []byte, err error) {
+	var start []byte
+	if len(filter.Cursor) > 0 {
+		start = r.getInboxKey(accountID, filter.Cursor)
+	}
+
+	limit := filter.GetLimit()
+	err = r.db.Iterate(InboxPrefix+hexutil.Encode(accountID), start, func(key []byte, model storage.Model) bool {
+		item, ok := model.(*InboxItem)
+		if !ok || !filter.Matches(item) {
+			return true
+		}
+
+		if len(items) == limit {
+			next = item.ID
+			return false
+		}
+
+		items = append(items, item)
+		return true
+	})
+
+	return items, next, err
+}
+
+// getAnchorStateKey constructs the key to the anchoring stage of the document version.
+func (r *repo) getAnchorStateKey(accountID, docID, versionID []byte) []byte {
+	hexKey := hexutil.Encode(append(append(append([]byte{}, accountID...), docID...), versionID...))
+	return append([]byte(AnchorStatePrefix), []byte(hexKey)...)
+}
+
+// StoreAnchorState creates or overwrites the anchoring stage of the document version, owned by accountID.
+func (r *repo) StoreAnchorState(accountID []byte, st *AnchorState) error {
+	key := r.getAnchorStateKey(accountID, st.DocumentID, st.VersionID)
+	if r.db.Exists(key) {
+		return r.db.Update(key, st)
+	}
+
+	return r.db.Create(key, st)
+}
+
+// GetAnchorState returns the anchoring stage of the document version, owned by accountID.
+func (r *repo) GetAnchorState(accountID, docID, versionID []byte) (*AnchorState, error) {
+	m, err := r.db.Get(r.getAnchorStateKey(accountID, docID, versionID))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrAnchorStateNotFound, err)
+	}
+
+	st, ok := m.(*AnchorState)
+	if !ok {
+		return nil, errors.NewTypedError(ErrAnchorStateNotFound, errors.New("version %s of document %s is not an anchor state object", hexutil.Encode(versionID), hexutil.Encode(docID)))
+	}
+
+	return st, nil
+}
+
+// getReadOnlyKey constructs the key marking the document as read-only.
+func (r *repo) getReadOnlyKey(accountID, docID []byte) []byte {
+	hexKey := hexutil.Encode(append(append([]byte{}, accountID...), docID...))
+	return append([]byte(ReadOnlyPrefix), []byte(hexKey)...)
+}
+
+// MarkReadOnly marks the document, owned by accountID, as read-only.
+func (r *repo) MarkReadOnly(accountID, docID []byte) error {
+	key := r.getReadOnlyKey(accountID, docID)
+	if r.db.Exists(key) {
+		return nil
+	}
+
+	return r.db.Create(key, &readOnly{ImportedAt: time.Now().UTC()})
+}
+
+// IsReadOnly returns true if the document, owned by accountID, is read-only.
+func (r *repo) IsReadOnly(accountID, docID []byte) bool {
+	return r.db.Exists(r.getReadOnlyKey(accountID, docID))
+}
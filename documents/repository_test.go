@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,8 +24,12 @@ func getRepository(ctx map[string]interface{}) Repository {
 type doc struct {
 	Model
 	DocID, Current, Next []byte
+	Prev                 []byte
 	SomeString           string `json:"some_string"`
 	Time                 time.Time
+	DocScheme            string
+	Status               Status
+	DocAuthor            identity.DID
 }
 
 type unknownDoc struct {
@@ -54,6 +60,10 @@ func (m *doc) NextVersion() []byte {
 	return m.Next
 }
 
+func (m *doc) PreviousVersion() []byte {
+	return m.Prev
+}
+
 func (m *doc) JSON() ([]byte, error) {
 	return json.Marshal(m)
 }
@@ -70,6 +80,18 @@ func (m *doc) Timestamp() (time.Time, error) {
 	return m.Time, nil
 }
 
+func (m *doc) Scheme() string {
+	return m.DocScheme
+}
+
+func (m *doc) GetStatus() Status {
+	return m.Status
+}
+
+func (m *doc) Author() (identity.DID, error) {
+	return m.DocAuthor, nil
+}
+
 func TestLevelDBRepo_Create_Exists(t *testing.T) {
 	repo := getRepository(ctx)
 	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
@@ -258,3 +280,124 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		NextVersion:    oldN,
 	}, lv)
 }
+
+func TestRepo_List(t *testing.T) {
+	r := getRepository(ctx)
+	r.Register(new(doc))
+	acc := utils.RandomSlice(20)
+	author := testingidentity.GenerateRandomDID()
+	tm := time.Now().UTC()
+
+	// no documents
+	models, next, err := r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	assert.Empty(t, next)
+
+	var docs []*doc
+	for i := 0; i < 5; i++ {
+		id := utils.RandomSlice(32)
+		d := &doc{
+			DocID:     id,
+			Current:   id,
+			Next:      utils.RandomSlice(32),
+			Time:      tm.Add(time.Duration(i) * time.Hour),
+			DocScheme: "generic",
+			Status:    Committed,
+		}
+
+		if i%2 == 0 {
+			d.DocScheme = "entity"
+			d.Status = Committing
+			d.DocAuthor = author
+		}
+
+		assert.NoError(t, r.Create(acc, id, d))
+		docs = append(docs, d)
+	}
+
+	// other account documents are not listed
+	id := utils.RandomSlice(32)
+	assert.NoError(t, r.Create(utils.RandomSlice(20), id, &doc{DocID: id, Current: id}))
+	models, next, err = r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, models, 5)
+	assert.Empty(t, next)
+
+	// scheme filter
+	models, _, err = r.List(acc, ListFilter{Scheme: "entity"})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	// status filter
+	models, _, err = r.List(acc, ListFilter{Status: Committed})
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+
+	// author filter
+	models, _, err = r.List(acc, ListFilter{Author: &author})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	// timestamp range
+	models, _, err = r.List(acc, ListFilter{From: tm.Add(time.Hour), To: tm.Add(3 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+
+	// pagination
+	seen := make(map[string]bool)
+	filter := ListFilter{Limit: 2}
+	for i := 0; i < 3; i++ {
+		models, next, err = r.List(acc, filter)
+		assert.NoError(t, err)
+		for _, m := range models {
+			seen[string(m.ID())] = true
+		}
+		filter.Cursor = next
+	}
+	assert.Empty(t, next)
+	assert.Len(t, seen, 5)
+
+	// status update is reflected in the index
+	d := docs[0]
+	d.Status = Committed
+	assert.NoError(t, r.Update(acc, d.Current, d))
+	models, _, err = r.List(acc, ListFilter{Status: Committed})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+}
+
+func TestRepo_List_BackfillIndexes(t *testing.T) {
+	r := getRepository(ctx)
+	r.Register(new(doc))
+	rr := r.(*repo)
+	acc := utils.RandomSlice(20)
+
+	// documents stored before the index
+	var ids [][]byte
+	for i := 0; i < 3; i++ {
+		id := utils.RandomSlice(32)
+		d := &doc{DocID: id, Current: id, Next: utils.RandomSlice(32), Time: time.Now().UTC(), Status: Committed}
+		assert.NoError(t, rr.db.Create(rr.getKey(acc, id), d))
+		assert.NoError(t, rr.storeLatestIndex(rr.getLatestKey(acc, id), d, false))
+		ids = append(ids, id)
+	}
+
+	models, next, err := r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Len(t, models, 3)
+	for _, id := range ids {
+		assert.True(t, rr.db.Exists(rr.getIndexKey(acc, id)))
+	}
+	assert.True(t, rr.db.Exists(rr.getIndexBackfillKey(acc)))
+
+	// backfill runs once per account
+	id := utils.RandomSlice(32)
+	d := &doc{DocID: id, Current: id, Next: utils.RandomSlice(32), Time: time.Now().UTC(), Status: Committed}
+	assert.NoError(t, rr.db.Create(rr.getKey(acc, id), d))
+	assert.NoError(t, rr.storeLatestIndex(rr.getLatestKey(acc, id), d, false))
+	models, _, err = r.List(acc, ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+}
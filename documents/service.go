@@ -32,6 +32,20 @@ type DocumentProof struct {
 	SignaturesRoot []byte
 }
 
+// VersionInfo holds the metadata of a single version of a document.
+type VersionInfo struct {
+	VersionID       []byte
+	PreviousVersion []byte
+	Author          identity.DID
+	Timestamp       time.Time
+	Status          Status
+
+	// Anchored is true if the document root of the version is found on chain.
+	Anchored     bool
+	AnchorID     []byte
+	DocumentRoot []byte
+}
+
 // Patcher interface defines a Patch method for inner Models
 type Patcher interface {
 	// Patch merges payload data into model
@@ -96,6 +110,46 @@ type Service interface {
 
 	// New returns a new uninitialised document.
 	New(scheme string) (Model, error)
+
+	// List returns the latest versions of the documents, owned by the account, that match the filter.
+	// next is the cursor to the next page and is empty when there are no more documents.
+	List(ctx context.Context, filter ListFilter) (models []Model, next []byte, err error)
+
+	// GetVersions walks the version chain of the document, from the latest version to the first,
+	// starting at cursor if provided, and returns at most limit versions.
+	// next is the cursor to the next page and is empty when there are no more versions.
+	GetVersions(ctx context.Context, documentID, cursor []byte, limit int) (versions []VersionInfo, next []byte, err error)
+
+	// ExportBundle packs the anchored version of the document, along with its signatures and anchor, into a bundle.
+	// Latest version is exported if the versionID is empty.
+	ExportBundle(ctx context.Context, documentID, versionID []byte) ([]byte, error)
+
+	// ImportBundle validates the anchored version in the bundle and stores it as a read-only committed version.
+	ImportBundle(ctx context.Context, data []byte) (Model, error)
+
+	// GetForks returns the forked versions of the document recorded for the account.
+	GetForks(ctx context.Context, documentID []byte) ([]*Fork, error)
+
+	// Rebase computes the changes made in the forked version since its previous version
+	// to be applied on the latest committed version of the document.
+	Rebase(ctx context.Context, documentID, versionID []byte) (Rebase, error)
+
+	// ListInbox returns the received versions in the inbox of the account, oldest first, that match the filter.
+	// Listing does not change the read state of the items.
+	ListInbox(ctx context.Context, filter InboxFilter) (items []*InboxItem, next []byte, err error)
+
+	// MarkInboxItemRead marks the item in the inbox of the account as read.
+	MarkInboxItemRead(ctx context.Context, id []byte) (*InboxItem, error)
+
+	// AckInboxItem marks the item in the inbox of the account as acknowledged.
+	AckInboxItem(ctx context.Context, id []byte) (*InboxItem, error)
+
+	// DryRun runs the checks applied on commit against a copy of the model and the latest committed version,
+	// without side effects, and returns every failure.
+	DryRun(ctx context.Context, model Model) ([]ValidationFailure, error)
+
+	// RetryCommit resumes the anchoring of the latest version of the document from the last stage completed.
+	RetryCommit(ctx context.Context, documentID []byte) (Model, jobs.JobID, error)
 }
 
 // service implements Service
@@ -108,6 +162,7 @@ type service struct {
 	idService  identity.Service
 	queueSrv   queue.TaskQueuer
 	jobManager jobs.Manager
+	events     notification.EventBus
 }
 
 var srvLog = logging.Logger("document-service")
@@ -120,7 +175,8 @@ func DefaultService(
 	registry *ServiceRegistry,
 	idService identity.Service,
 	queueSrv queue.TaskQueuer,
-	jobManager jobs.Manager) Service {
+	jobManager jobs.Manager,
+	events notification.EventBus) Service {
 	return service{
 		config:     config,
 		repo:       repo,
@@ -130,6 +186,7 @@ func DefaultService(
 		idService:  idService,
 		queueSrv:   queueSrv,
 		jobManager: jobManager,
+		events:     events,
 	}
 }
 
@@ -280,6 +337,12 @@ func (s service) ReceiveAnchoredDocument(ctx context.Context, model Model, colla
 		return errors.NewTypedError(ErrDocumentInvalid, err)
 	}
 
+	// the received version replaces the local version with the same version ID.
+	err = s.recordReceivedFork(did[:], model)
+	if err != nil {
+		log.Errorf("failed to record the fork: %v", err)
+	}
+
 	// set the status to committed since the document is anchored already.
 	if err := model.SetStatus(Committed); err != nil {
 		return err
@@ -290,6 +353,12 @@ func (s service) ReceiveAnchoredDocument(ctx context.Context, model Model, colla
 		return errors.NewTypedError(ErrDocumentPersistence, err)
 	}
 
+	err = s.recordInboxItem(did[:], model, collaborator)
+	if err != nil {
+		log.Errorf("failed to record the inbox item: %v", err)
+	}
+
+	publishVersion(s.events, notification.VersionReceived, did, &collaborator, old, model)
 	notificationMsg := notification.Message{
 		EventType:    notification.ReceivedPayload,
 		AccountID:    did.String(),
@@ -361,6 +430,10 @@ func (s service) Create(ctx context.Context, model Model) (Model, jobs.JobID, ch
 }
 
 func (s service) Update(ctx context.Context, model Model) (Model, jobs.JobID, chan error, error) {
+	if err := s.checkWritable(ctx, model.ID()); err != nil {
+		return nil, jobs.NilJobID(), nil, err
+	}
+
 	srv, err := s.getService(model)
 	if err != nil {
 		return nil, jobs.NilJobID(), nil, errors.New("failed to get service: %v", err)
@@ -383,6 +456,10 @@ func (s service) CreateModel(ctx context.Context, payload CreatePayload) (Model,
 }
 
 func (s service) UpdateModel(ctx context.Context, payload UpdatePayload) (Model, jobs.JobID, error) {
+	if err := s.checkWritable(ctx, payload.DocumentID); err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
 	srv, err := s.registry.LocateService(payload.Scheme)
 	if err != nil {
 		return nil, jobs.NilJobID(), errors.NewTypedError(ErrDocumentSchemeUnknown, err)
@@ -412,6 +489,10 @@ func (s service) Derive(ctx context.Context, payload UpdatePayload) (Model, erro
 		return doc, nil
 	}
 
+	if err := s.checkWritable(ctx, payload.DocumentID); err != nil {
+		return nil, err
+	}
+
 	old, err := s.GetCurrentVersion(ctx, payload.DocumentID)
 	if err != nil {
 		return nil, err
@@ -459,6 +540,10 @@ func (s service) Commit(ctx context.Context, model Model) (jobs.JobID, error) {
 		return jobs.NilJobID(), ErrDocumentConfigAccountID
 	}
 
+	if err := s.checkWritable(ctx, model.ID()); err != nil {
+		return jobs.NilJobID(), err
+	}
+
 	// Get latest committed version
 	old, err := s.GetCurrentVersion(ctx, model.ID())
 	if err != nil && !errors.IsOfType(ErrDocumentNotFound, err) {
@@ -496,3 +581,86 @@ func (s service) New(scheme string) (Model, error) {
 
 	return srv.New(scheme)
 }
+
+// List returns the latest versions of the documents, owned by the account, that match the filter.
+func (s service) List(ctx context.Context, filter ListFilter) (models []Model, next []byte, err error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, nil, ErrDocumentConfigAccountID
+	}
+
+	return s.repo.List(did[:], filter)
+}
+
+// GetVersions walks the version chain of the document, from the latest version to the first,
+// starting at cursor if provided, and returns at most limit versions.
+// Walk stops if a previous version is not present locally.
+func (s service) GetVersions(ctx context.Context, documentID, cursor []byte, limit int) (versions []VersionInfo, next []byte, err error) {
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	versionID := cursor
+	if len(versionID) == 0 {
+		latest, err := s.GetCurrentVersion(ctx, documentID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		versionID = latest.CurrentVersion()
+	}
+
+	for !utils.IsEmptyByteSlice(versionID) {
+		if len(versions) == limit {
+			return versions, versionID, nil
+		}
+
+		model, err := s.getVersion(ctx, documentID, versionID)
+		if err != nil {
+			if len(versions) == 0 {
+				return nil, nil, err
+			}
+
+			srvLog.Warningf("version chain of document %x is missing version %x: %v", documentID, versionID, err)
+			break
+		}
+
+		versions = append(versions, s.versionInfo(model))
+		versionID = model.PreviousVersion()
+	}
+
+	return versions, nil, nil
+}
+
+// versionInfo returns the version metadata of the model.
+// Anchor details are only checked for committed versions.
+func (s service) versionInfo(model Model) VersionInfo {
+	vi := VersionInfo{
+		VersionID:       model.CurrentVersion(),
+		PreviousVersion: model.PreviousVersion(),
+		Status:          model.GetStatus(),
+	}
+
+	// author and timestamp are not available until the version is signed.
+	vi.Author, _ = model.Author()
+	vi.Timestamp, _ = model.Timestamp()
+	if vi.Status != Committed {
+		return vi
+	}
+
+	anchorID, err := anchors.ToAnchorID(model.CurrentVersion())
+	if err != nil {
+		return vi
+	}
+
+	vi.AnchorID = anchorID[:]
+	docRoot, _, err := s.anchorSrv.GetAnchorData(anchorID)
+	if err != nil {
+		srvLog.Warningf("failed to get anchor data for version %x: %v", model.CurrentVersion(), err)
+		return vi
+	}
+
+	vi.Anchored = true
+	vi.DocumentRoot = docRoot[:]
+	return vi
+}
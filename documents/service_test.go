@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -41,6 +42,7 @@ func TestService_Validate(t *testing.T) {
 	m.On("NextVersion").Return(nid)
 	m.On("PreviousVersion").Return(nid)
 	m.On("Scheme", mock.Anything).Return("generic")
+	m.On("GetAttributes").Return(nil)
 	anchorSrv := new(mockAnchorService)
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(utils.RandomSlice(32), time.Now(), nil)
 	s.anchorSrv = anchorSrv
@@ -62,6 +64,7 @@ func TestService_Validate(t *testing.T) {
 	m1.On("NextVersion").Return(nid1)
 	m1.On("PreviousVersion").Return(id)
 	m1.On("Scheme", mock.Anything).Return("generic")
+	m1.On("GetAttributes").Return(nil)
 	anchorSrv = new(mockAnchorService)
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(utils.RandomSlice(32), time.Now(), nil)
 	s.anchorSrv = anchorSrv
@@ -116,6 +119,7 @@ func TestService_Commit(t *testing.T) {
 	m.On("NextVersion").Return(nid)
 	m.On("PreviousVersion").Return(nid)
 	mr = new(MockRepository)
+	mr.On("IsReadOnly", mock.Anything, id).Return(false)
 	mr.On("GetLatest", mock.Anything, mock.Anything).Return(nil, ErrDocumentVersionNotFound)
 	s.repo = mr
 	anchorSrv := new(mockAnchorService)
@@ -139,6 +143,7 @@ func TestService_Commit(t *testing.T) {
 	jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(jobs.NilJobID(), make(chan error), errors.New("error anchoring"))
 	s.jobManager = jobMan
 	mr = new(MockRepository)
+	mr.On("IsReadOnly", mock.Anything, id).Return(false)
 	mr.On("GetLatest", mock.Anything, mock.Anything).Return(nil, ErrDocumentVersionNotFound)
 	mr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	s.repo = mr
@@ -151,6 +156,15 @@ func TestService_Commit(t *testing.T) {
 	s.jobManager = jobMan
 	_, err = s.Commit(ctxh, m)
 	assert.NoError(t, err)
+
+	// read-only document
+	mr = new(MockRepository)
+	mr.On("IsReadOnly", did[:], id).Return(true).Once()
+	s.repo = mr
+	_, err = s.Commit(ctxh, m)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentReadOnly, err))
+	mr.AssertExpectations(t)
 }
 
 func TestService_Derive(t *testing.T) {
@@ -190,9 +204,17 @@ func TestService_Derive(t *testing.T) {
 	// missing old version
 	docID := utils.RandomSlice(32)
 	repo := new(MockRepository)
-	repo.On("GetLatest", did[:], docID).Return(nil, ErrDocumentNotFound).Once()
+	repo.On("IsReadOnly", did[:], docID).Return(true).Once()
 	s.repo = repo
 	payload.DocumentID = docID
+
+	// read-only document
+	_, err = s.Derive(ctx, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentReadOnly, err))
+
+	repo.On("IsReadOnly", did[:], docID).Return(false)
+	repo.On("GetLatest", did[:], docID).Return(nil, ErrDocumentNotFound).Once()
 	_, err = s.Derive(ctx, payload)
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
@@ -220,3 +242,87 @@ func TestService_Derive(t *testing.T) {
 	repo.AssertExpectations(t)
 	docSrv.AssertExpectations(t)
 }
+
+func TestService_GetVersions(t *testing.T) {
+	s := service{repo: getRepository(ctx)}
+	s.repo.Register(new(doc))
+
+	// missing account
+	docID := utils.RandomSlice(32)
+	_, _, err := s.GetVersions(context.Background(), docID, nil, 0)
+	assert.Error(t, err)
+
+	// missing document
+	ctxh := testingconfig.CreateAccountContext(t, cfg)
+	_, _, err = s.GetVersions(ctxh, docID, nil, 0)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+
+	// three versions, last one is committing
+	var docs []*doc
+	prev, current := []byte(nil), docID
+	tm := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		d := &doc{
+			DocID:     docID,
+			Current:   current,
+			Next:      utils.RandomSlice(32),
+			Prev:      prev,
+			Time:      tm.Add(time.Duration(i) * time.Minute),
+			DocAuthor: did,
+			Status:    Committed,
+		}
+		if i == 2 {
+			d.Status = Committing
+		}
+
+		assert.NoError(t, s.repo.Create(did[:], d.Current, d))
+		docs = append(docs, d)
+		prev, current = d.Current, d.Next
+	}
+
+	anchorSrv := new(mockAnchorService)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, nil, errors.New("missing")).Once()
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(anchors.DocumentRoot{1}, time.Now(), nil).Once()
+	s.anchorSrv = anchorSrv
+	versions, next, err := s.GetVersions(ctxh, docID, nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, docs[2].Current, versions[0].VersionID)
+	assert.Equal(t, Committing, versions[0].Status)
+	assert.False(t, versions[0].Anchored)
+	assert.Equal(t, docs[1].Current, versions[1].VersionID)
+	assert.NotEmpty(t, versions[1].AnchorID)
+	assert.False(t, versions[1].Anchored)
+	assert.Equal(t, docs[0].Current, versions[2].VersionID)
+	assert.True(t, versions[2].Anchored)
+	assert.Equal(t, did, versions[2].Author)
+
+	// paginated
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(anchors.DocumentRoot{1}, time.Now(), nil)
+	versions, next, err = s.GetVersions(ctxh, docID, nil, 2)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, docs[0].Current, next)
+	versions, next, err = s.GetVersions(ctxh, docID, next, 2)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+	assert.Empty(t, next)
+	assert.Equal(t, docs[0].Current, versions[0].VersionID)
+
+	// missing previous version stops the walk
+	d := &doc{
+		DocID:   docID,
+		Current: docs[2].Next,
+		Next:    utils.RandomSlice(32),
+		Prev:    utils.RandomSlice(32),
+		Time:    tm.Add(time.Hour),
+		Status:  Committing,
+	}
+	assert.NoError(t, s.repo.Create(did[:], d.Current, d))
+	versions, next, err = s.GetVersions(ctxh, docID, d.Current, 0)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 1)
+	assert.Empty(t, next)
+}
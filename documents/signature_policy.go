@@ -0,0 +1,275 @@
+package documents
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+const (
+	// signaturePolicyLabel is the label of the attribute holding the signature policy.
+	signaturePolicyLabel = "_signature_policy"
+
+	// signaturesJobKey is the key of the job value holding the status of the signatures requested from the collaborators.
+	signaturesJobKey = "collaborator_signatures"
+)
+
+// SignatureStatus is the status of the signature requested from a collaborator.
+type SignatureStatus string
+
+const (
+	// SignatureRequested is the status of the signature until the collaborator responds.
+	SignatureRequested SignatureStatus = "requested"
+
+	// SignatureSigned is the status of the signature once the collaborator signs the document.
+	SignatureSigned SignatureStatus = "signed"
+
+	// SignatureRejected is the status of the signature if the collaborator refuses to sign the document
+	// or responds with an invalid signature.
+	SignatureRejected SignatureStatus = "rejected"
+
+	// SignatureTimedOut is the status of the signature if the collaborator doesn't respond in time.
+	SignatureTimedOut SignatureStatus = "timed_out"
+
+	// SignatureUnreachable is the status of the signature if the request cannot be delivered to the collaborator.
+	SignatureUnreachable SignatureStatus = "unreachable"
+)
+
+// CollaboratorSignature holds the status of the signature requested from the collaborator.
+type CollaboratorSignature struct {
+	Collaborator identity.DID    `json:"collaborator" swaggertype:"primitive,string"`
+	Status       SignatureStatus `json:"status"`
+
+	// Reason is the reason the signature was not collected. Empty if signed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// SignatureCollectionError is the failure to collect the signature of the collaborator.
+type SignatureCollectionError struct {
+	Collaborator identity.DID
+	Status       SignatureStatus
+	Err          error
+}
+
+// NewSignatureCollectionError returns the failure to collect the signature of the collaborator.
+func NewSignatureCollectionError(collaborator identity.DID, status SignatureStatus, err error) error {
+	return &SignatureCollectionError{Collaborator: collaborator, Status: status, Err: err}
+}
+
+// Error returns the error in string.
+func (e *SignatureCollectionError) Error() string {
+	return fmt.Sprintf("failed to collect the signature of %s: %s: %v", e.Collaborator.String(), e.Status, e.Err)
+}
+
+// CollectSignatureStatus returns the status of the signatures requested from the signers,
+// given the collected signatures and the collection errors.
+// Signers that neither signed nor failed are marked as rejected.
+func CollectSignatureStatus(signers []identity.DID, sigs []*coredocumentpb.Signature, errs []error) []CollaboratorSignature {
+	signed := make(map[identity.DID]bool)
+	for _, sig := range sigs {
+		did, err := identity.NewDIDFromBytes(sig.SignerId)
+		if err != nil {
+			continue
+		}
+
+		signed[did] = true
+	}
+
+	failed := make(map[identity.DID]*SignatureCollectionError)
+	for _, err := range errs {
+		if serr, ok := err.(*SignatureCollectionError); ok {
+			failed[serr.Collaborator] = serr
+		}
+	}
+
+	statuses := make([]CollaboratorSignature, len(signers))
+	for i, signer := range signers {
+		statuses[i] = CollaboratorSignature{Collaborator: signer, Status: SignatureSigned}
+		if signed[signer] {
+			continue
+		}
+
+		statuses[i].Status, statuses[i].Reason = SignatureRejected, "no signature received"
+		if serr, ok := failed[signer]; ok {
+			statuses[i].Status, statuses[i].Reason = serr.Status, serr.Err.Error()
+		}
+	}
+
+	return statuses
+}
+
+// requestedSignatures returns the status of the signatures just requested from the signers.
+func requestedSignatures(signers []identity.DID) []CollaboratorSignature {
+	statuses := make([]CollaboratorSignature, len(signers))
+	for i, signer := range signers {
+		statuses[i] = CollaboratorSignature{Collaborator: signer, Status: SignatureRequested}
+	}
+
+	return statuses
+}
+
+// saveSignatureStatus persists the status of the signatures on the job.
+func saveSignatureStatus(jobMan jobs.Manager, accountID identity.DID, jobID jobs.JobID, statuses []CollaboratorSignature) error {
+	d, err := json.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+
+	return jobMan.UpdateJobWithValue(accountID, jobID, signaturesJobKey, d)
+}
+
+// GetSignatureStatus returns the status of the signatures requested from the collaborators within the job.
+// Returns nil if the job didn't request any signatures.
+func GetSignatureStatus(job *jobs.Job) ([]CollaboratorSignature, error) {
+	v, ok := job.Values[signaturesJobKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var statuses []CollaboratorSignature
+	return statuses, json.Unmarshal(v.Value, &statuses)
+}
+
+// SignaturePolicyMode decides when the document is anchored if collaborators fail to sign it.
+type SignaturePolicyMode string
+
+const (
+	// SignaturePolicyBestEffort anchors the document with the signatures collected. Default mode.
+	SignaturePolicyBestEffort SignaturePolicyMode = "best_effort"
+
+	// SignaturePolicyAll fails the commit unless all the signers sign the document.
+	SignaturePolicyAll SignaturePolicyMode = "all"
+
+	// SignaturePolicyRequired fails the commit unless the required signers sign the document.
+	SignaturePolicyRequired SignaturePolicyMode = "required"
+)
+
+// SignaturePolicy decides if the document is anchored given the signatures collected from the collaborators.
+type SignaturePolicy struct {
+	Mode SignaturePolicyMode `json:"mode"`
+
+	// RequiredSigners must sign the document if the mode is SignaturePolicyRequired.
+	RequiredSigners []identity.DID `json:"required_signers" swaggertype:"array,string"`
+}
+
+// validate checks that the mode is known and the required signers are signers of the model.
+func (p SignaturePolicy) validate(model Model) error {
+	switch p.Mode {
+	case SignaturePolicyBestEffort, SignaturePolicyAll:
+		if len(p.RequiredSigners) > 0 {
+			return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("required signers are only allowed in %s mode", SignaturePolicyRequired))
+		}
+
+		return nil
+	case SignaturePolicyRequired:
+	default:
+		return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unknown mode %q", p.Mode))
+	}
+
+	if len(p.RequiredSigners) < 1 {
+		return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("required signers missing"))
+	}
+
+	signers, err := model.GetSignerCollaborators()
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidSignaturePolicy, err)
+	}
+
+	for _, rs := range p.RequiredSigners {
+		if !containsDID(signers, rs) {
+			return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("%s is not a signer of the document", rs.String()))
+		}
+	}
+
+	return nil
+}
+
+// Check returns ErrSignaturePolicyNotMet if the signatures collected by the account don't satisfy the policy.
+func (p SignaturePolicy) Check(self identity.DID, statuses []CollaboratorSignature) (err error) {
+	if p.Mode != SignaturePolicyAll && p.Mode != SignaturePolicyRequired {
+		return nil
+	}
+
+	for _, st := range statuses {
+		if st.Status == SignatureSigned {
+			continue
+		}
+
+		if p.Mode == SignaturePolicyRequired && !containsDID(p.RequiredSigners, st.Collaborator) {
+			continue
+		}
+
+		err = errors.AppendError(err, errors.New("%s: %s: %s", st.Collaborator.String(), st.Status, st.Reason))
+	}
+
+	// required signers that are no longer signers of the document cannot sign it
+	for _, rs := range p.RequiredSigners {
+		if rs.Equal(self) || containsStatus(statuses, rs) {
+			continue
+		}
+
+		err = errors.AppendError(err, errors.New("%s: not a signer of the document", rs.String()))
+	}
+
+	if err != nil {
+		return errors.NewTypedError(ErrSignaturePolicyNotMet, err)
+	}
+
+	return nil
+}
+
+// containsStatus returns true if the status of the collaborator is in statuses.
+func containsStatus(statuses []CollaboratorSignature, collaborator identity.DID) bool {
+	for _, st := range statuses {
+		if st.Collaborator.Equal(collaborator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetSignaturePolicy sets the signature policy of the pending version.
+func SetSignaturePolicy(model Model, p SignaturePolicy) error {
+	if err := p.validate(model); err != nil {
+		return err
+	}
+
+	d, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	attr, err := NewStringAttribute(signaturePolicyLabel, AttrString, string(d))
+	if err != nil {
+		return err
+	}
+
+	return model.AddAttributes(CollaboratorsAccess{}, false, attr)
+}
+
+// GetSignaturePolicy returns the signature policy of the model.
+// Returns SignaturePolicyBestEffort policy if the model doesn't have one.
+func GetSignaturePolicy(model Model) (p SignaturePolicy, err error) {
+	p.Mode = SignaturePolicyBestEffort
+	key, err := AttrKeyFromLabel(signaturePolicyLabel)
+	if err != nil {
+		return p, err
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return p, nil
+	}
+
+	err = json.Unmarshal([]byte(attr.Value.Str), &p)
+	if err != nil {
+		return p, errors.NewTypedError(ErrInvalidSignaturePolicy, err)
+	}
+
+	return p, nil
+}
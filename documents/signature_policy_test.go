@@ -0,0 +1,124 @@
+// +build unit
+
+package documents
+
+import (
+	"encoding/json"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func (m *coreDocModel) GetSignerCollaborators(filterIDs ...identity.DID) ([]identity.DID, error) {
+	return m.cd.GetSignerCollaborators(filterIDs...)
+}
+
+func TestCollectSignatureStatus(t *testing.T) {
+	signed := testingidentity.GenerateRandomDID()
+	timedOut := testingidentity.GenerateRandomDID()
+	silent := testingidentity.GenerateRandomDID()
+	sigs := []*coredocumentpb.Signature{{SignerId: signed[:]}}
+	errs := []error{
+		NewSignatureCollectionError(timedOut, SignatureTimedOut, errors.New("context deadline exceeded")),
+		errors.New("some error"),
+	}
+
+	statuses := CollectSignatureStatus([]identity.DID{signed, timedOut, silent}, sigs, errs)
+	assert.Equal(t, []CollaboratorSignature{
+		{Collaborator: signed, Status: SignatureSigned},
+		{Collaborator: timedOut, Status: SignatureTimedOut, Reason: "context deadline exceeded"},
+		{Collaborator: silent, Status: SignatureRejected, Reason: "no signature received"},
+	}, statuses)
+}
+
+func TestGetSignatureStatus(t *testing.T) {
+	job := jobs.NewJob(testingidentity.GenerateRandomDID(), "some job")
+
+	// no signatures requested
+	statuses, err := GetSignatureStatus(job)
+	assert.NoError(t, err)
+	assert.Nil(t, statuses)
+
+	// signatures requested
+	sts := requestedSignatures([]identity.DID{testingidentity.GenerateRandomDID()})
+	d, err := json.Marshal(sts)
+	assert.NoError(t, err)
+	job.Values[signaturesJobKey] = jobs.JobValue{Key: signaturesJobKey, Value: d}
+	statuses, err = GetSignatureStatus(job)
+	assert.NoError(t, err)
+	assert.Equal(t, sts, statuses)
+}
+
+func TestSignaturePolicy_Check(t *testing.T) {
+	self := testingidentity.GenerateRandomDID()
+	signed := testingidentity.GenerateRandomDID()
+	rejected := testingidentity.GenerateRandomDID()
+	statuses := []CollaboratorSignature{
+		{Collaborator: signed, Status: SignatureSigned},
+		{Collaborator: rejected, Status: SignatureRejected, Reason: "invalid signature"},
+	}
+
+	// best effort
+	assert.NoError(t, SignaturePolicy{Mode: SignaturePolicyBestEffort}.Check(self, statuses))
+
+	// all
+	err := SignaturePolicy{Mode: SignaturePolicyAll}.Check(self, statuses)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+	assert.Contains(t, err.Error(), rejected.String())
+
+	// required signers signed
+	p := SignaturePolicy{Mode: SignaturePolicyRequired, RequiredSigners: []identity.DID{self, signed}}
+	assert.NoError(t, p.Check(self, statuses))
+
+	// required signer rejected
+	p.RequiredSigners = append(p.RequiredSigners, rejected)
+	err = p.Check(self, statuses)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+
+	// required signer not requested
+	p.RequiredSigners = []identity.DID{testingidentity.GenerateRandomDID()}
+	err = p.Check(self, statuses)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSignaturePolicyNotMet, err))
+}
+
+func TestSetSignaturePolicy(t *testing.T) {
+	signer := testingidentity.GenerateRandomDID()
+	cd, err := NewCoreDocument(nil, CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{signer}}, nil)
+	assert.NoError(t, err)
+	m := &coreDocModel{cd: cd}
+
+	// default policy
+	p, err := GetSignaturePolicy(m)
+	assert.NoError(t, err)
+	assert.Equal(t, SignaturePolicy{Mode: SignaturePolicyBestEffort}, p)
+
+	// invalid policies
+	for _, p := range []SignaturePolicy{
+		{Mode: "unknown"},
+		{Mode: SignaturePolicyAll, RequiredSigners: []identity.DID{signer}},
+		{Mode: SignaturePolicyRequired},
+		{Mode: SignaturePolicyRequired, RequiredSigners: []identity.DID{testingidentity.GenerateRandomDID()}},
+	} {
+		err = SetSignaturePolicy(m, p)
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+	}
+
+	// success
+	p = SignaturePolicy{Mode: SignaturePolicyRequired, RequiredSigners: []identity.DID{signer}}
+	assert.NoError(t, SetSignaturePolicy(m, p))
+	gp, err := GetSignaturePolicy(m)
+	assert.NoError(t, err)
+	assert.Equal(t, p, gp)
+	for _, attr := range m.GetAttributes() {
+		assert.True(t, IsReservedAttribute(attr.KeyLabel))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -91,6 +92,34 @@ func (m *MockModel) UpdateRole(roleID []byte, dids []identity.DID) (*coredocumen
 	return r, args.Error(1)
 }
 
+func (m *MockModel) AddReadRuleForRole(roleID []byte) (*coredocumentpb.ReadRule, error) {
+	args := m.Called(roleID)
+	r, _ := args.Get(0).(*coredocumentpb.ReadRule)
+	return r, args.Error(1)
+}
+
+func (m *MockModel) SetRoleValidity(roleID []byte, v Validity) error {
+	args := m.Called(roleID, v)
+	return args.Error(0)
+}
+
+func (m *MockModel) SetReadRuleValidity(roleID []byte, v Validity) error {
+	args := m.Called(roleID, v)
+	return args.Error(0)
+}
+
+func (m *MockModel) RoleValidity(roleID []byte) (role, read Validity, err error) {
+	args := m.Called(roleID)
+	role, _ = args.Get(0).(Validity)
+	read, _ = args.Get(1).(Validity)
+	return role, read, args.Error(2)
+}
+
+func (m *MockModel) IsAuthoringCollaborator(did identity.DID) bool {
+	args := m.Called(did)
+	return args.Bool(0)
+}
+
 func (m *MockModel) AddTransitionRuleForAttribute(roleID []byte, key AttrKey) (*coredocumentpb.TransitionRule, error) {
 	args := m.Called(roleID, key)
 	r, _ := args.Get(0).(*coredocumentpb.TransitionRule)
@@ -108,6 +137,11 @@ func (m *MockModel) DeleteTransitionRule(ruleID []byte) error {
 	return args.Error(0)
 }
 
+func (m *MockModel) CopyRules(ctx context.Context, src coredocumentpb.CoreDocument, opts CloneOptions) error {
+	args := m.Called(ctx, src, opts)
+	return args.Error(0)
+}
+
 type MockService struct {
 	Service
 	mock.Mock
@@ -136,6 +170,25 @@ func (m *MockService) Validate(ctx context.Context, model Model, old Model) erro
 	return args.Error(0)
 }
 
+func (m *MockService) ExportBundle(ctx context.Context, documentID, versionID []byte) ([]byte, error) {
+	args := m.Called(ctx, documentID, versionID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (m *MockService) ImportBundle(ctx context.Context, data []byte) (Model, error) {
+	args := m.Called(ctx, data)
+	doc, _ := args.Get(0).(Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context, filter ListFilter) ([]Model, []byte, error) {
+	args := m.Called(ctx, filter)
+	docs, _ := args.Get(0).([]Model)
+	next, _ := args.Get(1).([]byte)
+	return docs, next, args.Error(2)
+}
+
 func (m *MockService) New(scheme string) (Model, error) {
 	args := m.Called(scheme)
 	doc, _ := args.Get(0).(Model)
@@ -206,6 +259,40 @@ func (m *MockModel) AddAttributes(ca CollaboratorsAccess, prepareNewVersion bool
 	return args.Error(0)
 }
 
+func (m *MockModel) GrantAccessToken(ctx context.Context, payload AccessTokenParams) (*coredocumentpb.AccessToken, error) {
+	args := m.Called(ctx, payload)
+	at, _ := args.Get(0).(*coredocumentpb.AccessToken)
+	return at, args.Error(1)
+}
+
+func (m *MockModel) RevokeAccessToken(tokenID []byte) error {
+	args := m.Called(tokenID)
+	return args.Error(0)
+}
+
+func (m *MockModel) CalculateSigningRoot() ([]byte, error) {
+	args := m.Called()
+	root, _ := args.Get(0).([]byte)
+	return root, args.Error(1)
+}
+
+func (m *MockModel) CalculateDocumentRoot() ([]byte, error) {
+	args := m.Called()
+	root, _ := args.Get(0).([]byte)
+	return root, args.Error(1)
+}
+
+func (m *MockModel) DeleteAttribute(key AttrKey, prepareNewVersion bool) error {
+	args := m.Called(key, prepareNewVersion)
+	return args.Error(0)
+}
+
+func (m *MockModel) AnchorRepoAddress() common.Address {
+	args := m.Called()
+	addr, _ := args.Get(0).(common.Address)
+	return addr
+}
+
 func (m *MockModel) GetStatus() Status {
 	args := m.Called()
 	return args.Get(0).(Status)
@@ -268,6 +355,74 @@ func (m *MockRepository) GetLatest(accountID, docID []byte) (Model, error) {
 	return doc, args.Error(1)
 }
 
+func (m *MockRepository) List(accountID []byte, filter ListFilter) ([]Model, []byte, error) {
+	args := m.Called(accountID, filter)
+	docs, _ := args.Get(0).([]Model)
+	next, _ := args.Get(1).([]byte)
+	return docs, next, args.Error(2)
+}
+
+func (m *MockRepository) StoreFork(accountID []byte, fork *Fork) error {
+	args := m.Called(accountID, fork)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetFork(accountID, docID, versionID []byte) (*Fork, error) {
+	args := m.Called(accountID, docID, versionID)
+	fork, _ := args.Get(0).(*Fork)
+	return fork, args.Error(1)
+}
+
+func (m *MockRepository) GetForks(accountID, docID []byte) ([]*Fork, error) {
+	args := m.Called(accountID, docID)
+	forks, _ := args.Get(0).([]*Fork)
+	return forks, args.Error(1)
+}
+
+func (m *MockRepository) CreateInboxItem(accountID []byte, item *InboxItem) error {
+	args := m.Called(accountID, item)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateInboxItem(accountID []byte, item *InboxItem) error {
+	args := m.Called(accountID, item)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetInboxItem(accountID, id []byte) (*InboxItem, error) {
+	args := m.Called(accountID, id)
+	item, _ := args.Get(0).(*InboxItem)
+	return item, args.Error(1)
+}
+
+func (m *MockRepository) ListInbox(accountID []byte, filter InboxFilter) ([]*InboxItem, []byte, error) {
+	args := m.Called(accountID, filter)
+	items, _ := args.Get(0).([]*InboxItem)
+	next, _ := args.Get(1).([]byte)
+	return items, next, args.Error(2)
+}
+
+func (m *MockRepository) StoreAnchorState(accountID []byte, st *AnchorState) error {
+	args := m.Called(accountID, st)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetAnchorState(accountID, docID, versionID []byte) (*AnchorState, error) {
+	args := m.Called(accountID, docID, versionID)
+	st, _ := args.Get(0).(*AnchorState)
+	return st, args.Error(1)
+}
+
+func (m *MockRepository) MarkReadOnly(accountID, docID []byte) error {
+	args := m.Called(accountID, docID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) IsReadOnly(accountID, docID []byte) bool {
+	args := m.Called(accountID, docID)
+	return args.Bool(0)
+}
+
 func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
 	if _, ok := context[storage.BootstrappedDB]; !ok {
 		return errors.New("initializing LevelDB repository failed")
@@ -115,6 +115,7 @@ func UpdateVersionValidator(anchorSrv anchors.Service) Validator {
 		versionIDsValidator(),
 		currentVersionValidator(anchorSrv),
 		LatestVersionValidator(anchorSrv),
+		valueConstraintValidator(),
 	}
 }
 
@@ -461,10 +462,31 @@ func transitionValidator(collaborator identity.DID) Validator {
 			return errors.New("invalid document state transition: %v", err)
 		}
 
+		err = ValidateValueConstraints(old, new)
+		if err != nil {
+			return errors.New("invalid document state transition: %v", err)
+		}
+
+		err = ValidateValidityChanges(old, new, collaborator)
+		if err != nil {
+			return errors.New("invalid document state transition: %v", err)
+		}
+
 		return nil
 	})
 }
 
+// valueConstraintValidator checks that the attribute changes satisfy the value constraints of the old document.
+func valueConstraintValidator() Validator {
+	return ValidatorFunc(func(old, new Model) error {
+		if old == nil || new == nil {
+			return nil
+		}
+
+		return ValidateValueConstraints(old, new)
+	})
+}
+
 // PreAnchorValidator is a validator group with following validators
 // base validator
 // signing root validator
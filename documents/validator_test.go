@@ -166,7 +166,7 @@ func TestVersionIDsValidator(t *testing.T) {
 
 func TestUpdateVersionValidator(t *testing.T) {
 	uvv := UpdateVersionValidator(nil)
-	assert.Len(t, uvv, 3)
+	assert.Len(t, uvv, 4)
 }
 
 func TestCreateVersionValidator(t *testing.T) {
@@ -0,0 +1,207 @@
+package documents
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// roleValidityLabelPrefix is the label prefix of the attributes holding the validity of the roles.
+	roleValidityLabelPrefix = "_role_validity_"
+
+	// readValidityLabelPrefix is the label prefix of the attributes holding the validity of the read rules of the roles.
+	readValidityLabelPrefix = "_read_validity_"
+)
+
+// Validity is the time window in which a role or a read rule is in effect.
+// Zero bounds are open ended.
+type Validity struct {
+	NotBefore *time.Time `json:"not_before,omitempty" swaggertype:"primitive,string"`
+	NotAfter  *time.Time `json:"not_after,omitempty" swaggertype:"primitive,string"`
+}
+
+// Validate checks if the validity window is well formed.
+func (v Validity) Validate() error {
+	if v.NotBefore != nil && v.NotAfter != nil && v.NotAfter.Before(*v.NotBefore) {
+		return errors.NewTypedError(ErrInvalidValidity, errors.New("not_after is before not_before"))
+	}
+
+	return nil
+}
+
+// ActiveAt returns true if t is within the validity window.
+func (v Validity) ActiveAt(t time.Time) bool {
+	if v.NotBefore != nil && t.Before(*v.NotBefore) {
+		return false
+	}
+
+	if v.NotAfter != nil && t.After(*v.NotAfter) {
+		return false
+	}
+
+	return true
+}
+
+// setValidity stores the validity in the attribute with label prefix and role key.
+func (cd *CoreDocument) setValidity(prefix string, roleID []byte, v Validity) error {
+	if _, err := cd.GetRole(roleID); err != nil {
+		return err
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	d, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	attr, err := NewStringAttribute(prefix+hexutil.Encode(roleID), AttrString, string(d))
+	if err != nil {
+		return err
+	}
+
+	_, err = cd.AddAttributes(CollaboratorsAccess{}, false, nil, attr)
+	return err
+}
+
+// validity returns the validity stored with label prefix and role key.
+// ok is false if the validity is malformed.
+func (cd *CoreDocument) validity(prefix string, roleID []byte) (v Validity, ok bool) {
+	key, err := AttrKeyFromLabel(prefix + hexutil.Encode(roleID))
+	if err != nil {
+		return v, false
+	}
+
+	attr, ok := cd.Attributes[key]
+	if !ok {
+		return v, true
+	}
+
+	err = json.Unmarshal([]byte(attr.Value.Str), &v)
+	return v, err == nil
+}
+
+// SetRoleValidity restricts the role to the validity window.
+// Collaborators of the role lose their read and sign access outside the window.
+func (cd *CoreDocument) SetRoleValidity(roleID []byte, v Validity) error {
+	return cd.setValidity(roleValidityLabelPrefix, roleID, v)
+}
+
+// SetReadRuleValidity restricts the read rules of the role to the validity window.
+func (cd *CoreDocument) SetReadRuleValidity(roleID []byte, v Validity) error {
+	return cd.setValidity(readValidityLabelPrefix, roleID, v)
+}
+
+// RoleValidity returns the validity windows of the role and its read rules.
+func (cd *CoreDocument) RoleValidity(roleID []byte) (role, read Validity, err error) {
+	if _, err := cd.GetRole(roleID); err != nil {
+		return role, read, err
+	}
+
+	role, ok := cd.validity(roleValidityLabelPrefix, roleID)
+	if !ok {
+		return role, read, ErrInvalidValidity
+	}
+
+	read, ok = cd.validity(readValidityLabelPrefix, roleID)
+	if !ok {
+		return role, read, ErrInvalidValidity
+	}
+
+	return role, read, nil
+}
+
+// roleActive returns true if the role is within its validity window at t.
+// Malformed windows are considered inactive.
+func (cd *CoreDocument) roleActive(role *coredocumentpb.Role, t time.Time) bool {
+	v, ok := cd.validity(roleValidityLabelPrefix, role.RoleKey)
+	return ok && v.ActiveAt(t)
+}
+
+// readRoleActive returns true if both the role and its read rules are within their validity windows at t.
+func (cd *CoreDocument) readRoleActive(role *coredocumentpb.Role, t time.Time) bool {
+	if !cd.roleActive(role, t) {
+		return false
+	}
+
+	v, ok := cd.validity(readValidityLabelPrefix, role.RoleKey)
+	return ok && v.ActiveAt(t)
+}
+
+// versionTime returns the time of the document version.
+// Current time is returned if the version is not timestamped yet.
+func (cd *CoreDocument) versionTime() time.Time {
+	t, err := cd.Timestamp()
+	if err != nil || cd.Document.Timestamp == nil {
+		return time.Now().UTC()
+	}
+
+	return t
+}
+
+// authoringRole returns the role created along with the document.
+// The role holds the author and the read write collaborators the document was created with.
+func (cd *CoreDocument) authoringRole() (*coredocumentpb.Role, error) {
+	field := CompactProperties(CDTreePrefix)
+	for _, rule := range cd.Document.TransitionRules {
+		if rule.MatchType != coredocumentpb.FieldMatchType_FIELD_MATCH_TYPE_PREFIX ||
+			rule.Action != coredocumentpb.TransitionAction_TRANSITION_ACTION_EDIT ||
+			!bytes.Equal(rule.Field, field) || len(rule.Roles) < 1 {
+			continue
+		}
+
+		return getRole(rule.Roles[0], cd.Document.Roles)
+	}
+
+	return nil, ErrRoleNotExist
+}
+
+// IsAuthoringCollaborator returns true if the did is a collaborator of the role created along with the document.
+func (cd *CoreDocument) IsAuthoringCollaborator(did identity.DID) bool {
+	role, err := cd.authoringRole()
+	if err != nil {
+		return false
+	}
+
+	_, ok := isDIDInRole(role, did)
+	return ok
+}
+
+// validityAttributes returns the values of the validity attributes of the model.
+func validityAttributes(model Model) map[AttrKey]string {
+	attrs := make(map[AttrKey]string)
+	for _, attr := range model.GetAttributes() {
+		if !strings.HasPrefix(attr.KeyLabel, roleValidityLabelPrefix) &&
+			!strings.HasPrefix(attr.KeyLabel, readValidityLabelPrefix) {
+			continue
+		}
+
+		attrs[attr.Key] = attr.Value.Str
+	}
+
+	return attrs
+}
+
+// ValidateValidityChanges checks that the validity windows of the roles and read rules are changed from old to new
+// model only by the collaborators of the authoring role of the old model.
+func ValidateValidityChanges(old, new Model, collaborator identity.DID) error {
+	if old.IsAuthoringCollaborator(collaborator) {
+		return nil
+	}
+
+	if !reflect.DeepEqual(validityAttributes(old), validityAttributes(new)) {
+		return errors.NewTypedError(ErrValidityChangeNotAllowed, errors.New("collaborator %s is not in the authoring role", collaborator.String()))
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+// +build unit
+
+package documents
+
+import (
+	"testing"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidity(t *testing.T) {
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		v      Validity
+		valid  bool
+		active bool
+	}{
+		{v: Validity{}, valid: true, active: true},
+		{v: Validity{NotBefore: &past}, valid: true, active: true},
+		{v: Validity{NotBefore: &future}, valid: true},
+		{v: Validity{NotAfter: &past}, valid: true},
+		{v: Validity{NotAfter: &future}, valid: true, active: true},
+		{v: Validity{NotBefore: &past, NotAfter: &future}, valid: true, active: true},
+		{v: Validity{NotBefore: &future, NotAfter: &past}},
+	}
+
+	for _, c := range tests {
+		err := c.v.Validate()
+		if c.valid {
+			assert.NoError(t, err)
+		} else {
+			assert.True(t, errors.IsOfType(ErrInvalidValidity, err))
+		}
+
+		assert.Equal(t, c.active, c.v.ActiveAt(now))
+	}
+}
+
+func TestCoreDocument_SetRoleValidity(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	// missing role
+	err = cd.SetRoleValidity(utils.RandomSlice(32), Validity{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrRoleNotExist, err))
+
+	// invalid window
+	role, err := cd.AddRole("auditor", []identity.DID{testingidentity.GenerateRandomDID()})
+	assert.NoError(t, err)
+	err = cd.SetReadRuleValidity(role.RoleKey, Validity{NotBefore: &future, NotAfter: &past})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidValidity, err))
+
+	// success
+	assert.NoError(t, cd.SetRoleValidity(role.RoleKey, Validity{NotBefore: &past}))
+	assert.NoError(t, cd.SetReadRuleValidity(role.RoleKey, Validity{NotAfter: &future}))
+	rv, read, err := cd.RoleValidity(role.RoleKey)
+	assert.NoError(t, err)
+	assert.True(t, past.Equal(*rv.NotBefore))
+	assert.Nil(t, rv.NotAfter)
+	assert.True(t, future.Equal(*read.NotAfter))
+	for _, attr := range cd.GetAttributes() {
+		assert.True(t, IsReservedAttribute(attr.KeyLabel))
+	}
+}
+
+func TestCoreDocument_AccountCanRead_validity(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	auditor := testingidentity.GenerateRandomDID()
+	role, err := cd.AddRole("auditor", []identity.DID{auditor})
+	assert.NoError(t, err)
+	_, err = cd.AddReadRuleForRole(role.RoleKey)
+	assert.NoError(t, err)
+	assert.True(t, cd.AccountCanRead(auditor))
+
+	// expired read rule
+	past := time.Now().UTC().Add(-time.Hour)
+	assert.NoError(t, cd.SetReadRuleValidity(role.RoleKey, Validity{NotAfter: &past}))
+	assert.False(t, cd.AccountCanRead(auditor))
+
+	// role not active yet
+	future := time.Now().UTC().Add(time.Hour)
+	assert.NoError(t, cd.SetReadRuleValidity(role.RoleKey, Validity{}))
+	assert.True(t, cd.AccountCanRead(auditor))
+	assert.NoError(t, cd.SetRoleValidity(role.RoleKey, Validity{NotBefore: &future}))
+	assert.False(t, cd.AccountCanRead(auditor))
+}
+
+func TestCoreDocument_GetSignerCollaborators_validity(t *testing.T) {
+	cd, err := newCoreDocument()
+	assert.NoError(t, err)
+	signer := testingidentity.GenerateRandomDID()
+	role, err := cd.AddRole("financier", []identity.DID{signer})
+	assert.NoError(t, err)
+	cd.addNewReadRule(role.RoleKey, coredocumentpb.Action_ACTION_READ_SIGN)
+	assert.NoError(t, cd.AddUpdateLog(testingidentity.GenerateRandomDID()))
+	cs, err := cd.GetSignerCollaborators()
+	assert.NoError(t, err)
+	assert.Equal(t, []identity.DID{signer}, cs)
+
+	// role expired before the version
+	ts, err := cd.Timestamp()
+	assert.NoError(t, err)
+	before := ts.Add(-time.Second)
+	assert.NoError(t, cd.SetRoleValidity(role.RoleKey, Validity{NotAfter: &before}))
+	cs, err = cd.GetSignerCollaborators()
+	assert.NoError(t, err)
+	assert.Len(t, cs, 0)
+
+	// collaborators are still listed
+	ca, err := cd.GetCollaborators()
+	assert.NoError(t, err)
+	assert.Equal(t, []identity.DID{signer}, ca.ReadCollaborators)
+}
+
+func (m *coreDocModel) IsAuthoringCollaborator(did identity.DID) bool {
+	return m.cd.IsAuthoringCollaborator(did)
+}
+
+func TestValidateValidityChanges(t *testing.T) {
+	author := testingidentity.GenerateRandomDID()
+	financier := testingidentity.GenerateRandomDID()
+	cd, err := NewCoreDocument(nil, CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{author}}, nil)
+	assert.NoError(t, err)
+	cd, err = cd.PrepareNewVersion(nil, CollaboratorsAccess{ReadWriteCollaborators: []identity.DID{financier}}, nil)
+	assert.NoError(t, err)
+	role, err := cd.AddRole("financier", []identity.DID{financier})
+	assert.NoError(t, err)
+	past := time.Now().UTC().Add(-time.Hour)
+	assert.NoError(t, cd.SetRoleValidity(role.RoleKey, Validity{NotAfter: &past}))
+	assert.True(t, cd.IsAuthoringCollaborator(author))
+	assert.False(t, cd.IsAuthoringCollaborator(financier))
+	old := &coreDocModel{cd: cd}
+
+	// no validity changes
+	ncd, err := cd.PrepareNewVersion(nil, CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, ValidateValidityChanges(old, &coreDocModel{cd: ncd}, financier))
+
+	// financier extends its own window
+	assert.NoError(t, ncd.SetRoleValidity(role.RoleKey, Validity{}))
+	err = ValidateValidityChanges(old, &coreDocModel{cd: ncd}, financier)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrValidityChangeNotAllowed, err))
+
+	// author changes the window
+	assert.NoError(t, ValidateValidityChanges(old, &coreDocModel{cd: ncd}, author))
+}
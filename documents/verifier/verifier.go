@@ -0,0 +1,233 @@
+// Package verifier verifies document field proofs without a Centrifuge node.
+package verifier
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ErrInvalidRoots must be used when the roots of the proof don't add up to the document root.
+	ErrInvalidRoots = errors.Error("invalid proof roots")
+
+	// ErrInvalidFieldProof must be used when a field proof doesn't lead to its tree root.
+	ErrInvalidFieldProof = errors.Error("invalid field proof")
+
+	// ErrDocumentRootNotAnchored must be used when the document root doesn't match the anchored one.
+	ErrDocumentRootNotAnchored = errors.Error("document root not anchored")
+)
+
+// AnchorLookup returns the document root anchored with the anchor ID.
+// anchors.Service satisfies this interface.
+type AnchorLookup interface {
+	GetAnchorData(anchorID anchors.AnchorID) (docRoot anchors.DocumentRoot, anchoredTime time.Time, err error)
+}
+
+// DocumentProof holds the field proofs of a document version and the roots required to recompute its document root.
+type DocumentProof struct {
+	DocumentID     []byte
+	VersionID      []byte
+	FieldProofs    []documents.Proof
+	LeftDataRoot   []byte
+	RightDataRoot  []byte
+	SigningRoot    []byte
+	SignaturesRoot []byte
+}
+
+// FieldVerdict holds the result of the validation of a field proof.
+type FieldVerdict struct {
+	Property []byte
+	Err      error
+}
+
+// Valid returns true if the field proof is valid.
+func (v FieldVerdict) Valid() bool {
+	return v.Err == nil
+}
+
+// Signature is a signature of the signing root by a collaborator.
+type Signature struct {
+	SignerID            identity.DID
+	PublicKey           []byte
+	Signature           []byte
+	TransitionValidated bool
+}
+
+// SignatureValidator validates the signature with the keys of the signer identity.
+// identity.Service satisfies this interface.
+type SignatureValidator interface {
+	ValidateSignature(did identity.DID, pubKey []byte, signature []byte, message []byte, timestamp time.Time) error
+}
+
+// SignatureVerdict holds the result of the validation of a signature.
+type SignatureVerdict struct {
+	SignerID identity.DID
+	Err      error
+}
+
+// Valid returns true if the signature is valid.
+func (v SignatureVerdict) Valid() bool {
+	return v.Err == nil
+}
+
+// Verify recomputes the document root from the roots of the proof and validates each field proof against the root of its tree.
+// An error is returned if any of the field proofs is invalid.
+func Verify(p DocumentProof) (docRoot []byte, err error) {
+	docRoot, verdicts, err := VerifyFields(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(verdicts) < 1 {
+		return nil, errors.NewTypedError(ErrInvalidFieldProof, errors.New("no field proofs"))
+	}
+
+	for _, v := range verdicts {
+		if !v.Valid() {
+			err = errors.AppendError(err, errors.New("field %s: %v", hexutil.Encode(v.Property), v.Err))
+		}
+	}
+
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidFieldProof, err)
+	}
+
+	return docRoot, nil
+}
+
+// VerifyFields recomputes the document root from the roots of the proof and returns the verdict of each field proof.
+// Field proofs of the data and core document trees are validated against the left data root,
+// signature proofs against the signatures root, and document root tree proofs against the signing and signatures roots.
+// An error is returned only if the roots don't add up.
+func VerifyFields(p DocumentProof) (docRoot []byte, verdicts []FieldVerdict, err error) {
+	for _, r := range [][]byte{p.LeftDataRoot, p.RightDataRoot, p.SigningRoot, p.SignaturesRoot} {
+		if len(r) != blake2b.Size256 {
+			return nil, nil, errors.NewTypedError(ErrInvalidRoots, errors.New("roots must be %d bytes", blake2b.Size256))
+		}
+	}
+
+	h, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !bytes.Equal(proofs.HashTwoValues(p.LeftDataRoot, p.RightDataRoot, h), p.SigningRoot) {
+		return nil, nil, errors.NewTypedError(ErrInvalidRoots, errors.New("data roots don't match the signing root"))
+	}
+
+	h.Reset()
+	docRoot = proofs.HashTwoValues(p.SigningRoot, p.SignaturesRoot, h)
+	for _, fp := range p.FieldProofs {
+		verdicts = append(verdicts, FieldVerdict{Property: fp.Property, Err: verifyFieldProof(p, fp)})
+	}
+
+	return docRoot, verdicts, nil
+}
+
+// VerifyAnchored verifies the proof and checks that the recomputed document root is anchored with the version.
+func VerifyAnchored(p DocumentProof, lookup AnchorLookup) (docRoot []byte, err error) {
+	docRoot, err = Verify(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = CheckAnchor(lookup, p.VersionID, docRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return docRoot, nil
+}
+
+// CheckAnchor returns an error if the document root is not the one anchored with the version.
+// anchoredAt is the time the version was anchored at.
+func CheckAnchor(lookup AnchorLookup, versionID, docRoot []byte) (anchoredAt time.Time, err error) {
+	anchorID, err := anchors.ToAnchorID(versionID)
+	if err != nil {
+		return anchoredAt, errors.NewTypedError(ErrDocumentRootNotAnchored, err)
+	}
+
+	anchored, anchoredAt, err := lookup.GetAnchorData(anchorID)
+	if err != nil {
+		return anchoredAt, errors.NewTypedError(ErrDocumentRootNotAnchored, err)
+	}
+
+	if !utils.IsSameByteSlice(anchored[:], docRoot) {
+		return anchoredAt, errors.NewTypedError(ErrDocumentRootNotAnchored, errors.New("mismatched document roots"))
+	}
+
+	return anchoredAt, nil
+}
+
+// VerifySignatures returns the verdict of each signature of the signing root.
+// Signer keys are validated at the given time.
+func VerifySignatures(v SignatureValidator, signingRoot []byte, sigs []Signature, at time.Time) (verdicts []SignatureVerdict) {
+	for _, sig := range sigs {
+		err := v.ValidateSignature(
+			sig.SignerID, sig.PublicKey, sig.Signature, documents.ConsensusSignaturePayload(signingRoot, sig.TransitionValidated), at)
+		verdicts = append(verdicts, SignatureVerdict{SignerID: sig.SignerID, Err: err})
+	}
+
+	return verdicts
+}
+
+// verifyFieldProof validates the field proof against the root of the tree the field belongs to.
+func verifyFieldProof(p DocumentProof, fp documents.Proof) error {
+	fieldHash := fp.Hash.Bytes()
+	if len(fieldHash) == 0 {
+		var err error
+		fieldHash, err = proofs.CalculateHashForProofField(&proofspb.Proof{
+			Property: &proofspb.Proof_CompactName{CompactName: fp.Property},
+			Value:    fp.Value,
+			Salt:     fp.Salt,
+		}, sha3.NewKeccak256())
+		if err != nil {
+			return err
+		}
+	}
+
+	root := p.LeftDataRoot
+	switch {
+	case bytes.HasPrefix(fp.Property, documents.CompactProperties(documents.DRTreePrefix)):
+		// document root tree has only the signing and signatures roots as leaves.
+		if !bytes.Equal(fieldHash, p.SigningRoot) && !bytes.Equal(fieldHash, p.SignaturesRoot) {
+			return errors.New("hash doesn't match the signing or signatures root")
+		}
+
+		return nil
+	case bytes.HasPrefix(fp.Property, documents.CompactProperties(documents.SignaturesTreePrefix)):
+		root = p.SignaturesRoot
+	}
+
+	var sortedHashes [][]byte
+	for _, sh := range fp.SortedHashes {
+		sortedHashes = append(sortedHashes, sh.Bytes())
+	}
+
+	h, err := blake2b.New256(nil)
+	if err != nil {
+		return err
+	}
+
+	valid, err := proofs.ValidateProofSortedHashes(fieldHash, sortedHashes, root, h)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return errors.New("proof doesn't lead to the tree root")
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// +build unit
+
+package verifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+)
+
+type anchorLookup map[anchors.AnchorID]anchors.DocumentRoot
+
+func (l anchorLookup) GetAnchorData(anchorID anchors.AnchorID) (anchors.DocumentRoot, time.Time, error) {
+	dr, ok := l[anchorID]
+	if !ok {
+		return dr, time.Time{}, errors.New("anchor not found")
+	}
+
+	return dr, time.Now(), nil
+}
+
+func documentProof(t *testing.T, fields ...string) (DocumentProof, []byte) {
+	cd, err := documents.NewCoreDocument([]byte{1, 0, 0, 0}, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	cd.GetTestCoreDocWithReset().EmbeddedData = &any.Any{TypeUrl: documenttypes.InvoiceDataTypeUrl, Value: []byte{}}
+	tree, err := cd.DefaultTreeWithPrefix("prefix", []byte{1, 0, 0, 0})
+	assert.NoError(t, err)
+	assert.NoError(t, tree.AddLeaf(proofs.LeafNode{
+		Hash:     utils.RandomSlice(32),
+		Hashed:   true,
+		Property: documents.NewLeafProperty("prefix.sample_field", []byte{1, 0, 0, 0, 0, 0, 0, 200}),
+	}))
+	assert.NoError(t, tree.Generate())
+
+	dp, err := cd.CreateProofs(documenttypes.InvoiceDataTypeUrl, tree.GetLeaves(), fields)
+	assert.NoError(t, err)
+	docRoot, err := cd.CalculateDocumentRoot(documenttypes.InvoiceDataTypeUrl, tree.GetLeaves())
+	assert.NoError(t, err)
+	return DocumentProof{
+		DocumentID:     cd.ID(),
+		VersionID:      cd.CurrentVersion(),
+		FieldProofs:    documents.ConvertProofs(dp.FieldProofs),
+		LeftDataRoot:   dp.LeftDataRooot,
+		RightDataRoot:  dp.RightDataRoot,
+		SigningRoot:    dp.SigningRoot,
+		SignaturesRoot: dp.SignaturesRoot,
+	}, docRoot
+}
+
+func TestVerify(t *testing.T) {
+	p, docRoot := documentProof(t, "prefix.sample_field", documents.CDTreePrefix+".next_version")
+	got, err := Verify(p)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, got)
+
+	// tampered field
+	p.FieldProofs[1].Hash = utils.RandomSlice(32)
+	_, err = Verify(p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+
+	// tampered roots
+	p.RightDataRoot = utils.RandomSlice(32)
+	_, err = Verify(p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidRoots, err))
+
+	// missing roots
+	p.SigningRoot = nil
+	_, err = Verify(p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidRoots, err))
+
+	// no field proofs
+	p, _ = documentProof(t)
+	_, err = Verify(p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+
+	// per field verdicts
+	p, docRoot = documentProof(t, "prefix.sample_field", documents.CDTreePrefix+".next_version")
+	p.FieldProofs[0].Hash = utils.RandomSlice(32)
+	got, verdicts, err := VerifyFields(p)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, got)
+	assert.Len(t, verdicts, 2)
+	assert.False(t, verdicts[0].Valid())
+	assert.True(t, verdicts[1].Valid())
+
+	// tampered sorted hashes
+	p, _ = documentProof(t, "prefix.sample_field")
+	p.FieldProofs[0].SortedHashes = []byteutils.HexBytes{utils.RandomSlice(32)}
+	_, err = Verify(p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidFieldProof, err))
+}
+
+func TestVerifyAnchored(t *testing.T) {
+	p, docRoot := documentProof(t, "prefix.sample_field")
+	lookup := anchorLookup{}
+
+	// not anchored
+	_, err := VerifyAnchored(p, lookup)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentRootNotAnchored, err))
+
+	// different root
+	anchorID, err := anchors.ToAnchorID(p.VersionID)
+	assert.NoError(t, err)
+	lookup[anchorID] = anchors.DocumentRoot{1}
+	_, err = VerifyAnchored(p, lookup)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrDocumentRootNotAnchored, err))
+
+	// success
+	dr, err := anchors.ToDocumentRoot(docRoot)
+	assert.NoError(t, err)
+	lookup[anchorID] = dr
+	got, err := VerifyAnchored(p, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, got)
+}
+
+type signatureValidator map[string]error
+
+func (v signatureValidator) ValidateSignature(did identity.DID, pubKey []byte, signature []byte, message []byte, timestamp time.Time) error {
+	return v[did.String()]
+}
+
+func TestVerifySignatures(t *testing.T) {
+	valid, invalid := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	v := signatureValidator{invalid.String(): errors.New("key revoked")}
+	verdicts := VerifySignatures(v, utils.RandomSlice(32), []Signature{
+		{SignerID: valid, PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(65)},
+		{SignerID: invalid, PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(65)},
+	}, time.Now())
+	assert.Len(t, verdicts, 2)
+	assert.Equal(t, valid, verdicts[0].SignerID)
+	assert.True(t, verdicts[0].Valid())
+	assert.Equal(t, invalid, verdicts[1].SignerID)
+	assert.False(t, verdicts[1].Valid())
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -252,6 +253,7 @@ func (h handler) GetDocumentVersion(w http.ResponseWriter, r *http.Request) {
 // GenerateProofs returns proofs for the fields from latest version of the document.
 // @summary Generates proofs for the fields from latest version of the document.
 // @description Generates proofs for the fields from latest version of the document.
+// @description Labels can be passed instead of fields and are resolved to the fields of the document.
 // @id generate_document_proofs
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -291,6 +293,19 @@ func (h handler) GenerateProofs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(request.Labels) > 0 {
+		var resp ProofsResponse
+		resp, code, err = h.generateProofsForLabels(r, docID, nil, request.Labels)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+		return
+	}
+
 	proofs, err := h.srv.GenerateProofs(r.Context(), docID, request.Fields)
 	if err != nil {
 		code = http.StatusInternalServerError
@@ -305,6 +320,7 @@ func (h handler) GenerateProofs(w http.ResponseWriter, r *http.Request) {
 // GenerateProofsForVersion returns proofs for the fields from a specific document version.
 // @summary Generates proofs for the fields from a specific document version.
 // @description Generates proofs for the fields from a specific document version.
+// @description Labels can be passed instead of fields and are resolved to the fields of the document.
 // @id generate_document_version_proofs
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -351,6 +367,19 @@ func (h handler) GenerateProofsForVersion(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if len(request.Labels) > 0 {
+		var resp ProofsResponse
+		resp, code, err = h.generateProofsForLabels(r, ids[0], ids[1], request.Labels)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+		return
+	}
+
 	proofs, err := h.srv.GenerateProofsForVersion(r.Context(), ids[0], ids[1], request.Fields)
 	if err != nil {
 		code = http.StatusInternalServerError
@@ -361,3 +390,20 @@ func (h handler) GenerateProofsForVersion(w http.ResponseWriter, r *http.Request
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, convertProofs(proofs))
 }
+
+// generateProofsForLabels generates the proofs for the labels and returns the response with the resolved labels.
+// Returns the status code to respond with on error.
+func (h handler) generateProofsForLabels(r *http.Request, docID, versionID []byte, labels []string) (ProofsResponse, int, error) {
+	proofs, resolved, err := h.srv.GenerateProofsForLabels(r.Context(), docID, versionID, labels)
+	if err != nil {
+		if errors.IsOfType(documents.ErrInvalidProofLabel, err) {
+			return ProofsResponse{}, http.StatusBadRequest, err
+		}
+
+		return ProofsResponse{}, http.StatusInternalServerError, err
+	}
+
+	resp := convertProofs(proofs)
+	resp.Labels = resolved
+	return resp, http.StatusOK, nil
+}
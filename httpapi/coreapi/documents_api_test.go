@@ -532,3 +532,53 @@ func TestHandler_GenerateProofsForVersion(t *testing.T) {
 	assert.Contains(t, w.Body.String(), hexutil.Encode(id))
 	docSrv.AssertExpectations(t)
 }
+
+func TestHandler_GenerateProofsForLabels(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/proofs", body).WithContext(ctx)
+	}
+
+	id := utils.RandomSlice(32)
+	vid := utils.RandomSlice(32)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"document_id", "version_id"}
+	rctx.URLParams.Values = []string{hexutil.Encode(id), hexutil.Encode(vid)}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	attr, err := documents.NewStringAttribute("loan_status", documents.AttrString, "approved")
+	assert.NoError(t, err)
+	request := ProofsRequest{Labels: []string{"loan_status", "signing_root"}}
+	d, err := json.Marshal(request)
+	assert.NoError(t, err)
+
+	// unknown label
+	model := new(testingdocuments.MockModel)
+	model.On("GetAttribute", attr.Key).Return(nil, errors.New("attribute does not exist")).Once()
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetVersion", id, vid).Return(model, nil)
+	h := handler{srv: Service{docSrv: docSrv}}
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofsForVersion(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrInvalidProofLabel.Error())
+
+	// success
+	fields := []string{
+		"cd_tree.attributes[" + attr.Key.String() + "].str_val",
+		"dr_tree.signing_root",
+	}
+	model.On("GetAttribute", attr.Key).Return(attr, nil).Once()
+	model.On("CurrentVersion").Return(vid)
+	proof := &documents.DocumentProof{DocumentID: id, VersionID: vid}
+	docSrv.On("CreateProofsForVersion", mock.Anything, id, vid, fields).Return(proof, nil)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GenerateProofsForVersion(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ProofsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []documents.ProofLabel{
+		{Label: "loan_status", Property: fields[0]},
+		{Label: "signing_root", Property: fields[1]},
+	}, resp.Labels)
+	docSrv.AssertExpectations(t)
+	model.AssertExpectations(t)
+}
@@ -22,6 +22,7 @@ const (
 // GetJobStatus returns the status of a given job.
 // @summary Returns the status of a given Job.
 // @description Returns the status of a given Job.
+// @description Includes the status of the signature of each collaborator if the job requested signatures.
 // @id get_job_status
 // @tags Jobs
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
@@ -30,7 +31,7 @@ const (
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
-// @success 200 {object} jobs.StatusResponse
+// @success 200 {object} coreapi.JobStatusResponse
 // @router /v1/jobs/{job_id} [get]
 func (h handler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 	var err error
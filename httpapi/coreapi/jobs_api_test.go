@@ -4,12 +4,14 @@ package coreapi
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -69,10 +71,32 @@ func TestService_GetJobStatus(t *testing.T) {
 	jobMan = testingjobs.MockJobManager{}
 	tt := time.Now().UTC()
 	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{JobID: jobID.String(), LastUpdated: tt}, nil)
+	jobMan.On("GetJob", did, jobID).Return(jobs.NewJob(did, "some job"), nil)
 	h = handler{srv: Service{jobsSrv: jobMan}}
 	h.GetJobStatus(w, r)
 	assert.Equal(t, w.Code, http.StatusOK)
 	assert.Contains(t, w.Body.String(), jobID.String())
 	assert.Contains(t, w.Body.String(), tt.Format(time.RFC3339Nano))
+	assert.NotContains(t, w.Body.String(), "signatures")
+	jobMan.AssertExpectations(t)
+
+	// success with signatures
+	w, r = getHTTPReqAndResp(ctx)
+	jobMan = testingjobs.MockJobManager{}
+	collaborator := testingidentity.GenerateRandomDID()
+	sigs := []documents.CollaboratorSignature{{Collaborator: collaborator, Status: documents.SignatureTimedOut, Reason: "context deadline exceeded"}}
+	d, err := json.Marshal(sigs)
+	assert.NoError(t, err)
+	job := jobs.NewJob(did, "some job")
+	job.Values["collaborator_signatures"] = jobs.JobValue{Key: "collaborator_signatures", Value: d}
+	jobMan.On("GetJobStatus", did, jobID).Return(jobs.StatusResponse{JobID: jobID.String(), LastUpdated: tt}, nil)
+	jobMan.On("GetJob", did, jobID).Return(job, nil)
+	h = handler{srv: Service{jobsSrv: jobMan}}
+	h.GetJobStatus(w, r)
+	assert.Equal(t, w.Code, http.StatusOK)
+	var resp JobStatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, jobID.String(), resp.JobID)
+	assert.Equal(t, sigs, resp.Signatures)
 	jobMan.AssertExpectations(t)
 }
@@ -40,9 +40,20 @@ func (s Service) UpdateDocument(ctx context.Context, payload documents.UpdatePay
 	return s.docSrv.UpdateModel(ctx, payload)
 }
 
-// GetJobStatus returns the job status.
-func (s Service) GetJobStatus(account identity.DID, id jobs.JobID) (jobs.StatusResponse, error) {
-	return s.jobsSrv.GetJobStatus(account, id)
+// GetJobStatus returns the job status and the status of the signatures requested within the job.
+func (s Service) GetJobStatus(account identity.DID, id jobs.JobID) (JobStatusResponse, error) {
+	st, err := s.jobsSrv.GetJobStatus(account, id)
+	if err != nil {
+		return JobStatusResponse{}, err
+	}
+
+	job, err := s.jobsSrv.GetJob(account, id)
+	if err != nil {
+		return JobStatusResponse{}, err
+	}
+
+	sigs, err := documents.GetSignatureStatus(job)
+	return JobStatusResponse{StatusResponse: st, Signatures: sigs}, err
 }
 
 // GetDocument returns the latest version of the document.
@@ -65,6 +76,34 @@ func (s Service) GenerateProofsForVersion(ctx context.Context, docID, versionID
 	return s.docSrv.CreateProofsForVersion(ctx, docID, versionID, fields)
 }
 
+// GenerateProofsForLabels resolves the labels to the fields of the document version and returns the proofs for them.
+// Latest version of the document is used if the versionID is empty.
+func (s Service) GenerateProofsForLabels(
+	ctx context.Context, docID, versionID []byte, labels []string) (*documents.DocumentProof, []documents.ProofLabel, error) {
+	var model documents.Model
+	var err error
+	if len(versionID) == 0 {
+		model, err = s.docSrv.GetCurrentVersion(ctx, docID)
+	} else {
+		model, err = s.docSrv.GetVersion(ctx, docID, versionID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolved, err := documents.ResolveProofLabels(model, labels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := s.docSrv.CreateProofsForVersion(ctx, docID, model.CurrentVersion(), documents.ProofLabelFields(resolved))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proof, resolved, nil
+}
+
 // MintNFT mints an NFT.
 func (s Service) MintNFT(ctx context.Context, request nft.MintNFTRequest) (*nft.TokenResponse, error) {
 	resp, _, err := s.nftSrv.MintNFT(ctx, request)
@@ -34,7 +34,7 @@ type AttributeMapRequest map[string]AttributeRequest
 
 // CreateDocumentRequest defines the payload for creating documents.
 type CreateDocumentRequest struct {
-	Scheme      string              `json:"scheme" enums:"generic,entity"`
+	Scheme      string              `json:"scheme" enums:"generic,entity,invoice"`
 	ReadAccess  []identity.DID      `json:"read_access" swaggertype:"array,string"`
 	WriteAccess []identity.DID      `json:"write_access" swaggertype:"array,string"`
 	Data        interface{}         `json:"data"`
@@ -89,7 +89,7 @@ type ResponseHeader struct {
 // DocumentResponse is the common response for Document APIs.
 type DocumentResponse struct {
 	Header     ResponseHeader       `json:"header"`
-	Scheme     string               `json:"scheme" enums:"generic,entity"`
+	Scheme     string               `json:"scheme" enums:"generic,entity,invoice"`
 	Data       interface{}          `json:"data"`
 	Attributes AttributeMapResponse `json:"attributes"`
 }
@@ -118,6 +118,12 @@ func toDocumentAttributes(cattrs map[string]AttributeRequest) (map[documents.Att
 		attrs[attr.Key] = attr
 	}
 
+	// reserved attributes hold the document metadata and are only set through their own APIs
+	err := documents.ValidateUserAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+
 	return attrs, nil
 }
 
@@ -265,8 +271,11 @@ func GetDocumentResponse(model documents.Model, tokenRegistry documents.TokenReg
 }
 
 // ProofsRequest holds the fields for which proofs are generated.
+// Labels can be passed instead of fields. A label is either a custom attribute label,
+// a well known field alias such as "signing_root" or "author", or "nft:<registry>".
 type ProofsRequest struct {
 	Fields []string `json:"fields"`
+	Labels []string `json:"labels"`
 }
 
 // ProofResponseHeader holds the document details.
@@ -276,10 +285,26 @@ type ProofResponseHeader struct {
 	State      string             `json:"state"`
 }
 
+// JobStatusResponse holds the job status details and the status of the signatures requested within the job.
+type JobStatusResponse struct {
+	jobs.StatusResponse
+
+	// Signatures holds the status of the signature of each collaborator. Empty if the job didn't request any.
+	Signatures []documents.CollaboratorSignature `json:"signatures,omitempty"`
+}
+
 // ProofsResponse holds the proofs for the fields given for a document.
+// Roots are included so that the document root can be recomputed from the proofs.
 type ProofsResponse struct {
-	Header      ProofResponseHeader `json:"header"`
-	FieldProofs []documents.Proof   `json:"field_proofs"`
+	Header         ProofResponseHeader `json:"header"`
+	FieldProofs    []documents.Proof   `json:"field_proofs"`
+	LeftDataRoot   byteutils.HexBytes  `json:"left_data_root" swaggertype:"primitive,string"`
+	RightDataRoot  byteutils.HexBytes  `json:"right_data_root" swaggertype:"primitive,string"`
+	SigningRoot    byteutils.HexBytes  `json:"signing_root" swaggertype:"primitive,string"`
+	SignaturesRoot byteutils.HexBytes  `json:"signatures_root" swaggertype:"primitive,string"`
+
+	// Labels holds the labels and the properties they resolved to, in the order of the field proofs.
+	Labels []documents.ProofLabel `json:"labels,omitempty"`
 }
 
 func convertProofs(proof *documents.DocumentProof) ProofsResponse {
@@ -289,7 +314,11 @@ func convertProofs(proof *documents.DocumentProof) ProofsResponse {
 			VersionID:  proof.VersionID,
 			State:      proof.State,
 		},
-		FieldProofs: documents.ConvertProofs(proof.FieldProofs),
+		FieldProofs:    documents.ConvertProofs(proof.FieldProofs),
+		LeftDataRoot:   proof.LeftDataRooot,
+		RightDataRoot:  proof.RightDataRoot,
+		SigningRoot:    proof.SigningRoot,
+		SignaturesRoot: proof.SignaturesRoot,
 	}
 }
 
@@ -72,6 +72,12 @@ func TestTypes_toAttributeMapResponse(t *testing.T) {
 	assert.Error(t, err)
 	delete(attrs, "monetary_test_dec_empty")
 
+	attrs["_approval_policy"] = AttributeRequest{Type: "string", Value: "{}"}
+	_, err = toDocumentAttributes(attrs)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrReservedAttribute, err))
+	delete(attrs, "_approval_policy")
+
 	attrs["invalid"] = AttributeRequest{Type: "unknown", Value: "some value"}
 	_, err = toDocumentAttributes(attrs)
 	assert.Error(t, err)
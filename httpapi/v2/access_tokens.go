@@ -0,0 +1,293 @@
+package v2
+
+import (
+	"net/http"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// TokenIDParam is the key for access token ID in the API path.
+const TokenIDParam = "token_id"
+
+// ErrInvalidTokenID for invalid access token ID in the api path.
+const ErrInvalidTokenID = errors.Error("Invalid Access Token ID")
+
+// AccessToken grants the grantee read access to the document through the document holding the token.
+type AccessToken struct {
+	ID              byteutils.HexBytes `json:"id" swaggertype:"primitive,string"`
+	Granter         identity.DID       `json:"granter" swaggertype:"primitive,string"`
+	Grantee         identity.DID       `json:"grantee" swaggertype:"primitive,string"`
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	DocumentVersion byteutils.HexBytes `json:"document_version" swaggertype:"primitive,string"`
+}
+
+// AccessTokens holds the list of access tokens.
+type AccessTokens struct {
+	Tokens []AccessToken `json:"tokens"`
+}
+
+// GrantAccessTokenRequest holds the grantee and the document it is granted read access to.
+type GrantAccessTokenRequest struct {
+	Grantee    identity.DID       `json:"grantee" swaggertype:"primitive,string"`
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+}
+
+// AccessTokenDocumentRequest holds the access token used to request the document from the granter.
+type AccessTokenDocumentRequest struct {
+	Granter              identity.DID       `json:"granter" swaggertype:"primitive,string"`
+	TokenID              byteutils.HexBytes `json:"token_id" swaggertype:"primitive,string"`
+	DelegatingDocumentID byteutils.HexBytes `json:"delegating_document_id" swaggertype:"primitive,string"`
+}
+
+func toAccessToken(at *coredocumentpb.AccessToken) (token AccessToken, err error) {
+	granter, err := identity.NewDIDFromBytes(at.Granter)
+	if err != nil {
+		return token, err
+	}
+
+	grantee, err := identity.NewDIDFromBytes(at.Grantee)
+	if err != nil {
+		return token, err
+	}
+
+	return AccessToken{
+		ID:              at.Identifier,
+		Granter:         granter,
+		Grantee:         grantee,
+		DocumentID:      at.DocumentIdentifier,
+		DocumentVersion: at.DocumentVersion,
+	}, nil
+}
+
+// GrantAccessToken adds an access token to the pending document.
+// @summary Adds an access token to the pending document.
+// @description Grants the grantee read access to the committed document through the pending document.
+// @description Access token is usable once the pending document is committed and shared with the grantee.
+// @id grant_access_token
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param body body v2.GrantAccessTokenRequest true "Grant Access Token Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 201 {object} v2.AccessToken
+// @router /v2/documents/{document_id}/access_tokens [post]
+func (h handler) GrantAccessToken(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	var req GrantAccessTokenRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	ctx, p := withPrecondition(r)
+	at, err := h.srv.GrantAccessToken(ctx, docID, req.Grantee, req.DocumentID)
+	if err != nil {
+		code = preconditionFailed(err, http.StatusBadRequest)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	resp, err := toAccessToken(at)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
+// ListAccessTokens returns the access tokens of the pending document.
+// @summary Returns the access tokens of the pending document.
+// @description Returns the access tokens of the pending document.
+// @id list_access_tokens
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.AccessTokens
+// @router /v2/documents/{document_id}/access_tokens [get]
+func (h handler) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	ats, err := h.srv.GetAccessTokens(r.Context(), docID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	resp := AccessTokens{Tokens: []AccessToken{}}
+	for _, at := range ats {
+		var token AccessToken
+		token, err = toAccessToken(at)
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		resp.Tokens = append(resp.Tokens, token)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// RevokeAccessToken removes the access token from the pending document.
+// @summary Removes the access token from the pending document.
+// @description Removes the access token from the pending document. Revocation takes effect once the pending document is committed.
+// @id revoke_access_token
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param token_id path string true "Access Token ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 204 {object} nil
+// @router /v2/documents/{document_id}/access_tokens/{token_id} [delete]
+func (h handler) RevokeAccessToken(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	tokenID, err := hexutil.Decode(chi.URLParam(r, TokenIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidTokenID
+		return
+	}
+
+	ctx, p := withPrecondition(r)
+	err = h.srv.RevokeAccessToken(ctx, docID, tokenID)
+	if err != nil {
+		code = preconditionFailed(err, http.StatusBadRequest)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) || errors.IsOfType(documents.ErrAccessTokenNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.NoContent(w, r)
+}
+
+// GetDocumentWithAccessToken requests the document from the granter using the access token.
+// @summary Requests the document from the granter using the access token.
+// @description Requests the document from the granter node. The access token is looked up in the delegating document held by the granter.
+// @id get_document_with_access_token
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param body body v2.AccessTokenDocumentRequest true "Access Token Document Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} coreapi.DocumentResponse
+// @router /v2/documents/{document_id}/access_token_requests [post]
+func (h handler) GetDocumentWithAccessToken(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	var req AccessTokenDocumentRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.GetDocumentWithAccessToken(r.Context(), req.Granter, req.TokenID, docID, req.DelegatingDocumentID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
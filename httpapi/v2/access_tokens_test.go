@@ -0,0 +1,222 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GrantAccessToken(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/access_tokens", b).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.GrantAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.GrantAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing token document
+	req := GrantAccessTokenRequest{Grantee: testingidentity.GenerateRandomDID(), DocumentID: utils.RandomSlice(32)}
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("GrantAccessToken", mock.Anything, docID, req.Grantee, req.DocumentID.Bytes()).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GrantAccessToken(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	granter := testingidentity.GenerateRandomDID()
+	at := &coredocumentpb.AccessToken{
+		Identifier:         utils.RandomSlice(32),
+		Granter:            granter[:],
+		Grantee:            req.Grantee[:],
+		DocumentIdentifier: req.DocumentID,
+		DocumentVersion:    utils.RandomSlice(32),
+	}
+	psrv.On("GrantAccessToken", mock.Anything, docID, req.Grantee, req.DocumentID.Bytes()).Return(at, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GrantAccessToken(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp AccessToken
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, at.Identifier, resp.ID.Bytes())
+	assert.Equal(t, granter, resp.Granter)
+	assert.Equal(t, req.Grantee, resp.Grantee)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_ListAccessTokens(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/access_tokens", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.ListAccessTokens(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("GetAccessTokens", mock.Anything, docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ListAccessTokens(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	granter, grantee := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	ats := []*coredocumentpb.AccessToken{{Identifier: utils.RandomSlice(32), Granter: granter[:], Grantee: grantee[:]}}
+	psrv.On("GetAccessTokens", mock.Anything, docID).Return(ats, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ListAccessTokens(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp AccessTokens
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Tokens, 1)
+	assert.Equal(t, grantee, resp.Tokens[0].Grantee)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_RevokeAccessToken(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("DELETE", "/documents/{document_id}/access_tokens/{token_id}", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam, TokenIDParam}
+	rctx.URLParams.Values = []string{"some invalid id", ""}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.RevokeAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid token id
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	rctx.URLParams.Values[1] = "some token"
+	w, r = getHTTPReqAndResp(ctx)
+	h.RevokeAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidTokenID.Error())
+
+	// missing token
+	tokenID := utils.RandomSlice(32)
+	rctx.URLParams.Values[1] = hexutil.Encode(tokenID)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("RevokeAccessToken", mock.Anything, docID, tokenID).Return(documents.ErrAccessTokenNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RevokeAccessToken(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	psrv.On("RevokeAccessToken", mock.Anything, docID, tokenID).Return(nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RevokeAccessToken(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_GetDocumentWithAccessToken(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/access_token_requests", b).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.GetDocumentWithAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.GetDocumentWithAccessToken(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// access denied
+	req := AccessTokenDocumentRequest{
+		Granter:              testingidentity.GenerateRandomDID(),
+		TokenID:              utils.RandomSlice(32),
+		DelegatingDocumentID: utils.RandomSlice(32),
+	}
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("GetWithAccessToken", mock.Anything, req.Granter, req.TokenID.Bytes(), docID, req.DelegatingDocumentID.Bytes()).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentNotFound, documents.ErrRequesterNotGrantee)).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GetDocumentWithAccessToken(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	doc.On("ID").Return(docID)
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32))
+	doc.On("Author").Return(nil, errors.New("somerror"))
+	doc.On("Timestamp").Return(nil, errors.New("somerror"))
+	doc.On("NFTs").Return(nil)
+	doc.On("GetStatus").Return(documents.Committed)
+	psrv.On("GetWithAccessToken", mock.Anything, req.Granter, req.TokenID.Bytes(), docID, req.DelegatingDocumentID.Bytes()).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.GetDocumentWithAccessToken(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	psrv.AssertExpectations(t)
+}
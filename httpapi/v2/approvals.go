@@ -0,0 +1,227 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ApprovalPolicyRequest requires threshold members of the role to approve the pending document before it is committed.
+type ApprovalPolicyRequest = documents.ApprovalPolicy
+
+// ApprovalStatusResponse holds the approval policy and the approvers of the pending document.
+type ApprovalStatusResponse struct {
+	RoleKey   byteutils.HexBytes `json:"role_key" swaggertype:"primitive,string"`
+	Threshold int                `json:"threshold"`
+	Approvers []identity.DID     `json:"approvers" swaggertype:"array,string"`
+	Approved  bool               `json:"approved"`
+}
+
+// PendingApprovalResponse holds the approval status of a pending document owned by an account.
+type PendingApprovalResponse struct {
+	Owner      identity.DID           `json:"owner" swaggertype:"primitive,string"`
+	DocumentID byteutils.HexBytes     `json:"document_id" swaggertype:"primitive,string"`
+	VersionID  byteutils.HexBytes     `json:"version_id" swaggertype:"primitive,string"`
+	Status     ApprovalStatusResponse `json:"status"`
+}
+
+func toApprovalStatusResponse(st documents.ApprovalStatus) ApprovalStatusResponse {
+	approvers := st.Approvers
+	if approvers == nil {
+		approvers = []identity.DID{}
+	}
+
+	return ApprovalStatusResponse{
+		RoleKey:   st.Policy.RoleKey,
+		Threshold: st.Policy.Threshold,
+		Approvers: approvers,
+		Approved:  st.Approved(),
+	}
+}
+
+func toPendingApprovalsResponse(approvals []pending.PendingApproval) []PendingApprovalResponse {
+	resp := []PendingApprovalResponse{}
+	for _, pa := range approvals {
+		resp = append(resp, PendingApprovalResponse{
+			Owner:      pa.Owner,
+			DocumentID: pa.DocumentID,
+			VersionID:  pa.VersionID,
+			Status:     toApprovalStatusResponse(pa.Status),
+		})
+	}
+
+	return resp
+}
+
+// SetApprovalPolicy sets the approval policy of the pending document.
+// @summary Sets the approval policy of the pending document.
+// @description Pending document cannot be committed until threshold members of the role approve it.
+// @description Changing the policy, or the document, discards the existing approvals.
+// @description The pending document is sent to the members of the role to request their approval. Setting the policy again sends the requests again.
+// @id set_approval_policy
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param body body v2.ApprovalPolicyRequest true "Approval Policy Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.ApprovalStatusResponse
+// @router /v2/documents/{document_id}/approval_policy [put]
+func (h handler) SetApprovalPolicy(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	var req ApprovalPolicyRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	ctx, p := withPrecondition(r)
+	st, err := h.srv.SetApprovalPolicy(ctx, docID, req)
+	if err != nil {
+		code = preconditionFailed(err, http.StatusBadRequest)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toApprovalStatusResponse(st))
+}
+
+// GetApprovalStatus returns the approval status of the pending document.
+// @summary Returns the approval status of the pending document.
+// @description Returns the approval policy and the collaborators who approved the pending document.
+// @id get_approval_status
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.ApprovalStatusResponse
+// @router /v2/documents/{document_id}/approvals [get]
+func (h handler) GetApprovalStatus(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	st, err := h.srv.GetApprovalStatus(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) ||
+			errors.IsOfType(documents.ErrApprovalPolicyMissing, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toApprovalStatusResponse(st))
+}
+
+// Approve approves the pending document on behalf of the account.
+// @summary Approves the pending document.
+// @description Signs an approval of every pending version of the document awaiting the approval of the account and sends it to the owner of the version.
+// @id approve_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} []v2.PendingApprovalResponse
+// @router /v2/documents/{document_id}/approvals [post]
+func (h handler) Approve(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	approvals, err := h.srv.Approve(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toPendingApprovalsResponse(approvals))
+}
+
+// ListPendingApprovals returns the pending documents awaiting the approval of the account.
+// @summary Returns the pending documents awaiting the approval of the account.
+// @description Returns the pending documents, of the other accounts, awaiting the approval of the account.
+// @id list_pending_approvals
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} []v2.PendingApprovalResponse
+// @router /v2/approvals [get]
+func (h handler) ListPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	approvals, err := h.srv.ListPendingApprovals(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toPendingApprovalsResponse(approvals))
+}
@@ -0,0 +1,200 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_SetApprovalPolicy(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("PUT", "/documents/{document_id}/approval_policy", b).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.SetApprovalPolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.SetApprovalPolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid policy
+	p := ApprovalPolicyRequest{RoleKey: utils.RandomSlice(32), Threshold: 2}
+	d, err := json.Marshal(p)
+	assert.NoError(t, err)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("SetApprovalPolicy", mock.Anything, docID, p).Return(nil, documents.ErrInvalidApprovalPolicy).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetApprovalPolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrInvalidApprovalPolicy.Error())
+
+	// missing document
+	psrv.On("SetApprovalPolicy", mock.Anything, docID, p).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetApprovalPolicy(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	psrv.On("SetApprovalPolicy", mock.Anything, docID, p).Return(documents.ApprovalStatus{Policy: p}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetApprovalPolicy(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ApprovalStatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, p.RoleKey, resp.RoleKey)
+	assert.Equal(t, 2, resp.Threshold)
+	assert.Len(t, resp.Approvers, 0)
+	assert.False(t, resp.Approved)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_GetApprovalStatus(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/approvals", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.GetApprovalStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing policy
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("GetApprovalStatus", mock.Anything, docID).Return(nil, documents.ErrApprovalPolicyMissing).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetApprovalStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	approver := testingidentity.GenerateRandomDID()
+	st := documents.ApprovalStatus{
+		Policy:    documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1},
+		Approvers: []identity.DID{approver},
+	}
+	psrv.On("GetApprovalStatus", mock.Anything, docID).Return(st, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetApprovalStatus(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ApprovalStatusResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []identity.DID{approver}, resp.Approvers)
+	assert.True(t, resp.Approved)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_Approve(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/approvals", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.Approve(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// nothing to approve
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("Approve", mock.Anything, docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.Approve(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// failed to sign
+	psrv.On("Approve", mock.Anything, docID).Return(nil, errors.New("failed to sign")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.Approve(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	owner, approver := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	versionID := utils.RandomSlice(32)
+	psrv.On("Approve", mock.Anything, docID).Return([]pending.PendingApproval{{
+		Owner:      owner,
+		DocumentID: docID,
+		VersionID:  versionID,
+		Status: documents.ApprovalStatus{
+			Policy:    documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 2},
+			Approvers: []identity.DID{approver},
+		},
+	}}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.Approve(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []PendingApprovalResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, owner, resp[0].Owner)
+	assert.Equal(t, versionID, resp[0].VersionID.Bytes())
+	assert.Equal(t, []identity.DID{approver}, resp[0].Status.Approvers)
+	assert.False(t, resp[0].Status.Approved)
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_ListPendingApprovals(t *testing.T) {
+	h := handler{}
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+
+	// failed
+	psrv.On("ListPendingApprovals", mock.Anything).Return(nil, errors.New("failed to list")).Once()
+	w, r := httptest.NewRecorder(), httptest.NewRequest("GET", "/approvals", nil)
+	h.ListPendingApprovals(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// nothing pending
+	psrv.On("ListPendingApprovals", mock.Anything).Return(nil, nil).Once()
+	w, r = httptest.NewRecorder(), httptest.NewRequest("GET", "/approvals", nil)
+	h.ListPendingApprovals(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]\n", w.Body.String())
+	psrv.AssertExpectations(t)
+}
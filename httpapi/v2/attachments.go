@@ -0,0 +1,148 @@
+package v2
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// AttachmentHashParam is the key for the attachment hash in the API path.
+	AttachmentHashParam = "attachment_hash"
+
+	// AttachmentNameQueryParam is the key for the attachment name in the add attachment query.
+	AttachmentNameQueryParam = "name"
+
+	// defaultAttachmentMIMEType is used when the content type of the attachment is not provided.
+	defaultAttachmentMIMEType = "application/octet-stream"
+)
+
+// ErrInvalidAttachmentHash for invalid attachment hash in the api path.
+const ErrInvalidAttachmentHash = errors.Error("Invalid Attachment Hash")
+
+// AddAttachment stores the content and adds the attachment to the pending document.
+// @summary Adds an attachment to the pending document.
+// @description Stores the request body as the content of the attachment and references it from the pending document
+// @description with its name, MIME type, size, and sha256 hash. MIME type is taken from the Content-Type header.
+// @description Attachment with the same name is replaced.
+// @id add_attachment
+// @tags Documents
+// @accept application/octet-stream
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param name query string true "Attachment name"
+// @param body body string true "Attachment content"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 201 {object} documents.Attachment
+// @router /v2/documents/{document_id}/attachments [post]
+func (h handler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	// read one more byte than allowed to detect oversized content
+	content, err := ioutil.ReadAll(io.LimitReader(r.Body, documents.MaxAttachmentSize+1))
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	mimeType := r.Header.Get("Content-Type")
+	if mimeType == "" {
+		mimeType = defaultAttachmentMIMEType
+	}
+
+	ctx, p := withPrecondition(r)
+	att, err := h.srv.AddAttachment(ctx, docID, r.URL.Query().Get(AttachmentNameQueryParam), mimeType, content)
+	if err != nil {
+		code = preconditionFailed(err, http.StatusBadRequest)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, att)
+}
+
+// GetAttachment returns the content of the attachment.
+// @summary Returns the content of the attachment.
+// @description Returns the content of the attachment referenced from the pending or the latest committed version of the document.
+// @description Content not available locally is requested from the author of the version and checked against the attachment hash.
+// @id get_attachment
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param attachment_hash path string true "Attachment Hash"
+// @produce application/octet-stream
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {file} file
+// @router /v2/documents/{document_id}/attachments/{attachment_hash} [get]
+func (h handler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	hash, err := hexutil.Decode(chi.URLParam(r, AttachmentHashParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidAttachmentHash
+		return
+	}
+
+	att, content, err := h.srv.GetAttachment(r.Context(), docID, hash)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) ||
+			errors.IsOfType(documents.ErrAttachmentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	w.Header().Set("Content-Type", att.MIMEType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", att.Name))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(content)
+	if err != nil {
+		log.Error(err)
+	}
+}
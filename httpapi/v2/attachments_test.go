@@ -0,0 +1,154 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_AddAttachment(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		r := httptest.NewRequest("POST", "/documents/{document_id}/attachments?name=invoice.pdf", b).WithContext(ctx)
+		r.Header.Set("Content-Type", "application/pdf")
+		return httptest.NewRecorder(), r
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.AddAttachment(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	content := utils.RandomSlice(64)
+	att, err := documents.NewAttachment("invoice.pdf", "application/pdf", content)
+	assert.NoError(t, err)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	repo := new(testingdocuments.MockAttachmentRepository)
+	repo.On("Create", content).Return([]byte(att.Hash), nil)
+	psrv := new(pending.MockService)
+	psrv.On("AddAttachment", mock.Anything, docID, att).Return(nil, documents.ErrDocumentNotFound).Once()
+	h.srv = Service{pendingDocSrv: psrv, attachmentRepo: repo}
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(content))
+	h.AddAttachment(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	psrv.On("AddAttachment", mock.Anything, docID, att).Return(new(testingdocuments.MockModel), nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(content))
+	h.AddAttachment(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp documents.Attachment
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, att, resp)
+	psrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestHandler_GetAttachment(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/attachments/{attachment_hash}", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam, AttachmentHashParam}
+	rctx.URLParams.Values = []string{"some invalid id", "some invalid hash"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid hash
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidAttachmentHash.Error())
+
+	// attachment not in the document
+	content := utils.RandomSlice(64)
+	att, err := documents.NewAttachment("invoice.pdf", "application/pdf", content)
+	assert.NoError(t, err)
+	attr, err := documents.NewAttachmentAttribute(att)
+	assert.NoError(t, err)
+	rctx.URLParams.Values[1] = hexutil.Encode(att.Hash)
+	model := new(testingdocuments.MockModel)
+	model.On("GetAttributes").Return(nil).Once()
+	psrv := new(pending.MockService)
+	psrv.On("Get", mock.Anything, docID, documents.Pending).Return(nil, documents.ErrDocumentNotFound)
+	psrv.On("Get", mock.Anything, docID, documents.Committed).Return(model, nil)
+	repo := new(testingdocuments.MockAttachmentRepository)
+	client := new(testingdocuments.MockP2PClient)
+	h.srv = Service{pendingDocSrv: psrv, attachmentRepo: repo, p2pClient: client}
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// content stored locally
+	model.On("GetAttributes").Return([]documents.Attribute{attr})
+	repo.On("Get", []byte(att.Hash)).Return(content, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, content, w.Body.Bytes())
+
+	// content from the author doesn't match
+	author := testingidentity.GenerateRandomDID()
+	model.On("Author").Return(author, nil)
+	repo.On("Get", []byte(att.Hash)).Return(nil, documents.ErrAttachmentNotFound)
+	client.On("GetAttachmentRequest", mock.Anything, author, mock.Anything).Return(
+		&p2pcommon.GetAttachmentResponse{Content: utils.RandomSlice(64)}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrInvalidAttachment.Error())
+
+	// author unreachable
+	client.On("GetAttachmentRequest", mock.Anything, author, mock.Anything).Return(nil, errors.New("unreachable")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// content from the author
+	client.On("GetAttachmentRequest", mock.Anything, author, mock.Anything).Return(
+		&p2pcommon.GetAttachmentResponse{Content: content}, nil).Once()
+	repo.On("Create", content).Return([]byte(att.Hash), nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAttachment(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, content, w.Body.Bytes())
+	psrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	client.AssertExpectations(t)
+	model.AssertExpectations(t)
+}
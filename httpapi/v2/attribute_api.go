@@ -25,9 +25,11 @@ type SignedAttributeRequest struct {
 // @tags Documents
 // @accept json
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param body body v2.SignedAttributeRequest true "Signed Attribute request"
 // @produce json
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 403 {object} httputils.HTTPError
 // @success 200 {object} coreapi.DocumentResponse
@@ -53,9 +55,10 @@ func (h handler) AddSignedAttribute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	doc, err := h.srv.AddSignedAttribute(r.Context(), docID, req.Label, req.Payload.Bytes())
+	ctx, p := withPrecondition(r)
+	doc, err := h.srv.AddSignedAttribute(ctx, docID, req.Label, req.Payload.Bytes())
 	if err != nil {
-		code = http.StatusBadRequest
+		code = preconditionFailed(err, http.StatusBadRequest)
 		log.Error(err)
 		return
 	}
@@ -67,6 +70,7 @@ func (h handler) AddSignedAttribute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -68,7 +68,7 @@ func TestHandler_AddSignedAttribute(t *testing.T) {
 	}
 	d, err := json.Marshal(req)
 	assert.NoError(t, err)
-	pendingSrv.On("AddSignedAttribute", ctx, docID, label, payload).Return(nil, errors.New("failed to add attribute")).Once()
+	pendingSrv.On("AddSignedAttribute", mock.Anything, docID, label, payload).Return(nil, errors.New("failed to add attribute")).Once()
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
 	h.AddSignedAttribute(w, r)
 	assert.Equal(t, w.Code, http.StatusBadRequest)
@@ -80,7 +80,7 @@ func TestHandler_AddSignedAttribute(t *testing.T) {
 	doc.On("Scheme").Return("generic").Twice()
 	doc.On("GetAttributes").Return(nil).Twice()
 	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, errors.New("failed to get collaborators")).Once()
-	pendingSrv.On("AddSignedAttribute", ctx, docID, label, payload).Return(doc, nil)
+	pendingSrv.On("AddSignedAttribute", mock.Anything, docID, label, payload).Return(doc, nil)
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
 	h.AddSignedAttribute(w, r)
 	assert.Equal(t, w.Code, http.StatusInternalServerError)
@@ -0,0 +1,145 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// JobIDParam is the key for job ID in the API path.
+const JobIDParam = "job_id"
+
+// BatchCommitRequest holds the documents to be committed together.
+type BatchCommitRequest struct {
+	DocumentIDs []byteutils.HexBytes `json:"document_ids" swaggertype:"array,string"`
+}
+
+// BatchCommitDocument holds the commit progress of a document in the batch.
+type BatchCommitDocument struct {
+	DocumentID byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+
+	// JobID is the anchor job of the document. Empty until the document is committed.
+	JobID   string `json:"job_id,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// BatchCommitResponse holds the batch job and the commit progress of each document.
+type BatchCommitResponse struct {
+	JobID     string                `json:"job_id"`
+	Status    string                `json:"status"`
+	Documents []BatchCommitDocument `json:"documents"`
+}
+
+func toBatchCommitResponse(jobID jobs.JobID, st jobs.Status, docs []pending.BatchCommitStatus) BatchCommitResponse {
+	resp := BatchCommitResponse{JobID: jobID.String(), Status: string(st), Documents: []BatchCommitDocument{}}
+	for _, d := range docs {
+		resp.Documents = append(resp.Documents, BatchCommitDocument{
+			DocumentID: d.DocumentID,
+			JobID:      d.JobID.String(),
+			Status:     string(d.Status),
+			Message:    d.Message,
+		})
+	}
+
+	return resp
+}
+
+// CommitDocuments commits multiple pending documents within a single job.
+// @summary Commits multiple pending documents.
+// @description Validates all the pending documents first and fails if any of them is invalid.
+// @description Valid documents are committed within a single job that tracks the anchoring of each document.
+// @id commit_documents
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.BatchCommitRequest true "Batch Commit Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 202 {object} v2.BatchCommitResponse
+// @router /v2/documents/commit [post]
+func (h handler) CommitDocuments(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req BatchCommitRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	docIDs := make([][]byte, len(req.DocumentIDs))
+	for i, id := range req.DocumentIDs {
+		docIDs[i] = id
+	}
+
+	ctx := r.Context()
+	jobID, err := h.srv.CommitDocuments(ctx, docIDs)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	st, docs, err := h.srv.GetBatchCommitStatus(ctx, jobID)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, toBatchCommitResponse(jobID, st, docs))
+}
+
+// GetBatchCommitStatus returns the status of the batch commit job.
+// @summary Returns the status of the batch commit job.
+// @description Returns the status of the batch commit job along with the commit progress of each document.
+// @id get_batch_commit_status
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Batch Commit Job ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.BatchCommitResponse
+// @router /v2/documents/commit/{job_id} [get]
+func (h handler) GetBatchCommitStatus(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, JobIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidJobID
+		return
+	}
+
+	st, docs, err := h.srv.GetBatchCommitStatus(r.Context(), jobID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			code = http.StatusNotFound
+			err = coreapi.ErrJobNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toBatchCommitResponse(jobID, st, docs))
+}
@@ -0,0 +1,110 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_CommitDocuments(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/commit", b).WithContext(ctx)
+	}
+
+	// invalid body
+	ctx := context.Background()
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.CommitDocuments(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid batch
+	id1, id2 := utils.RandomSlice(32), utils.RandomSlice(32)
+	d, err := json.Marshal(BatchCommitRequest{DocumentIDs: []byteutils.HexBytes{id1, id2}})
+	assert.NoError(t, err)
+	srv := new(pending.MockService)
+	h = handler{srv: Service{pendingDocSrv: srv}}
+	srv.On("CommitBatch", ctx, [][]byte{id1, id2}).Return(nil, pending.ErrInvalidBatch).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CommitDocuments(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrInvalidBatch.Error())
+
+	// success
+	jobID := jobs.NewJobID()
+	srv.On("CommitBatch", ctx, [][]byte{id1, id2}).Return(jobID, nil).Once()
+	srv.On("GetBatchCommitStatus", ctx, jobID).Return(jobs.Pending, []pending.BatchCommitStatus{
+		{DocumentID: id1, JobID: jobs.NilJobID(), Status: jobs.Pending, Message: "validated"},
+		{DocumentID: id2, JobID: jobs.NilJobID(), Status: jobs.Pending, Message: "validated"},
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CommitDocuments(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp BatchCommitResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, jobID.String(), resp.JobID)
+	assert.Equal(t, string(jobs.Pending), resp.Status)
+	assert.Len(t, resp.Documents, 2)
+	assert.Equal(t, byteutils.HexBytes(id1), resp.Documents[0].DocumentID)
+	assert.Empty(t, resp.Documents[0].JobID)
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_GetBatchCommitStatus(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/commit/{job_id}", nil).WithContext(ctx)
+	}
+
+	// invalid job ID
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{JobIDParam}
+	rctx.URLParams.Values = []string{"invalid"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetBatchCommitStatus(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidJobID.Error())
+
+	// missing job
+	jobID := jobs.NewJobID()
+	rctx.URLParams.Values[0] = jobID.String()
+	srv := new(pending.MockService)
+	h = handler{srv: Service{pendingDocSrv: srv}}
+	srv.On("GetBatchCommitStatus", ctx, jobID).Return(nil, nil, errors.NewTypedError(jobs.ErrJobsMissing, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetBatchCommitStatus(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrJobNotFound.Error())
+
+	// success
+	docID, childID := utils.RandomSlice(32), jobs.NewJobID()
+	srv.On("GetBatchCommitStatus", ctx, jobID).Return(jobs.Success, []pending.BatchCommitStatus{
+		{DocumentID: docID, JobID: childID, Status: jobs.Success, Message: "committed"},
+	}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetBatchCommitStatus(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp BatchCommitResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, string(jobs.Success), resp.Status)
+	assert.Len(t, resp.Documents, 1)
+	assert.Equal(t, childID.String(), resp.Documents[0].JobID)
+	assert.Equal(t, "committed", resp.Documents[0].Message)
+	srv.AssertExpectations(t)
+}
@@ -1,10 +1,15 @@
 package v2
 
 import (
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/schemas"
+	"github.com/centrifuge/go-centrifuge/templates"
 )
 
 // BootstrappedService key maps to the Service implementation in Bootstrap context.
@@ -25,9 +30,51 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("failed to get %s", bootstrap.BootstrappedNFTService)
 	}
 
+	schemaSrv, ok := ctx[schemas.BootstrappedService].(schemas.Service)
+	if !ok {
+		return errors.New("failed to get %s", schemas.BootstrappedService)
+	}
+
+	templateSrv, ok := ctx[templates.BootstrappedService].(templates.Service)
+	if !ok {
+		return errors.New("failed to get %s", templates.BootstrappedService)
+	}
+
+	anchorSrv, ok := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+	if !ok {
+		return errors.New("failed to get %s", anchors.BootstrappedAnchorService)
+	}
+
+	idService, ok := ctx[identity.BootstrappedDIDService].(identity.Service)
+	if !ok {
+		return errors.New("failed to get %s", identity.BootstrappedDIDService)
+	}
+
+	attachmentRepo, ok := ctx[documents.BootstrappedAttachmentRepository].(documents.AttachmentRepository)
+	if !ok {
+		return errors.New("failed to get %s", documents.BootstrappedAttachmentRepository)
+	}
+
+	p2pClient, ok := ctx[bootstrap.BootstrappedPeer].(documents.Client)
+	if !ok {
+		return errors.New("failed to get %s", bootstrap.BootstrappedPeer)
+	}
+
+	eventBus, ok := ctx[notification.BootstrappedEventBus].(notification.EventBus)
+	if !ok {
+		return errors.New("failed to get %s", notification.BootstrappedEventBus)
+	}
+
 	ctx[BootstrappedService] = Service{
-		pendingDocSrv: pendingDocSrv,
-		tokenRegistry: nftSrv,
+		pendingDocSrv:  pendingDocSrv,
+		tokenRegistry:  nftSrv,
+		schemaSrv:      schemaSrv,
+		templateSrv:    templateSrv,
+		anchorSrv:      anchorSrv,
+		idService:      idService,
+		attachmentRepo: attachmentRepo,
+		p2pClient:      p2pClient,
+		eventBus:       eventBus,
 	}
 	return nil
 }
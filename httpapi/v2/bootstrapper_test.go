@@ -5,8 +5,17 @@ package v2
 import (
 	"testing"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/schemas"
+	"github.com/centrifuge/go-centrifuge/templates"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingnfts "github.com/centrifuge/go-centrifuge/testingutils/nfts"
 	"github.com/stretchr/testify/assert"
 )
@@ -26,9 +35,51 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), bootstrap.BootstrappedNFTService)
 
-	// success
+	// missing schema service
 	ctx[bootstrap.BootstrappedNFTService] = new(testingnfts.MockNFTService)
 	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), schemas.BootstrappedService)
+
+	// missing template service
+	ctx[schemas.BootstrappedService] = new(schemas.MockService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), templates.BootstrappedService)
+
+	// missing anchor service
+	ctx[templates.BootstrappedService] = new(templates.MockService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), anchors.BootstrappedAnchorService)
+
+	// missing identity service
+	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), identity.BootstrappedDIDService)
+
+	// missing attachment repository
+	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.BootstrappedAttachmentRepository)
+
+	// missing p2p client
+	ctx[documents.BootstrappedAttachmentRepository] = new(testingdocuments.MockAttachmentRepository)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), bootstrap.BootstrappedPeer)
+
+	// missing event bus
+	ctx[bootstrap.BootstrappedPeer] = new(testingdocuments.MockP2PClient)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), notification.BootstrappedEventBus)
+
+	// success
+	ctx[notification.BootstrappedEventBus] = notification.NewEventBus()
+	err = b.Bootstrap(ctx)
 	assert.NoError(t, b.Bootstrap(ctx))
 	assert.NotNil(t, ctx[BootstrappedService])
 }
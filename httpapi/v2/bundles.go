@@ -0,0 +1,117 @@
+package v2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ExportDocumentBundle returns the bundle of the committed version of the document.
+// @summary Returns the bundle of the committed version of the document.
+// @description Returns a zip archive holding the packed core document and a manifest with the signatures,
+// @description the validity of the signer keys at the document timestamp, and the anchor of the version.
+// @description Bundle can be verified without a centrifuge node.
+// @id export_document_bundle
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Version Identifier"
+// @produce application/zip
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {file} file
+// @router /v2/documents/{document_id}/versions/{version_id}/bundle [get]
+func (h handler) ExportDocumentBundle(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ids := make([][]byte, 2, 2)
+	for i, idStr := range []string{chi.URLParam(r, coreapi.DocumentIDParam), chi.URLParam(r, coreapi.VersionIDParam)} {
+		var id []byte
+		id, err = hexutil.Decode(idStr)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+
+		ids[i] = id
+	}
+
+	data, err := h.srv.ExportDocumentBundle(r.Context(), ids[0], ids[1])
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) ||
+			errors.IsOfType(documents.ErrDocumentVersionNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", hexutil.Encode(ids[1])))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// ImportDocumentBundle validates the bundle and stores the anchored version of the document.
+// @summary Imports the anchored version of the document from the bundle.
+// @description Validates the signatures and the anchor of the version in the bundle against the chain
+// @description and stores it as a read-only committed version. Existing versions are not overwritten.
+// @description New versions of a read-only document cannot be created. Bundles larger than 16MB are rejected.
+// @id import_document_bundle
+// @tags Documents
+// @accept application/zip
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body string true "Document Bundle"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} coreapi.DocumentResponse
+// @router /v2/documents/bundles [post]
+func (h handler) ImportDocumentBundle(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, documents.MaxBundleSize))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	doc, err := h.srv.ImportDocumentBundle(r.Context(), data)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,109 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ExportDocumentBundle(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions/{version_id}/bundle", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam, coreapi.VersionIDParam}
+	rctx.URLParams.Values = []string{"some invalid id", "some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.ExportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid version id
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing version
+	rctx.URLParams.Values[1] = hexutil.Encode(versionID)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("ExportBundle", mock.Anything, docID, versionID).Return(nil, documents.ErrDocumentVersionNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// not anchored
+	psrv.On("ExportBundle", mock.Anything, docID, versionID).Return(
+		nil, errors.NewTypedError(documents.ErrDocumentNotInAllowedState, errors.New("not anchored"))).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	data := utils.RandomSlice(64)
+	psrv.On("ExportBundle", mock.Anything, docID, versionID).Return(data, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ExportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, data, w.Body.Bytes())
+	psrv.AssertExpectations(t)
+}
+
+func TestHandler_ImportDocumentBundle(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/bundles", b).WithContext(ctx)
+	}
+
+	// invalid bundle
+	ctx := context.Background()
+	data := utils.RandomSlice(64)
+	psrv := new(pending.MockService)
+	h := handler{srv: Service{pendingDocSrv: psrv}}
+	psrv.On("ImportBundle", mock.Anything, data).Return(nil, documents.ErrInvalidBundle).Once()
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader(data))
+	h.ImportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrInvalidBundle.Error())
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	doc.On("ID").Return(utils.RandomSlice(32))
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32))
+	doc.On("Author").Return(nil, errors.New("somerror"))
+	doc.On("Timestamp").Return(nil, errors.New("somerror"))
+	doc.On("NFTs").Return(nil)
+	doc.On("GetStatus").Return(documents.Committed)
+	psrv.On("ImportBundle", mock.Anything, data).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(data))
+	h.ImportDocumentBundle(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	psrv.AssertExpectations(t)
+}
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func toDocumentsPayload(req DocumentRequest, docID []byte) (payload documents.UpdatePayload, err error) {
@@ -65,3 +67,110 @@ func toClientRules(rules []*coredocumentpb.TransitionRule) (tr TransitionRules)
 
 	return tr
 }
+
+func toDocumentVersions(versions []documents.VersionInfo, next []byte) DocumentVersions {
+	resp := DocumentVersions{Versions: []DocumentVersion{}, Next: next}
+	for _, v := range versions {
+		dv := DocumentVersion{
+			VersionID:         v.VersionID,
+			PreviousVersionID: v.PreviousVersion,
+			Author:            v.Author.String(),
+			Status:            string(v.Status),
+		}
+
+		if !v.Timestamp.IsZero() {
+			dv.CreatedAt = v.Timestamp.UTC().Format(time.RFC3339)
+		}
+
+		if len(v.AnchorID) > 0 {
+			dv.Anchor = &Anchor{
+				AnchorID:     v.AnchorID,
+				DocumentRoot: v.DocumentRoot,
+				Anchored:     v.Anchored,
+			}
+		}
+
+		resp.Versions = append(resp.Versions, dv)
+	}
+
+	return resp
+}
+
+func toDocumentDiff(diff documents.Diff) DocumentDiff {
+	resp := DocumentDiff{
+		DocumentID:    diff.DocumentID,
+		From:          diff.From,
+		To:            diff.To,
+		Attributes:    []AttributeChange{},
+		Roles:         []RoleChange{},
+		ReadRules:     []ReadRuleChange{},
+		Collaborators: []CollaboratorChange{},
+		NFTs:          []NFTChange{},
+		Fields:        []FieldChange{},
+	}
+
+	for _, a := range diff.Attributes {
+		key := a.Key
+		resp.Attributes = append(resp.Attributes, AttributeChange{
+			Action:   string(a.Action),
+			Key:      key[:],
+			KeyLabel: a.KeyLabel,
+			Type:     a.Type.String(),
+			Old:      a.Old,
+			New:      a.New,
+		})
+	}
+
+	for _, r := range diff.Roles {
+		resp.Roles = append(resp.Roles, RoleChange{
+			Action:  string(r.Action),
+			RoleID:  r.RoleKey,
+			Added:   byteutils.ToHexByteSlice(r.Added),
+			Removed: byteutils.ToHexByteSlice(r.Removed),
+		})
+	}
+
+	for _, r := range diff.ReadRules {
+		resp.ReadRules = append(resp.ReadRules, ReadRuleChange{
+			Action: string(r.Action),
+			Roles:  byteutils.ToHexByteSlice(r.Roles),
+			Access: coredocumentpb.Action_name[int32(r.ReadAction)],
+		})
+	}
+
+	for _, c := range diff.Collaborators {
+		access := "read"
+		if c.Write {
+			access = "read_write"
+		}
+
+		resp.Collaborators = append(resp.Collaborators, CollaboratorChange{
+			Action:       string(c.Action),
+			Collaborator: c.DID,
+			Access:       access,
+		})
+	}
+
+	for _, n := range diff.NFTsAdded {
+		registry := n.RegistryId
+		if len(registry) > common.AddressLength {
+			registry = registry[:common.AddressLength]
+		}
+
+		resp.NFTs = append(resp.NFTs, NFTChange{
+			Registry: common.BytesToAddress(registry).Hex(),
+			TokenID:  n.TokenId,
+		})
+	}
+
+	for _, f := range diff.ChangedFields {
+		resp.Fields = append(resp.Fields, FieldChange{
+			Property: f.Property,
+			Name:     f.Name,
+			Old:      f.Old,
+			New:      f.New,
+		})
+	}
+
+	return resp
+}
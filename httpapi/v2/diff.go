@@ -0,0 +1,138 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ErrInvalidVersionID for invalid from and to versions in the diff query.
+const ErrInvalidVersionID = errors.Error("Invalid VersionID")
+
+// AttributeChange is a single attribute added, removed or changed in the new version.
+type AttributeChange struct {
+	Action   string             `json:"action" enums:"added,removed,changed"`
+	Key      byteutils.HexBytes `json:"key" swaggertype:"primitive,string"`
+	KeyLabel string             `json:"key_label"`
+	Type     string             `json:"type"`
+	Old      string             `json:"old,omitempty"`
+	New      string             `json:"new,omitempty"`
+}
+
+// RoleChange holds the collaborators added to or removed from a role.
+type RoleChange struct {
+	Action  string               `json:"action" enums:"added,removed,changed"`
+	RoleID  byteutils.HexBytes   `json:"role_id" swaggertype:"primitive,string"`
+	Added   []byteutils.HexBytes `json:"added" swaggertype:"array,string"`
+	Removed []byteutils.HexBytes `json:"removed" swaggertype:"array,string"`
+}
+
+// ReadRuleChange is a read rule added or removed in the new version.
+type ReadRuleChange struct {
+	Action string               `json:"action" enums:"added,removed"`
+	Roles  []byteutils.HexBytes `json:"roles" swaggertype:"array,string"`
+	Access string               `json:"access"`
+}
+
+// CollaboratorChange is a collaborator whose access was added or removed in the new version.
+type CollaboratorChange struct {
+	Action       string       `json:"action" enums:"added,removed"`
+	Collaborator identity.DID `json:"collaborator" swaggertype:"primitive,string"`
+	Access       string       `json:"access" enums:"read,read_write"`
+}
+
+// NFTChange is an NFT minted in the new version.
+type NFTChange struct {
+	Registry string             `json:"registry"`
+	TokenID  byteutils.HexBytes `json:"token_id" swaggertype:"primitive,string"`
+}
+
+// FieldChange is a changed leaf of the core document tree.
+type FieldChange struct {
+	Property byteutils.HexBytes `json:"property" swaggertype:"primitive,string"`
+	Name     string             `json:"name"`
+	Old      byteutils.HexBytes `json:"old" swaggertype:"primitive,string"`
+	New      byteutils.HexBytes `json:"new" swaggertype:"primitive,string"`
+}
+
+// DocumentDiff holds the changes made in the version to compared to the version from.
+type DocumentDiff struct {
+	DocumentID    byteutils.HexBytes   `json:"document_id" swaggertype:"primitive,string"`
+	From          byteutils.HexBytes   `json:"from" swaggertype:"primitive,string"`
+	To            byteutils.HexBytes   `json:"to" swaggertype:"primitive,string"`
+	Attributes    []AttributeChange    `json:"attributes"`
+	Roles         []RoleChange         `json:"roles"`
+	ReadRules     []ReadRuleChange     `json:"read_rules"`
+	Collaborators []CollaboratorChange `json:"collaborators"`
+	NFTs          []NFTChange          `json:"nfts"`
+	Fields        []FieldChange        `json:"fields"`
+}
+
+// GetDocumentDiff returns the changes between two versions of the document.
+// @summary Returns the changes between two versions of the document.
+// @description Returns the changes between two versions of the document.
+// @description If to is not provided, pending version is used if present, else the latest committed version.
+// @description If from is not provided, previous version of to is used.
+// @id get_document_diff
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param from query string false "Old Version Identifier"
+// @param to query string false "New Version Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentDiff
+// @router /v2/documents/{document_id}/diff [get]
+func (h handler) GetDocumentDiff(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	versions := make([][]byte, 2)
+	for i, v := range []string{"from", "to"} {
+		val := r.URL.Query().Get(v)
+		if val == "" {
+			continue
+		}
+
+		versions[i], err = hexutil.Decode(val)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = ErrInvalidVersionID
+			return
+		}
+	}
+
+	diff, err := h.srv.GetDocumentDiff(r.Context(), docID, versions[0], versions[1])
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toDocumentDiff(diff))
+}
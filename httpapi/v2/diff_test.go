@@ -0,0 +1,111 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDocumentDiff(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/diff?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid document id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	rctx.URLParams.Values[0] = "invalid"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, "")
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid from version
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, "from=invalid")
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidVersionID.Error())
+
+	// missing document
+	pendingSrv := new(pending.MockService)
+	pendingSrv.On("Diff", ctx, docID, []byte(nil), []byte(nil)).Return(nil, documents.ErrDocumentNotFound).Once()
+	h.srv.pendingDocSrv = pendingSrv
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrDocumentNotFound.Error())
+
+	// different documents
+	from, to := utils.RandomSlice(32), utils.RandomSlice(32)
+	pendingSrv.On("Diff", ctx, docID, from, to).Return(nil, errors.NewTypedError(documents.ErrDocumentInvalid, errors.New("different documents"))).Once()
+	w, r = getHTTPReqAndResp(ctx, "from="+hexutil.Encode(from)+"&to="+hexutil.Encode(to))
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	attr, err := documents.NewStringAttribute("label", documents.AttrString, "value")
+	assert.NoError(t, err)
+	collab := testingidentity.GenerateRandomDID()
+	diff := documents.Diff{
+		DocumentID: docID,
+		From:       from,
+		To:         to,
+		Attributes: []documents.AttributeDiff{
+			{Action: documents.DiffAdded, KeyLabel: attr.KeyLabel, Key: attr.Key, Type: attr.Value.Type, New: "value"},
+		},
+		Roles: []documents.RoleDiff{
+			{Action: documents.DiffAdded, RoleKey: utils.RandomSlice(32), Added: [][]byte{collab[:]}},
+		},
+		ReadRules: []documents.ReadRuleDiff{
+			{Action: documents.DiffRemoved, Roles: [][]byte{utils.RandomSlice(32)}, ReadAction: coredocumentpb.Action_ACTION_READ_SIGN},
+		},
+		Collaborators: []documents.CollaboratorDiff{
+			{Action: documents.DiffAdded, DID: collab, Write: true},
+		},
+		NFTsAdded: []*coredocumentpb.NFT{
+			{RegistryId: utils.RandomSlice(32), TokenId: utils.RandomSlice(32)},
+			// short registry received from a collaborator
+			{RegistryId: utils.RandomSlice(4), TokenId: utils.RandomSlice(32)},
+		},
+	}
+	pendingSrv.On("Diff", ctx, docID, from, to).Return(diff, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "from="+hexutil.Encode(from)+"&to="+hexutil.Encode(to))
+	h.GetDocumentDiff(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DocumentDiff
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, docID, resp.DocumentID.Bytes())
+	assert.Len(t, resp.Attributes, 1)
+	assert.Equal(t, attr.Key[:], resp.Attributes[0].Key.Bytes())
+	assert.Equal(t, "added", resp.Attributes[0].Action)
+	assert.Len(t, resp.Roles, 1)
+	assert.Len(t, resp.ReadRules, 1)
+	assert.Equal(t, "ACTION_READ_SIGN", resp.ReadRules[0].Access)
+	assert.Len(t, resp.Collaborators, 1)
+	assert.Equal(t, collab, resp.Collaborators[0].Collaborator)
+	assert.Equal(t, "read_write", resp.Collaborators[0].Access)
+	assert.Len(t, resp.NFTs, 2)
+	assert.Empty(t, resp.Fields)
+	pendingSrv.AssertExpectations(t)
+}
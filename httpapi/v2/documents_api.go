@@ -2,11 +2,16 @@ package v2
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/templates"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,6 +27,9 @@ type DocumentRequest = coreapi.CreateDocumentRequest
 type CreateDocumentRequest struct {
 	DocumentRequest
 	DocumentID byteutils.OptionalHex `json:"document_id" swaggertype:"primitive,string"` // if provided, creates the next version of the document.
+
+	// Params substitute the placeholders of the template. Used only when the document is created from a template.
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // UpdateDocumentRequest defines the payload to patch an existing document.
@@ -32,13 +40,16 @@ type UpdateDocumentRequest struct {
 // CreateDocument creates a document.
 // @summary Creates a new document.
 // @description Creates a new document.
+// @description If the template is provided, scheme, default attributes, roles, and rules are taken from the template.
 // @id create_document_v2
 // @tags Documents
 // @accept json
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template query string false "Template Name"
 // @param body body v2.CreateDocumentRequest true "Document Create request"
 // @produce json
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 403 {object} httputils.HTTPError
 // @success 201 {object} coreapi.DocumentResponse
@@ -64,9 +75,17 @@ func (h handler) CreateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	doc, err := h.srv.CreateDocument(ctx, payload)
+	var doc documents.Model
+	if name := r.URL.Query().Get(TemplateQueryParam); name != "" {
+		doc, err = h.srv.CreateDocumentFromTemplate(ctx, name, req.Params, payload)
+	} else {
+		doc, err = h.srv.CreateDocument(ctx, payload)
+	}
 	if err != nil {
 		code = http.StatusBadRequest
+		if errors.IsOfType(templates.ErrTemplateNotFound, err) {
+			code = http.StatusNotFound
+		}
 		log.Error(err)
 		return
 	}
@@ -78,6 +97,7 @@ func (h handler) CreateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setDocumentETag(w, doc)
 	render.Status(r, http.StatusCreated)
 	render.JSON(w, r, resp)
 }
@@ -89,10 +109,12 @@ func (h handler) CreateDocument(w http.ResponseWriter, r *http.Request) {
 // @tags Documents
 // @accept json
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param body body v2.UpdateDocumentRequest true "Document Update request"
 // @param document_id path string true "Document Identifier"
 // @produce json
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 403 {object} httputils.HTTPError
 // @success 200 {object} coreapi.DocumentResponse
@@ -110,7 +132,6 @@ func (h handler) UpdateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
 	var req UpdateDocumentRequest
 	err = unmarshalBody(r, &req)
 	if err != nil {
@@ -126,9 +147,10 @@ func (h handler) UpdateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx, p := withPrecondition(r)
 	doc, err := h.srv.UpdateDocument(ctx, payload)
 	if err != nil {
-		code = http.StatusBadRequest
+		code = preconditionFailed(err, http.StatusBadRequest)
 		log.Error(err)
 		return
 	}
@@ -140,6 +162,7 @@ func (h handler) UpdateDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -190,6 +213,126 @@ func (h handler) Commit(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, resp)
 }
 
+// RetryCommit resumes the anchoring of the committed document.
+// @summary Resumes the anchoring of the committed document after failure.
+// @description Resumes the anchoring of the latest version of the document from the last stage completed by the failed job.
+// @description Stages are prepared, precommitted, signed, anchored, and sent. Completed stages are not repeated.
+// @description Fails while the job anchoring the version is still running, or if no anchor job is recorded for the version.
+// @id retry_commit_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 202 {object} coreapi.DocumentResponse
+// @router /v2/documents/{document_id}/commit/retry [post]
+func (h handler) RetryCommit(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	doc, jobID, err := h.srv.RetryCommit(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		switch {
+		case errors.IsOfType(documents.ErrDocumentNotFound, err):
+			code = http.StatusNotFound
+		case errors.IsOfType(documents.ErrCommitNotRetryable, err):
+			code = http.StatusConflict
+		}
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobID)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, resp)
+}
+
+// CloneDocumentRequest defines what is left out of the cloned document.
+type CloneDocumentRequest = documents.CloneOptions
+
+// CloneDocument creates a new pending document from the latest committed version of the document.
+// @summary Clones a committed document into a new pending document.
+// @description Creates a new pending document with a new ID from the latest committed version of the document.
+// @description Data, attributes, roles, and rules are copied. Collaborators, NFT read access, access tokens, and signed attributes can be dropped.
+// @id clone_document
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param body body v2.CloneDocumentRequest false "Document Clone request"
+// @produce json
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @Failure 403 {object} httputils.HTTPError
+// @success 201 {object} coreapi.DocumentResponse
+// @router /v2/documents/{document_id}/clone [post]
+func (h handler) CloneDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	// options are optional
+	var req CloneDocumentRequest
+	if r.ContentLength != 0 {
+		err = unmarshalBody(r, &req)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			return
+		}
+	}
+
+	doc, err := h.srv.CloneDocument(r.Context(), docID, req)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	setDocumentETag(w, doc)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
+
 func (h handler) getDocumentWithStatus(w http.ResponseWriter, r *http.Request, st documents.Status) {
 	var err error
 	var code int
@@ -219,6 +362,10 @@ func (h handler) getDocumentWithStatus(w http.ResponseWriter, r *http.Request, s
 		return
 	}
 
+	if st == documents.Pending {
+		setDocumentETag(w, doc)
+	}
+
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
@@ -241,6 +388,45 @@ func (h handler) GetPendingDocument(w http.ResponseWriter, r *http.Request) {
 	h.getDocumentWithStatus(w, r, documents.Pending)
 }
 
+// DeletePendingDocument discards the pending document associated with docID.
+// @summary Discards the pending document associated with docID.
+// @description Discards the pending document associated with docID. Committed versions of the document are not affected.
+// @id delete_pending_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 204 {object} nil
+// @router /v2/documents/{document_id}/pending [delete]
+func (h handler) DeletePendingDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	err = h.srv.DeletePendingDocument(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
 // GetCommittedDocument returns the latest committed document associated with docID.
 // @summary Returns the latest committed document associated with docID.
 // @description Returns the latest committed document associated with docID.
@@ -324,9 +510,11 @@ type RemoveCollaboratorsRequest struct {
 // @tags Documents
 // @accept json
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param body body v2.RemoveCollaboratorsRequest true "Remove Collaborators request"
 // @produce json
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 500 {object} httputils.HTTPError
 // @Failure 403 {object} httputils.HTTPError
 // @success 200 {object} coreapi.DocumentResponse
@@ -352,9 +540,10 @@ func (h handler) RemoveCollaborators(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	doc, err := h.srv.RemoveCollaborators(r.Context(), docID, req.Collaborators)
+	ctx, p := withPrecondition(r)
+	doc, err := h.srv.RemoveCollaborators(ctx, docID, req.Collaborators)
 	if err != nil {
-		code = http.StatusBadRequest
+		code = preconditionFailed(err, http.StatusBadRequest)
 		log.Error(err)
 		return
 	}
@@ -366,6 +555,199 @@ func (h handler) RemoveCollaborators(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, resp)
 }
+
+const (
+	// ErrInvalidListFilter is a sentinel error for invalid list query params.
+	ErrInvalidListFilter = errors.Error("invalid list filter")
+)
+
+// DocumentList holds a page of documents and the cursor to the next page.
+type DocumentList struct {
+	Documents []coreapi.DocumentResponse `json:"documents"`
+	// Next is the cursor to the next page. Empty if there are no more documents.
+	Next byteutils.HexBytes `json:"next" swaggertype:"primitive,string"`
+}
+
+// toListFilter converts the query params to documents list filter.
+func toListFilter(r *http.Request) (filter documents.ListFilter, err error) {
+	q := r.URL.Query()
+	filter.Scheme = q.Get("scheme")
+	if st := q.Get("status"); st != "" {
+		filter.Status = documents.Status(strings.ToLower(st))
+		switch filter.Status {
+		case documents.Pending, documents.Committing, documents.Committed:
+		default:
+			return filter, errors.NewTypedError(ErrInvalidListFilter, errors.New("unknown status %s", st))
+		}
+	}
+
+	if author := q.Get("author"); author != "" {
+		did, err := identity.NewDIDFromString(author)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+
+		filter.Author = &did
+	}
+
+	for _, tm := range []struct {
+		key string
+		val *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		str := q.Get(tm.key)
+		if str == "" {
+			continue
+		}
+
+		*tm.val, err = time.Parse(time.RFC3339, str)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		filter.Cursor, err = hexutil.Decode(cursor)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// ListDocuments returns the documents owned by the account.
+// @summary Returns a page of the documents owned by the account.
+// @description Returns a page of the documents owned by the account. Without a status, pending documents are listed along with the committed documents, and a document with both is listed with its pending version. Use status=pending to list only the pending documents.
+// @id list_documents
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param scheme query string false "Document scheme"
+// @param status query string false "Document status: pending, committing or committed"
+// @param author query string false "Author DID of the latest version"
+// @param from query string false "RFC3339 timestamp from which the latest version was created"
+// @param to query string false "RFC3339 timestamp until which the latest version was created"
+// @param cursor query string false "Document ID to start the page from"
+// @param limit query int false "Maximum number of documents in the page"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentList
+// @router /v2/documents [get]
+func (h handler) ListDocuments(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	filter, err := toListFilter(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	docs, next, err := h.srv.ListDocuments(r.Context(), filter)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := DocumentList{Documents: []coreapi.DocumentResponse{}, Next: next}
+	for _, doc := range docs {
+		var dr coreapi.DocumentResponse
+		dr, err = toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+		if err != nil {
+			code = http.StatusInternalServerError
+			log.Error(err)
+			return
+		}
+
+		resp.Documents = append(resp.Documents, dr)
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// Anchor holds the anchor details of a document version.
+type Anchor struct {
+	AnchorID     byteutils.HexBytes `json:"anchor_id" swaggertype:"primitive,string"`
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	Anchored     bool               `json:"anchored"`
+}
+
+// DocumentVersion holds the details of a single version of the document.
+type DocumentVersion struct {
+	VersionID         byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	PreviousVersionID byteutils.HexBytes `json:"previous_version_id" swaggertype:"primitive,string"`
+	Author            string             `json:"author"`
+	CreatedAt         string             `json:"created_at"`
+	Status            string             `json:"status"`
+	Anchor            *Anchor            `json:"anchor,omitempty"`
+}
+
+// DocumentVersions holds a page of document versions and the cursor to the next page.
+type DocumentVersions struct {
+	Versions []DocumentVersion `json:"versions"`
+	// Next is the cursor to the next page. Empty if there are no more versions.
+	Next byteutils.HexBytes `json:"next" swaggertype:"primitive,string"`
+}
+
+// GetDocumentVersions returns the committed versions of the document.
+// @summary Returns the committed versions of the document, latest first.
+// @description Returns the committed versions of the document, latest first, with author, timestamp, status and anchor details.
+// @id get_document_versions
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param cursor query string false "Version ID to start the page from"
+// @param limit query int false "Maximum number of versions in the page"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.DocumentVersions
+// @router /v2/documents/{document_id}/versions [get]
+func (h handler) GetDocumentVersions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	filter, err := toListFilter(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	versions, next, err := h.srv.GetDocumentVersions(r.Context(), docID, filter.Cursor, filter.Limit)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toDocumentVersions(versions, next))
+}
@@ -10,11 +10,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/pending"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -129,10 +131,14 @@ func TestHandler_CreateDocument(t *testing.T) {
 	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
 	doc.On("NFTs").Return(nil).Once()
 	doc.On("GetStatus").Return(documents.Pending).Once()
+	doc.On("JSON").Return([]byte(`{"status":"pending"}`), nil)
 	w, r = getHTTPReqAndResp(ctx, validPayload(t))
 	h.CreateDocument(w, r)
 	assert.Equal(t, w.Code, http.StatusCreated)
 	assert.Contains(t, w.Body.String(), "\"status\":\"pending\"")
+	etag, err := pending.ETag(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, etag, w.Header().Get(ETagHeader))
 	pendingSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
 }
@@ -178,19 +184,27 @@ func TestHandler_UpdateDocument(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "not a valid attribute type")
 
 	// failed to update document
-	pendingSrv.On("Update", ctx, mock.Anything).Return(nil, errors.New("Failed to update document")).Once()
+	pendingSrv.On("Update", mock.Anything, mock.Anything).Return(nil, errors.New("Failed to update document")).Once()
 	w, r = getHTTPReqAndResp(ctx, validPayload(t))
 	h.UpdateDocument(w, r)
 	assert.Equal(t, w.Code, http.StatusBadRequest)
 	assert.Contains(t, w.Body.String(), "Failed to update document")
 
+	// stale etag
+	pendingSrv.On("Update", mock.Anything, mock.Anything).Return(nil, pending.ErrETagMismatch).Once()
+	w, r = getHTTPReqAndResp(ctx, validPayload(t))
+	r.Header.Set(IfMatchHeader, `"stale"`)
+	h.UpdateDocument(w, r)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Contains(t, w.Body.String(), pending.ErrETagMismatch.Error())
+
 	// failed document conversion
 	doc := new(testingdocuments.MockModel)
 	doc.On("GetData").Return(generic.Data{}).Twice()
 	doc.On("Scheme").Return("generic").Twice()
 	doc.On("GetAttributes").Return(nil).Twice()
 	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, errors.New("failed to get collaborators")).Once()
-	pendingSrv.On("Update", ctx, mock.Anything).Return(doc, nil)
+	pendingSrv.On("Update", mock.Anything, mock.Anything).Return(doc, nil)
 	w, r = getHTTPReqAndResp(ctx, validPayload(t))
 	h.UpdateDocument(w, r)
 	assert.Equal(t, w.Code, http.StatusInternalServerError)
@@ -275,6 +289,159 @@ func TestHandler_Commit(t *testing.T) {
 	doc.AssertExpectations(t)
 }
 
+func TestHandler_RetryCommit(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/commit/retry", nil).WithContext(ctx)
+	}
+
+	// invalid hex
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"document_id"}
+	rctx.URLParams.Values = []string{"invalid hex"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.RetryCommit(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	srv := new(pending.MockService)
+	h = handler{srv: Service{pendingDocSrv: srv}}
+	srv.On("RetryCommit", ctx, docID).Return(nil, nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RetryCommit(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// not retryable
+	srv.On("RetryCommit", ctx, docID).Return(nil, nil, documents.ErrCommitNotRetryable).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RetryCommit(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrCommitNotRetryable.Error())
+
+	// failed to create job
+	srv.On("RetryCommit", ctx, docID).Return(nil, nil, errors.New("failed to create job")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RetryCommit(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{}).Once()
+	doc.On("Scheme").Return("generic").Once()
+	doc.On("GetAttributes").Return(nil).Once()
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+	doc.On("ID").Return(docID).Once()
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+	doc.On("Author").Return(nil, errors.New("somerror")).Once()
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+	doc.On("NFTs").Return(nil).Once()
+	doc.On("GetStatus").Return(documents.Committing).Once()
+	jobID := jobs.NewJobID()
+	srv.On("RetryCommit", ctx, docID).Return(doc, jobID, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RetryCommit(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), "\"status\":\"committing\"")
+	assert.Contains(t, w.Body.String(), jobID.String())
+	srv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
+
+func TestHandler_CloneDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/clone", b).WithContext(ctx)
+	}
+
+	// invalid hex
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"document_id"}
+	rctx.URLParams.Values = []string{"invalid hex"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.CloneDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.CloneDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing document
+	srv := new(pending.MockService)
+	h = handler{srv: Service{pendingDocSrv: srv}}
+	srv.On("Clone", ctx, docID, documents.CloneOptions{}).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.CloneDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	opts := documents.CloneOptions{DropCollaborators: true, DropSignedAttributes: true}
+	d, err := json.Marshal(opts)
+	assert.NoError(t, err)
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+	doc.On("ID").Return(utils.RandomSlice(32)).Once()
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+	doc.On("Author").Return(nil, errors.New("somerror")).Once()
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+	doc.On("NFTs").Return(nil).Once()
+	doc.On("GetStatus").Return(documents.Pending).Once()
+	doc.On("JSON").Return([]byte(`{"status":"pending"}`), nil)
+	srv.On("Clone", ctx, docID, opts).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CloneDocument(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEmpty(t, w.Header().Get(ETagHeader))
+	assert.Contains(t, w.Body.String(), "\"status\":\"pending\"")
+	srv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
+
+func TestHandler_DeletePendingDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("DELETE", "/documents/{document_id}/pending", nil).WithContext(ctx)
+	}
+
+	// invalid hex
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{"document_id"}
+	rctx.URLParams.Values = []string{"invalid hex"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.DeletePendingDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	srv := new(pending.MockService)
+	h = handler{srv: Service{pendingDocSrv: srv}}
+	srv.On("Delete", ctx, docID).Return(documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeletePendingDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	srv.On("Delete", ctx, docID).Return(nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.DeletePendingDocument(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	srv.AssertExpectations(t)
+}
+
 func TestHandler_GetDocument(t *testing.T) {
 	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
 		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/pending", b).WithContext(ctx)
@@ -324,12 +491,17 @@ func TestHandler_GetDocument(t *testing.T) {
 	doc.On("Timestamp").Return(nil, errors.New("somerror")).Twice()
 	doc.On("NFTs").Return(nil).Twice()
 	doc.On("GetStatus").Return(documents.Pending).Twice()
+	doc.On("JSON").Return([]byte(`{"status":"pending"}`), nil)
 	w, r = getHTTPReqAndResp(ctx, nil)
 	h.GetPendingDocument(w, r)
 	assert.Equal(t, http.StatusOK, w.Code)
+	etag, err := pending.ETag(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, etag, w.Header().Get(ETagHeader))
 	w, r = getHTTPReqAndResp(ctx, nil)
 	h.GetCommittedDocument(w, r)
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(ETagHeader))
 	pendingSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
 }
@@ -438,7 +610,7 @@ func TestHandler_RemoveCollaborators(t *testing.T) {
 	}
 	d, err := json.Marshal(req)
 	assert.NoError(t, err)
-	pendingSrv.On("RemoveCollaborators", ctx, docID, mock.Anything).Return(nil, errors.New("failed to delete collaborators")).Once()
+	pendingSrv.On("RemoveCollaborators", mock.Anything, docID, mock.Anything).Return(nil, errors.New("failed to delete collaborators")).Once()
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
 	h.RemoveCollaborators(w, r)
 	assert.Equal(t, w.Code, http.StatusBadRequest)
@@ -450,7 +622,7 @@ func TestHandler_RemoveCollaborators(t *testing.T) {
 	doc.On("Scheme").Return("generic").Twice()
 	doc.On("GetAttributes").Return(nil).Twice()
 	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, errors.New("failed to get collaborators")).Once()
-	pendingSrv.On("RemoveCollaborators", ctx, docID, mock.Anything).Return(doc, nil)
+	pendingSrv.On("RemoveCollaborators", mock.Anything, docID, mock.Anything).Return(doc, nil)
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
 	h.RemoveCollaborators(w, r)
 	assert.Equal(t, w.Code, http.StatusInternalServerError)
@@ -470,3 +642,129 @@ func TestHandler_RemoveCollaborators(t *testing.T) {
 	doc.AssertExpectations(t)
 	pendingSrv.AssertExpectations(t)
 }
+
+func TestHandler_ListDocuments(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid filters
+	ctx := context.Background()
+	h := handler{}
+	for _, q := range []string{"status=invalid", "author=0x1234", "from=yesterday", "cursor=invalid", "limit=ten"} {
+		w, r := getHTTPReqAndResp(ctx, q)
+		h.ListDocuments(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidListFilter.Error())
+	}
+
+	// failed to list
+	author := testingidentity.GenerateRandomDID()
+	cursor := utils.RandomSlice(32)
+	filter := documents.ListFilter{
+		Scheme: "generic",
+		Status: documents.Committed,
+		Author: &author,
+		Cursor: cursor,
+		Limit:  10,
+	}
+	query := "scheme=generic&status=Committed&author=" + author.String() + "&cursor=" + hexutil.Encode(cursor) + "&limit=10"
+	pendingSrv := new(pending.MockService)
+	pendingSrv.On("List", ctx, filter).Return(nil, nil, errors.New("failed to list")).Once()
+	h.srv.pendingDocSrv = pendingSrv
+	w, r := getHTTPReqAndResp(ctx, query)
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to list")
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil)
+	doc.On("ID").Return(utils.RandomSlice(32))
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32))
+	doc.On("Author").Return(nil, errors.New("somerror"))
+	doc.On("Timestamp").Return(nil, errors.New("somerror"))
+	doc.On("NFTs").Return(nil)
+	doc.On("GetStatus").Return(documents.Committed)
+	next := utils.RandomSlice(32)
+	pendingSrv.On("List", ctx, filter).Return([]documents.Model{doc, doc}, next, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, query)
+	h.ListDocuments(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DocumentList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Documents, 2)
+	assert.Equal(t, next, resp.Next.Bytes())
+	pendingSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetDocumentVersions(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/versions?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid document id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = make([]string, 1, 1)
+	rctx.URLParams.Values = make([]string, 1, 1)
+	rctx.URLParams.Keys[0] = "document_id"
+	rctx.URLParams.Values[0] = "invalid"
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, "")
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid cursor
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, "cursor=invalid")
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidListFilter.Error())
+
+	// missing document
+	pendingSrv := new(pending.MockService)
+	pendingSrv.On("GetVersions", ctx, docID, []byte(nil), 0).Return(nil, nil, errors.New("missing")).Once()
+	h.srv.pendingDocSrv = pendingSrv
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// success
+	author := testingidentity.GenerateRandomDID()
+	cursor := utils.RandomSlice(32)
+	next := utils.RandomSlice(32)
+	versions := []documents.VersionInfo{
+		{
+			VersionID: cursor,
+			Author:    author,
+			Timestamp: time.Now(),
+			Status:    documents.Committing,
+		},
+		{
+			VersionID:    next,
+			Status:       documents.Committed,
+			AnchorID:     utils.RandomSlice(32),
+			DocumentRoot: utils.RandomSlice(32),
+			Anchored:     true,
+		},
+	}
+	pendingSrv.On("GetVersions", ctx, docID, cursor, 2).Return(versions, next, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "cursor="+hexutil.Encode(cursor)+"&limit=2")
+	h.GetDocumentVersions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DocumentVersions
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Versions, 2)
+	assert.Equal(t, author.String(), resp.Versions[0].Author)
+	assert.Nil(t, resp.Versions[0].Anchor)
+	assert.True(t, resp.Versions[1].Anchor.Anchored)
+	assert.Equal(t, next, resp.Next.Bytes())
+	pendingSrv.AssertExpectations(t)
+}
@@ -0,0 +1,54 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/pending"
+)
+
+const (
+	// ETagHeader is the response header carrying the ETag of the pending document.
+	ETagHeader = "ETag"
+
+	// IfMatchHeader is the request header carrying the expected ETag of the pending document.
+	IfMatchHeader = "If-Match"
+)
+
+// withPrecondition returns the request context carrying the If-Match precondition of the request.
+// ETag of the pending document is set on the precondition once the update succeeds.
+func withPrecondition(r *http.Request) (context.Context, *pending.Precondition) {
+	p := &pending.Precondition{IfMatch: r.Header.Get(IfMatchHeader)}
+	return pending.WithPrecondition(r.Context(), p), p
+}
+
+// setETag sets the ETag header if the etag is not empty.
+func setETag(w http.ResponseWriter, etag string) {
+	if etag == "" {
+		return
+	}
+
+	w.Header().Set(ETagHeader, etag)
+}
+
+// setDocumentETag sets the ETag header derived from the pending document.
+func setDocumentETag(w http.ResponseWriter, doc documents.Model) {
+	etag, err := pending.ETag(doc)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	setETag(w, etag)
+}
+
+// preconditionFailed returns http.StatusPreconditionFailed if the pending document was modified since the If-Match ETag, else code.
+func preconditionFailed(err error, code int) int {
+	if errors.IsOfType(pending.ErrETagMismatch, err) {
+		return http.StatusPreconditionFailed
+	}
+
+	return code
+}
@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrStreamingUnsupported is a sentinel error used when the response cannot be streamed to the client.
+const ErrStreamingUnsupported = errors.Error("streaming not supported")
+
+// eventKeepAlive is the interval of the comments sent to keep the idle event stream open.
+var eventKeepAlive = 15 * time.Second
+
+// writeEvent writes the document event in the Server-Sent Events format.
+func writeEvent(w http.ResponseWriter, id uint64, event notification.DocumentEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event.Type, data)
+	return err
+}
+
+// StreamDocumentEvents streams the changes of the documents owned by the account as Server-Sent Events.
+// @summary Streams the document events of the account.
+// @description Streams the events of the documents owned by the account as Server-Sent Events until the client disconnects.
+// @description Events are sent for new pending versions, committed versions, received versions, signatures, and NFTs.
+// @description Events of all the documents of the account are streamed if no document_id is provided.
+// @id stream_document_events
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id query []string false "Document Identifiers to subscribe to" collectionFormat(multi)
+// @produce text/event-stream
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} notification.DocumentEvent
+// @router /v2/events [get]
+func (h handler) StreamDocumentEvents(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docIDs := make([][]byte, len(r.URL.Query()["document_id"]))
+	for i, v := range r.URL.Query()["document_id"] {
+		docIDs[i], err = hexutil.Decode(v)
+		if err != nil {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = coreapi.ErrInvalidDocumentID
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = ErrStreamingUnsupported
+		log.Error(err)
+		return
+	}
+
+	sub, err := h.srv.SubscribeDocumentEvents(r.Context(), docIDs)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventKeepAlive)
+	defer ticker.Stop()
+	var id uint64
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, werr := fmt.Fprint(w, ": keep-alive\n\n"); werr != nil {
+				return
+			}
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			id++
+			if werr := writeEvent(w, id, event); werr != nil {
+				log.Error(werr)
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
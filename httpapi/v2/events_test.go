@@ -0,0 +1,95 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventsAccount struct {
+	config.Account
+	did identity.DID
+}
+
+func (a eventsAccount) GetIdentityID() []byte {
+	return a.did[:]
+}
+
+// flushRecorder signals every flush of the event stream.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed chan struct{}
+}
+
+func (f flushRecorder) Flush() {
+	f.ResponseRecorder.Flush()
+	f.flushed <- struct{}{}
+}
+
+func TestHandler_StreamDocumentEvents(t *testing.T) {
+	// invalid document id
+	h := handler{srv: Service{eventBus: notification.NewEventBus()}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/events?document_id=invalid", nil)
+	h.StreamDocumentEvents(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing account
+	docID := utils.RandomSlice(32)
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest("GET", "/events?document_id="+hexutil.Encode(docID), nil)
+	h.StreamDocumentEvents(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), contextutil.ErrDIDMissingFromContext.Error())
+
+	// success
+	did := testingidentity.GenerateRandomDID()
+	ctx, err := contextutil.New(context.Background(), eventsAccount{did: did})
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(ctx)
+	fw := flushRecorder{ResponseRecorder: httptest.NewRecorder(), flushed: make(chan struct{})}
+	r = httptest.NewRequest("GET", "/events?document_id="+hexutil.Encode(docID), nil).WithContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		h.StreamDocumentEvents(fw, r)
+		close(done)
+	}()
+
+	// subscribed once the headers are flushed
+	<-fw.flushed
+	versionID := utils.RandomSlice(32)
+	h.srv.eventBus.Publish(notification.DocumentEvent{
+		Type:       notification.PendingVersion,
+		AccountID:  testingidentity.GenerateRandomDID(),
+		DocumentID: docID,
+	})
+	h.srv.eventBus.Publish(notification.DocumentEvent{
+		Type:       notification.VersionCommitted,
+		AccountID:  did,
+		DocumentID: docID,
+		VersionID:  versionID,
+	})
+	<-fw.flushed
+	cancel()
+	<-done
+	assert.Equal(t, http.StatusOK, fw.Code)
+	assert.Equal(t, "text/event-stream", fw.Header().Get("Content-Type"))
+	body := fw.Body.String()
+	assert.Contains(t, body, "id: 1\nevent: committed\n")
+	assert.Contains(t, body, hexutil.Encode(versionID))
+	assert.NotContains(t, body, "pending_version")
+}
@@ -0,0 +1,145 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// Fork is a local version of the document that lost against the version anchored by another collaborator.
+type Fork struct {
+	DocumentID           byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID            byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	PreviousVersion      byteutils.HexBytes `json:"previous_version" swaggertype:"primitive,string"`
+	Scheme               string             `json:"scheme"`
+	LocalSigningRoot     byteutils.HexBytes `json:"local_signing_root" swaggertype:"primitive,string"`
+	AnchoredDocumentRoot byteutils.HexBytes `json:"anchored_document_root" swaggertype:"primitive,string"`
+	DetectedAt           time.Time          `json:"detected_at" swaggertype:"primitive,string"`
+}
+
+// ForksResponse holds the forked versions of the document.
+type ForksResponse struct {
+	Forks []Fork `json:"forks"`
+}
+
+func toForksResponse(forks []*documents.Fork) ForksResponse {
+	resp := ForksResponse{Forks: []Fork{}}
+	for _, f := range forks {
+		resp.Forks = append(resp.Forks, Fork{
+			DocumentID:           f.DocumentID,
+			VersionID:            f.VersionID,
+			PreviousVersion:      f.PreviousVersion,
+			Scheme:               f.Scheme,
+			LocalSigningRoot:     f.LocalSigningRoot,
+			AnchoredDocumentRoot: f.AnchoredDocumentRoot,
+			DetectedAt:           f.DetectedAt,
+		})
+	}
+
+	return resp
+}
+
+// GetDocumentForks returns the local versions of the document that lost against versions anchored by other collaborators.
+// @summary Returns the forked versions of the document.
+// @description Returns the local versions of the document that lost against versions, with the same version ID, anchored by other collaborators.
+// @id get_document_forks
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} v2.ForksResponse
+// @router /v2/documents/{document_id}/forks [get]
+func (h handler) GetDocumentForks(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	forks, err := h.srv.GetDocumentForks(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toForksResponse(forks))
+}
+
+// RebaseDocumentFork creates a pending document from the latest committed version with the changes of the forked version.
+// @summary Rebases the changes of the forked version onto the latest committed version.
+// @description Creates a pending document from the latest committed version of the document with the attributes, collaborators, and data
+// @description changed in the forked version since its previous version. Signed attributes, roles, rules, and NFTs are not rebased.
+// @id rebase_document_fork
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @param version_id path string true "Forked Version Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 201 {object} coreapi.DocumentResponse
+// @router /v2/documents/{document_id}/forks/{version_id}/rebase [post]
+func (h handler) RebaseDocumentFork(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	versionID, err := hexutil.Decode(chi.URLParam(r, coreapi.VersionIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidVersionID
+		return
+	}
+
+	doc, err := h.srv.RebaseDocumentFork(r.Context(), docID, versionID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrForkNotFound, err) || errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	resp, err := toDocumentResponse(doc, h.srv.tokenRegistry, jobs.NilJobID())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	setDocumentETag(w, doc)
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+}
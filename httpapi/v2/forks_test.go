@@ -0,0 +1,137 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_GetDocumentForks(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/forks", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.GetDocumentForks(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// failed to get forks
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	srv := new(pending.MockService)
+	srv.On("GetForks", ctx, docID).Return(nil, errors.New("failed")).Once()
+	h.srv = Service{pendingDocSrv: srv}
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentForks(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	fork := &documents.Fork{
+		DocumentID:           docID,
+		VersionID:            utils.RandomSlice(32),
+		PreviousVersion:      utils.RandomSlice(32),
+		Scheme:               "generic",
+		LocalSigningRoot:     utils.RandomSlice(32),
+		AnchoredDocumentRoot: utils.RandomSlice(32),
+		DetectedAt:           time.Now().UTC(),
+		LocalDocument:        utils.RandomSlice(64),
+	}
+	srv.On("GetForks", ctx, docID).Return([]*documents.Fork{fork}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetDocumentForks(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "local_document")
+	var resp ForksResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Forks, 1)
+	assert.Equal(t, fork.VersionID, resp.Forks[0].VersionID)
+	assert.Equal(t, fork.AnchoredDocumentRoot, resp.Forks[0].AnchoredDocumentRoot)
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_RebaseDocumentFork(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/forks/{version_id}/rebase", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam, coreapi.VersionIDParam}
+	rctx.URLParams.Values = []string{"some invalid id", "some invalid version"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.RebaseDocumentFork(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid version id
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx)
+	h.RebaseDocumentFork(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidVersionID.Error())
+
+	// missing fork
+	versionID := utils.RandomSlice(32)
+	rctx.URLParams.Values[1] = hexutil.Encode(versionID)
+	srv := new(pending.MockService)
+	srv.On("Rebase", ctx, docID, versionID).Return(nil, documents.ErrForkNotFound).Once()
+	h.srv = Service{pendingDocSrv: srv}
+	w, r = getHTTPReqAndResp(ctx)
+	h.RebaseDocumentFork(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// pending document exists
+	srv.On("Rebase", ctx, docID, versionID).Return(nil, pending.ErrPendingDocumentExists).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RebaseDocumentFork(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	doc := new(testingdocuments.MockModel)
+	doc.On("GetData").Return(generic.Data{})
+	doc.On("Scheme").Return("generic")
+	doc.On("GetAttributes").Return(nil)
+	doc.On("GetCollaborators", mock.Anything).Return(documents.CollaboratorsAccess{}, nil).Once()
+	doc.On("ID").Return(docID).Once()
+	doc.On("CurrentVersion").Return(utils.RandomSlice(32)).Once()
+	doc.On("Author").Return(nil, errors.New("somerror")).Once()
+	doc.On("Timestamp").Return(nil, errors.New("somerror")).Once()
+	doc.On("NFTs").Return(nil).Once()
+	doc.On("GetStatus").Return(documents.Pending).Once()
+	doc.On("JSON").Return([]byte(`{"status":"pending"}`), nil)
+	srv.On("Rebase", ctx, docID, versionID).Return(doc, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.RebaseDocumentFork(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEmpty(t, w.Header().Get(ETagHeader))
+	assert.Contains(t, w.Body.String(), "\"status\":\"pending\"")
+	srv.AssertExpectations(t)
+	doc.AssertExpectations(t)
+}
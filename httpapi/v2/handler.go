@@ -19,17 +19,55 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	h := handler{srv: srv}
 
 	r.Post("/documents", h.CreateDocument)
+	r.Get("/documents", h.ListDocuments)
+	r.Post("/documents/commit", h.CommitDocuments)
+	r.Get("/documents/commit/{"+JobIDParam+"}", h.GetBatchCommitStatus)
+	r.Post("/documents/bundles", h.ImportDocumentBundle)
 	r.Patch("/documents/{"+coreapi.DocumentIDParam+"}", h.UpdateDocument)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/commit", h.Commit)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/commit/retry", h.RetryCommit)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/clone", h.CloneDocument)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/validate", h.ValidateDocument)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/pending", h.GetPendingDocument)
+	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/pending", h.DeletePendingDocument)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/committed", h.GetCommittedDocument)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions", h.GetDocumentVersions)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}", h.GetDocumentVersion)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/versions/{"+coreapi.VersionIDParam+"}/bundle", h.ExportDocumentBundle)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/diff", h.GetDocumentDiff)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/forks", h.GetDocumentForks)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/forks/{"+coreapi.VersionIDParam+"}/rebase", h.RebaseDocumentFork)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/signed_attribute", h.AddSignedAttribute)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/collaborators", h.RemoveCollaborators)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/attachments", h.AddAttachment)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/attachments/{"+AttachmentHashParam+"}", h.GetAttachment)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/roles/{"+RoleIDParam+"}", h.GetRole)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/roles", h.AddRole)
 	r.Patch("/documents/{"+coreapi.DocumentIDParam+"}/roles/{"+RoleIDParam+"}", h.UpdateRole)
+	r.Put("/documents/{"+coreapi.DocumentIDParam+"}/roles/{"+RoleIDParam+"}/validity", h.SetRoleValidity)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/access_tokens", h.GrantAccessToken)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/access_tokens", h.ListAccessTokens)
+	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/access_tokens/{"+TokenIDParam+"}", h.RevokeAccessToken)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/access_token_requests", h.GetDocumentWithAccessToken)
+	r.Put("/documents/{"+coreapi.DocumentIDParam+"}/approval_policy", h.SetApprovalPolicy)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/approvals", h.GetApprovalStatus)
+	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/approvals", h.Approve)
+	r.Put("/documents/{"+coreapi.DocumentIDParam+"}/signature_policy", h.SetSignaturePolicy)
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules", h.AddTransitionRules)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.GetTransitionRule)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.DeleteTransitionRule)
+	r.Get("/approvals", h.ListPendingApprovals)
+	r.Get("/events", h.StreamDocumentEvents)
+	r.Get("/inbox", h.ListInbox)
+	r.Post("/inbox/{"+InboxItemIDParam+"}/read", h.MarkInboxItemRead)
+	r.Post("/inbox/{"+InboxItemIDParam+"}/ack", h.AckInboxItem)
+	r.Post("/proofs/verify", h.VerifyProof)
+	r.Post("/schemas", h.CreateSchema)
+	r.Get("/schemas", h.ListSchemas)
+	r.Get("/schemas/{"+SchemaNameParam+"}", h.GetSchema)
+	r.Get("/schemas/{"+SchemaNameParam+"}/versions", h.GetSchemaVersions)
+	r.Post("/templates", h.CreateTemplate)
+	r.Get("/templates", h.ListTemplates)
+	r.Get("/templates/{"+TemplateNameParam+"}", h.GetTemplate)
+	r.Delete("/templates/{"+TemplateNameParam+"}", h.DeleteTemplate)
 }
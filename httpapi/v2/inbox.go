@@ -0,0 +1,230 @@
+package v2
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// InboxItemIDParam is the key for the inbox item ID in the API path.
+const InboxItemIDParam = "inbox_item_id"
+
+// ErrInvalidInboxItemID for invalid inbox item ID in the api path.
+const ErrInvalidInboxItemID = errors.Error("Invalid Inbox Item ID")
+
+// InboxList holds a page of inbox items and the cursor to the next page.
+type InboxList struct {
+	Items []*documents.InboxItem `json:"items"`
+	// Next is the cursor to the next page. Empty if there are no more items.
+	Next byteutils.HexBytes `json:"next" swaggertype:"primitive,string"`
+}
+
+// toInboxFilter converts the query params to inbox filter.
+func toInboxFilter(r *http.Request) (filter documents.InboxFilter, err error) {
+	q := r.URL.Query()
+	filter.Scheme = q.Get("scheme")
+	if sender := q.Get("sender"); sender != "" {
+		did, err := identity.NewDIDFromString(sender)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+
+		filter.Sender = &did
+	}
+
+	for _, b := range []struct {
+		key string
+		val **bool
+	}{{"read", &filter.Read}, {"acknowledged", &filter.Acknowledged}} {
+		str := q.Get(b.key)
+		if str == "" {
+			continue
+		}
+
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+
+		*b.val = &v
+	}
+
+	for _, tm := range []struct {
+		key string
+		val *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		str := q.Get(tm.key)
+		if str == "" {
+			continue
+		}
+
+		*tm.val, err = time.Parse(time.RFC3339, str)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	for _, h := range []struct {
+		key string
+		val *[]byte
+	}{{"document_id", &filter.DocumentID}, {"cursor", &filter.Cursor}} {
+		str := q.Get(h.key)
+		if str == "" {
+			continue
+		}
+
+		*h.val, err = hexutil.Decode(str)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	if limit := q.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return filter, errors.NewTypedError(ErrInvalidListFilter, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// ListInbox returns the versions received from the collaborators.
+// @summary Returns a page of the versions received from the collaborators.
+// @description Returns a page of the anchored versions received from the collaborators, oldest first.
+// @description Listing doesn't mark the items as read. Items remain in the inbox once acknowledged.
+// @id list_inbox
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param scheme query string false "Document scheme"
+// @param sender query string false "Sender DID of the received version"
+// @param document_id query string false "Document Identifier"
+// @param read query bool false "Read flag of the items"
+// @param acknowledged query bool false "Acknowledged flag of the items"
+// @param from query string false "RFC3339 timestamp from which the versions were received"
+// @param to query string false "RFC3339 timestamp until which the versions were received"
+// @param cursor query string false "Inbox item ID to start the page from"
+// @param limit query int false "Maximum number of items in the page"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} v2.InboxList
+// @router /v2/inbox [get]
+func (h handler) ListInbox(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	filter, err := toInboxFilter(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	items, next, err := h.srv.ListInbox(r.Context(), filter)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := InboxList{Items: items, Next: next}
+	if resp.Items == nil {
+		resp.Items = []*documents.InboxItem{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// MarkInboxItemRead marks the inbox item as read.
+// @summary Marks the received version as read.
+// @description Marks the inbox item as read. Marking an item again is a no-op.
+// @id mark_inbox_item_read
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param inbox_item_id path string true "Inbox Item Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} documents.InboxItem
+// @router /v2/inbox/{inbox_item_id}/read [post]
+func (h handler) MarkInboxItemRead(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	id, err := hexutil.Decode(chi.URLParam(r, InboxItemIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidInboxItemID
+		return
+	}
+
+	item, err := h.srv.MarkInboxItemRead(r.Context(), id)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrInboxItemNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, item)
+}
+
+// AckInboxItem marks the inbox item as acknowledged.
+// @summary Acknowledges the received version.
+// @description Marks the inbox item as read and acknowledged. Acknowledging an item again is a no-op.
+// @id ack_inbox_item
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param inbox_item_id path string true "Inbox Item Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} documents.InboxItem
+// @router /v2/inbox/{inbox_item_id}/ack [post]
+func (h handler) AckInboxItem(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	id, err := hexutil.Decode(chi.URLParam(r, InboxItemIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidInboxItemID
+		return
+	}
+
+	item, err := h.srv.AckInboxItem(r.Context(), id)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrInboxItemNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, item)
+}
@@ -0,0 +1,151 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListInbox(t *testing.T) {
+	getHTTPReqAndResp := func(query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/inbox"+query, nil)
+	}
+
+	// invalid filters
+	h := handler{}
+	for _, q := range []string{"?sender=invalid", "?read=maybe", "?from=yesterday", "?document_id=invalid", "?limit=ten"} {
+		w, r := getHTTPReqAndResp(q)
+		h.ListInbox(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidListFilter.Error())
+	}
+
+	// failed to list
+	srv := new(pending.MockService)
+	h.srv = Service{pendingDocSrv: srv}
+	srv.On("ListInbox", mock.Anything, documents.InboxFilter{}).Return(nil, nil, errors.New("failed")).Once()
+	w, r := getHTTPReqAndResp("")
+	h.ListInbox(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// empty inbox
+	srv.On("ListInbox", mock.Anything, documents.InboxFilter{}).Return(nil, nil, nil).Once()
+	w, r = getHTTPReqAndResp("")
+	h.ListInbox(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"items\":[]")
+
+	// success
+	sender := testingidentity.GenerateRandomDID()
+	docID, next := utils.RandomSlice(32), utils.RandomSlice(40)
+	unacked := false
+	filter := documents.InboxFilter{Sender: &sender, DocumentID: docID, Acknowledged: &unacked, Limit: 5}
+	item := &documents.InboxItem{
+		ID:         utils.RandomSlice(40),
+		DocumentID: docID,
+		VersionID:  utils.RandomSlice(32),
+		Sender:     sender,
+		Scheme:     "generic",
+		ReceivedAt: time.Now().UTC(),
+		Read:       true,
+	}
+	srv.On("ListInbox", mock.Anything, filter).Return([]*documents.InboxItem{item}, next, nil).Once()
+	w, r = getHTTPReqAndResp("?sender=" + sender.String() + "&document_id=" + hexutil.Encode(docID) + "&acknowledged=false&limit=5")
+	h.ListInbox(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp InboxList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, item.ID, resp.Items[0].ID)
+	assert.Equal(t, sender, resp.Items[0].Sender)
+	assert.Equal(t, next, []byte(resp.Next))
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_AckInboxItem(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/inbox/{inbox_item_id}/ack", nil).WithContext(ctx)
+	}
+
+	// invalid id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{InboxItemIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.AckInboxItem(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidInboxItemID.Error())
+
+	// missing item
+	id := utils.RandomSlice(40)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	srv := new(pending.MockService)
+	h.srv = Service{pendingDocSrv: srv}
+	srv.On("AckInboxItem", ctx, id).Return(nil, documents.ErrInboxItemNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.AckInboxItem(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	now := time.Now().UTC()
+	item := &documents.InboxItem{ID: id, Read: true, Acknowledged: true, AcknowledgedAt: &now}
+	srv.On("AckInboxItem", ctx, id).Return(item, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.AckInboxItem(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"acknowledged\":true")
+	srv.AssertExpectations(t)
+}
+
+func TestHandler_MarkInboxItemRead(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/inbox/{inbox_item_id}/read", nil).WithContext(ctx)
+	}
+
+	// invalid id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{InboxItemIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.MarkInboxItemRead(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidInboxItemID.Error())
+
+	// missing item
+	id := utils.RandomSlice(40)
+	rctx.URLParams.Values[0] = hexutil.Encode(id)
+	srv := new(pending.MockService)
+	h.srv = Service{pendingDocSrv: srv}
+	srv.On("MarkInboxItemRead", ctx, id).Return(nil, documents.ErrInboxItemNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.MarkInboxItemRead(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	item := &documents.InboxItem{ID: id, Read: true}
+	srv.On("MarkInboxItemRead", ctx, id).Return(item, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.MarkInboxItemRead(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\"read\":true")
+	srv.AssertExpectations(t)
+}
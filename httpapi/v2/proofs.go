@@ -0,0 +1,111 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/render"
+)
+
+// ProofSignature is a signature of the signing root by a collaborator.
+type ProofSignature struct {
+	SignerID            identity.DID       `json:"signer_id" swaggertype:"primitive,string"`
+	PublicKey           byteutils.HexBytes `json:"public_key" swaggertype:"primitive,string"`
+	Signature           byteutils.HexBytes `json:"signature" swaggertype:"primitive,string"`
+	TransitionValidated bool               `json:"transition_validated"`
+}
+
+// VerifyProofRequest holds the field proofs of a document version and the roots required to recompute its document root.
+// Signatures are optional and are validated against the keys of the signer identities at the anchor time of the version.
+type VerifyProofRequest struct {
+	DocumentID     byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID      byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	FieldProofs    []documents.Proof  `json:"field_proofs"`
+	LeftDataRoot   byteutils.HexBytes `json:"left_data_root" swaggertype:"primitive,string"`
+	RightDataRoot  byteutils.HexBytes `json:"right_data_root" swaggertype:"primitive,string"`
+	SigningRoot    byteutils.HexBytes `json:"signing_root" swaggertype:"primitive,string"`
+	SignaturesRoot byteutils.HexBytes `json:"signatures_root" swaggertype:"primitive,string"`
+	Signatures     []ProofSignature   `json:"signatures"`
+}
+
+// FieldVerdict is the result of the validation of a field proof.
+type FieldVerdict struct {
+	Property byteutils.HexBytes `json:"property" swaggertype:"primitive,string"`
+	Valid    bool               `json:"valid"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// SignatureVerdict is the result of the validation of a signature.
+type SignatureVerdict struct {
+	SignerID identity.DID `json:"signer_id" swaggertype:"primitive,string"`
+	Valid    bool         `json:"valid"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// VerifyProofResponse holds the recomputed document root and the verdicts of the field proofs, anchor and signatures.
+// Valid is true only if the field proofs and the anchor are valid. Signatures provided are not proven to be part of
+// the anchored document, so their verdicts don't affect Valid.
+type VerifyProofResponse struct {
+	DocumentRoot byteutils.HexBytes `json:"document_root" swaggertype:"primitive,string"`
+	Valid        bool               `json:"valid"`
+	Anchored     bool               `json:"anchored"`
+	AnchorError  string             `json:"anchor_error,omitempty"`
+	Fields       []FieldVerdict     `json:"fields"`
+	Signatures   []SignatureVerdict `json:"signatures"`
+
+	// KeysValidatedAt is the time the signer keys are validated at.
+	// Anchor time of the version, or the time of the verification if the version is not anchored.
+	KeysValidatedAt time.Time `json:"keys_validated_at" swaggertype:"primitive,string"`
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// VerifyProof verifies the field proofs of a document version received from another party.
+// @summary Verifies the field proofs of a document version.
+// @description Recomputes the document root from the roots, validates each field proof against the root of its tree,
+// @description and checks the document root against the anchor on chain. Signatures of the signing root are validated
+// @description against the keys of the signer identities at the anchor time if provided.
+// @description Signatures are not proven to be part of the anchored document and don't affect the overall verdict.
+// @id verify_proof
+// @tags Proofs
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.VerifyProofRequest true "Verify Proof Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} v2.VerifyProofResponse
+// @router /v2/proofs/verify [post]
+func (h handler) VerifyProof(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req VerifyProofRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := h.srv.VerifyProof(req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,145 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/documenttypes"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/verifier"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func verifyProofRequest(t *testing.T) (VerifyProofRequest, []byte) {
+	cd, err := documents.NewCoreDocument([]byte{1, 0, 0, 0}, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	cd.GetTestCoreDocWithReset().EmbeddedData = &any.Any{TypeUrl: documenttypes.InvoiceDataTypeUrl, Value: []byte{}}
+	tree, err := cd.DefaultTreeWithPrefix("prefix", []byte{1, 0, 0, 0})
+	assert.NoError(t, err)
+	assert.NoError(t, tree.AddLeaf(proofs.LeafNode{
+		Hash:     utils.RandomSlice(32),
+		Hashed:   true,
+		Property: documents.NewLeafProperty("prefix.sample_field", []byte{1, 0, 0, 0, 0, 0, 0, 200}),
+	}))
+	assert.NoError(t, tree.Generate())
+	dp, err := cd.CreateProofs(
+		documenttypes.InvoiceDataTypeUrl, tree.GetLeaves(), []string{"prefix.sample_field", documents.CDTreePrefix + ".next_version"})
+	assert.NoError(t, err)
+	docRoot, err := cd.CalculateDocumentRoot(documenttypes.InvoiceDataTypeUrl, tree.GetLeaves())
+	assert.NoError(t, err)
+	return VerifyProofRequest{
+		DocumentID:     cd.ID(),
+		VersionID:      cd.CurrentVersion(),
+		FieldProofs:    documents.ConvertProofs(dp.FieldProofs),
+		LeftDataRoot:   dp.LeftDataRooot,
+		RightDataRoot:  dp.RightDataRoot,
+		SigningRoot:    dp.SigningRoot,
+		SignaturesRoot: dp.SignaturesRoot,
+	}, docRoot
+}
+
+func TestHandler_VerifyProof(t *testing.T) {
+	getHTTPReqAndResp := func(b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/proofs/verify", b).WithContext(context.Background())
+	}
+
+	// invalid body
+	anchorSrv := new(testinganchors.MockAnchorService)
+	idService := new(testingcommons.MockIdentityService)
+	h := handler{srv: Service{anchorSrv: anchorSrv, idService: idService}}
+	w, r := getHTTPReqAndResp(bytes.NewReader([]byte("invalid")))
+	h.VerifyProof(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid roots
+	req, docRoot := verifyProofRequest(t)
+	signingRoot := req.SigningRoot
+	req.SigningRoot = utils.RandomSlice(32)
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(bytes.NewReader(d))
+	h.VerifyProof(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), verifier.ErrInvalidRoots.Error())
+
+	// valid fields and signature, not anchored
+	req.SigningRoot = signingRoot
+	signer := testingidentity.GenerateRandomDID()
+	req.Signatures = []ProofSignature{{SignerID: signer, PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(65)}}
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("anchor missing")).Once()
+	idService.On("ValidateSignature", signer, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	d, err = json.Marshal(req)
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(bytes.NewReader(d))
+	h.VerifyProof(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp VerifyProofResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Valid)
+	assert.False(t, resp.Anchored)
+	assert.NotEmpty(t, resp.AnchorError)
+	assert.Len(t, resp.Fields, 2)
+	assert.True(t, resp.Fields[0].Valid)
+	assert.True(t, resp.Fields[1].Valid)
+	assert.Len(t, resp.Signatures, 1)
+	assert.True(t, resp.Signatures[0].Valid)
+
+	// anchored, tampered field
+	req.Signatures = nil
+	req.FieldProofs[0].Hash = utils.RandomSlice(32)
+	dr, err := anchors.ToDocumentRoot(docRoot)
+	assert.NoError(t, err)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(dr, nil).Once()
+	d, err = json.Marshal(req)
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(bytes.NewReader(d))
+	h.VerifyProof(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp = VerifyProofResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Valid)
+	assert.True(t, resp.Anchored)
+	assert.Equal(t, docRoot, resp.DocumentRoot.Bytes())
+	assert.False(t, resp.Fields[0].Valid)
+	assert.NotEmpty(t, resp.Fields[0].Error)
+	assert.True(t, resp.Fields[1].Valid)
+
+	// anchored, invalid signature doesn't affect the verdict
+	req, docRoot = verifyProofRequest(t)
+	req.Signatures = []ProofSignature{{SignerID: signer, PublicKey: utils.RandomSlice(32), Signature: utils.RandomSlice(65)}}
+	dr, err = anchors.ToDocumentRoot(docRoot)
+	assert.NoError(t, err)
+	anchorSrv.On("GetAnchorData", mock.Anything).Return(dr, nil).Once()
+	idService.On("ValidateSignature", signer, mock.Anything, mock.Anything, mock.Anything, time.Time{}).Return(errors.New("invalid key")).Once()
+	d, err = json.Marshal(req)
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(bytes.NewReader(d))
+	h.VerifyProof(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp = VerifyProofResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Valid)
+	assert.True(t, resp.Anchored)
+	assert.True(t, resp.KeysValidatedAt.IsZero())
+	assert.Len(t, resp.Signatures, 1)
+	assert.False(t, resp.Signatures[0].Valid)
+	anchorSrv.AssertExpectations(t)
+	idService.AssertExpectations(t)
+}
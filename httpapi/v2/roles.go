@@ -3,9 +3,11 @@ package v2
 import (
 	"net/http"
 
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -85,11 +87,13 @@ func (h handler) GetRole(w http.ResponseWriter, r *http.Request) {
 // @id add_role
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param document_id path string true "Document Identifier"
 // @param body body v2.AddRole true "Add Role Request"
 // @produce json
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
 // @success 200 {object} v2.Role
 // @router /v2/documents/{document_id}/roles [post]
@@ -106,7 +110,6 @@ func (h handler) AddRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
 	var rl AddRole
 	err = unmarshalBody(r, &rl)
 	if err != nil {
@@ -115,13 +118,15 @@ func (h handler) AddRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx, p := withPrecondition(r)
 	nrl, err := h.srv.AddRole(ctx, docID, rl.Key, rl.Collaborators)
 	if err != nil {
-		code = http.StatusBadRequest
+		code = preconditionFailed(err, http.StatusBadRequest)
 		log.Error(err)
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, toClientRole(nrl))
 }
@@ -137,12 +142,14 @@ type UpdateRole struct {
 // @id update_role
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param document_id path string true "Document Identifier"
 // @param role_id path string true "Role ID"
 // @param body body v2.UpdateRole true "Update Role Request"
 // @produce json
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
 // @success 200 {object} v2.Role
 // @router /v2/documents/{document_id}/roles/{role_id} [patch]
@@ -175,13 +182,79 @@ func (h handler) UpdateRole(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rl, err := h.srv.UpdateRole(r.Context(), docID, roleID, ur.Collaborators)
+	ctx, p := withPrecondition(r)
+	rl, err := h.srv.UpdateRole(ctx, docID, roleID, ur.Collaborators)
 	if err != nil {
-		code = http.StatusNotFound
+		code = preconditionFailed(err, http.StatusNotFound)
 		log.Error(err)
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, toClientRole(rl))
 }
+
+// SetRoleValidity sets the validity windows of the role and its read rules.
+// @summary Sets the validity windows of the role and its read rules.
+// @description Collaborators of the role lose their access outside the validity windows.
+// @description Windows left empty in the request are unchanged.
+// @id set_role_validity
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param role_id path string true "Role ID"
+// @param body body pending.RoleValidity true "Role Validity Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} pending.RoleValidity
+// @router /v2/documents/{document_id}/roles/{role_id}/validity [put]
+func (h handler) SetRoleValidity(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	roleID, err := hexutil.Decode(chi.URLParam(r, RoleIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidRoleID
+		return
+	}
+
+	var v pending.RoleValidity
+	err = unmarshalBody(r, &v)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	ctx, p := withPrecondition(r)
+	v, err = h.srv.SetRoleValidity(ctx, docID, roleID, v)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) || errors.IsOfType(documents.ErrRoleNotExist, err) {
+			code = http.StatusNotFound
+		}
+		code = preconditionFailed(err, code)
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, v)
+}
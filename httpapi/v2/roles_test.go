@@ -10,9 +10,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -212,6 +214,13 @@ func TestHandler_UpdateRole(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusNotFound)
 	assert.Contains(t, w.Body.String(), "NotFound")
 
+	// stale etag
+	psrv.On("UpdateRole", mock.Anything, docID, roleID, []identity.DID{collab}).Return(nil, pending.ErrETagMismatch).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	r.Header.Set(IfMatchHeader, `"stale"`)
+	h.UpdateRole(w, r)
+	assert.Equal(t, w.Code, http.StatusPreconditionFailed)
+
 	// success
 	psrv.On("UpdateRole", mock.Anything, docID, roleID, []identity.DID{collab}).Return(&coredocumentpb.Role{}, nil).Once()
 	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
@@ -219,3 +228,61 @@ func TestHandler_UpdateRole(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusOK)
 	psrv.AssertExpectations(t)
 }
+
+func TestHandler_SetRoleValidity(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("PUT", "/documents/{document_id}/roles/{role_id}/validity", b).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam, RoleIDParam}
+	rctx.URLParams.Values = []string{"some invalid id", ""}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.SetRoleValidity(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid role ID
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	rctx.URLParams.Values[1] = "some roleID"
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.SetRoleValidity(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidRoleID.Error())
+
+	// invalid body
+	roleID := utils.RandomSlice(32)
+	rctx.URLParams.Values[1] = hexutil.Encode(roleID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.SetRoleValidity(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing role
+	na := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	v := pending.RoleValidity{Role: &documents.Validity{NotAfter: &na}}
+	d, err := json.Marshal(v)
+	assert.NoError(t, err)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("SetRoleValidity", mock.Anything, docID, roleID, mock.Anything).Return(nil, documents.ErrRoleNotExist).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetRoleValidity(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	read := documents.Validity{}
+	psrv.On("SetRoleValidity", mock.Anything, docID, roleID, mock.Anything).Return(
+		pending.RoleValidity{Role: v.Role, ReadRules: &read}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetRoleValidity(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp pending.RoleValidity
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, na.Equal(*resp.Role.NotAfter))
+	assert.Nil(t, resp.ReadRules.NotAfter)
+	psrv.AssertExpectations(t)
+}
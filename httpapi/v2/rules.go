@@ -38,11 +38,13 @@ type TransitionRules struct {
 // @id add_transition_rule
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param document_id path string true "Document Identifier"
 // @param body body pending.AddTransitionRules true "Add Transition rules Request"
 // @produce json
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
 // @success 200 {object} v2.TransitionRules
 // @router /v2/documents/{document_id}/transition_rules [post]
@@ -59,7 +61,6 @@ func (h handler) AddTransitionRules(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
 	var addRules pending.AddTransitionRules
 	err = unmarshalBody(r, &addRules)
 	if err != nil {
@@ -68,13 +69,15 @@ func (h handler) AddTransitionRules(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx, p := withPrecondition(r)
 	rules, err := h.srv.AddTransitionRules(ctx, docID, addRules)
 	if err != nil {
-		code = http.StatusBadRequest
+		code = preconditionFailed(err, http.StatusBadRequest)
 		log.Error(err)
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.Status(r, http.StatusOK)
 	render.JSON(w, r, toClientRules(rules))
 }
@@ -131,11 +134,13 @@ func (h handler) GetTransitionRule(w http.ResponseWriter, r *http.Request) {
 // @id delete_transition_rule
 // @tags Documents
 // @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
 // @param document_id path string true "Document Identifier"
 // @param rule_id path string true "Transition rule ID"
 // @produce json
 // @Failure 403 {object} httputils.HTTPError
 // @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
 // @Failure 404 {object} httputils.HTTPError
 // @success 204 {object} nil
 // @router /v2/documents/{document_id}/transition_rules/{rule_id} [delete]
@@ -160,12 +165,14 @@ func (h handler) DeleteTransitionRule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.srv.DeleteTransitionRule(r.Context(), docID, ruleID)
+	ctx, p := withPrecondition(r)
+	err = h.srv.DeleteTransitionRule(ctx, docID, ruleID)
 	if err != nil {
-		code = http.StatusNotFound
+		code = preconditionFailed(err, http.StatusNotFound)
 		log.Error(err)
 		return
 	}
 
+	setETag(w, p.ETag)
 	render.NoContent(w, r)
 }
@@ -0,0 +1,177 @@
+package v2
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/schemas"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// SchemaNameParam is the key for schema name in the API path.
+const SchemaNameParam = "schema_name"
+
+// ErrInvalidSchemaVersion for invalid schema version in the api query.
+const ErrInvalidSchemaVersion = errors.Error("Invalid Schema Version")
+
+// SchemaRequest defines the payload to create a new version of the schema.
+type SchemaRequest struct {
+	Name  string                  `json:"name"`
+	Rules []schemas.AttributeRule `json:"rules"`
+
+	// Strict rejects attributes that are not defined in the schema.
+	Strict bool `json:"strict"`
+}
+
+// Schema is an alias to the schemas.Schema.
+// Aliased here to fix the swagger generation issues.
+type Schema = schemas.Schema
+
+// SchemaList holds the list of schemas.
+type SchemaList struct {
+	Schemas []*Schema `json:"schemas"`
+}
+
+// CreateSchema creates a new version of the schema.
+// @summary Creates a new version of the schema.
+// @description Creates a new version of the schema. First version is created if the schema doesn't exist yet.
+// @description Generic documents are tagged with a schema using the string attribute "_schema" with value "name" or "name@version".
+// @description Tagged documents are validated against the schema on commit and fail if the schema is unknown.
+// @id create_schema
+// @tags Schemas
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.SchemaRequest true "Schema Create Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 201 {object} v2.Schema
+// @router /v2/schemas [post]
+func (h handler) CreateSchema(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req SchemaRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	s, err := h.srv.CreateSchema(r.Context(), schemas.Schema{
+		Name:   req.Name,
+		Rules:  req.Rules,
+		Strict: req.Strict,
+	})
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, s)
+}
+
+// ListSchemas returns the latest version of every schema of the account.
+// @summary Returns the latest version of every schema of the account.
+// @description Returns the latest version of every schema of the account.
+// @id list_schemas
+// @tags Schemas
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} v2.SchemaList
+// @router /v2/schemas [get]
+func (h handler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ss, err := h.srv.ListSchemas(r.Context())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := SchemaList{Schemas: []*Schema{}}
+	resp.Schemas = append(resp.Schemas, ss...)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// GetSchema returns the version of the schema.
+// @summary Returns the version of the schema.
+// @description Returns the version of the schema. Latest version is returned if the version is not provided.
+// @id get_schema
+// @tags Schemas
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param schema_name path string true "Schema Name"
+// @param version query int false "Schema Version"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Schema
+// @router /v2/schemas/{schema_name} [get]
+func (h handler) GetSchema(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var version int
+	if v := r.URL.Query().Get("version"); v != "" {
+		version, err = strconv.Atoi(v)
+		if err != nil || version < 1 {
+			code = http.StatusBadRequest
+			log.Error(err)
+			err = ErrInvalidSchemaVersion
+			return
+		}
+	}
+
+	s, err := h.srv.GetSchema(r.Context(), chi.URLParam(r, SchemaNameParam), version)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, s)
+}
+
+// GetSchemaVersions returns all the versions of the schema.
+// @summary Returns all the versions of the schema.
+// @description Returns all the versions of the schema, oldest first.
+// @id get_schema_versions
+// @tags Schemas
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param schema_name path string true "Schema Name"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.SchemaList
+// @router /v2/schemas/{schema_name}/versions [get]
+func (h handler) GetSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ss, err := h.srv.GetSchemaVersions(r.Context(), chi.URLParam(r, SchemaNameParam))
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, SchemaList{Schemas: ss})
+}
@@ -0,0 +1,161 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/schemas"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_CreateSchema(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/schemas", b).WithContext(ctx)
+	}
+
+	// invalid body
+	ctx := context.Background()
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.CreateSchema(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid schema
+	req := SchemaRequest{
+		Name: "invoice",
+		Rules: []schemas.AttributeRule{
+			{Label: "number", Required: true, Types: []documents.AttributeType{documents.AttrString}},
+		},
+	}
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	s := schemas.Schema{Name: req.Name, Rules: req.Rules}
+	schemaSrv := new(schemas.MockService)
+	schemaSrv.On("Create", ctx, s).Return(nil, schemas.ErrInvalidSchema).Once()
+	h.srv.schemaSrv = schemaSrv
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CreateSchema(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), schemas.ErrInvalidSchema.Error())
+
+	// success
+	cs := s
+	cs.Version = 1
+	schemaSrv.On("Create", ctx, s).Return(&cs, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CreateSchema(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp Schema
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.Version)
+	assert.Equal(t, req.Rules, resp.Rules)
+	schemaSrv.AssertExpectations(t)
+}
+
+func TestHandler_ListSchemas(t *testing.T) {
+	ctx := context.Background()
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/schemas", nil).WithContext(ctx)
+	}
+
+	// failed
+	schemaSrv := new(schemas.MockService)
+	schemaSrv.On("List", ctx).Return(nil, errors.New("failed")).Once()
+	h := handler{srv: Service{schemaSrv: schemaSrv}}
+	w, r := getHTTPReqAndResp()
+	h.ListSchemas(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// empty
+	schemaSrv.On("List", ctx).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListSchemas(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"schemas":[]`)
+
+	// success
+	schemaSrv.On("List", ctx).Return([]*schemas.Schema{{Name: "invoice", Version: 2}, {Name: "entity", Version: 1}}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListSchemas(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp SchemaList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Schemas, 2)
+	schemaSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetSchema(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/schemas/{schema_name}?"+query, nil).WithContext(ctx)
+	}
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{SchemaNameParam}
+	rctx.URLParams.Values = []string{"invoice"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+
+	// invalid version
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, "version=0")
+	h.GetSchema(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidSchemaVersion.Error())
+
+	// missing schema
+	schemaSrv := new(schemas.MockService)
+	schemaSrv.On("Get", ctx, "invoice", 0).Return(nil, schemas.ErrSchemaNotFound).Once()
+	h.srv.schemaSrv = schemaSrv
+	w, r = getHTTPReqAndResp(ctx, "")
+	h.GetSchema(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), schemas.ErrSchemaNotFound.Error())
+
+	// success
+	schemaSrv.On("Get", ctx, "invoice", 2).Return(&schemas.Schema{Name: "invoice", Version: 2}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, "version=2")
+	h.GetSchema(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp Schema
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Version)
+	schemaSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetSchemaVersions(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/schemas/{schema_name}/versions", nil).WithContext(ctx)
+	}
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{SchemaNameParam}
+	rctx.URLParams.Values = []string{"invoice"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+
+	// missing schema
+	schemaSrv := new(schemas.MockService)
+	schemaSrv.On("GetVersions", ctx, "invoice").Return(nil, schemas.ErrSchemaNotFound).Once()
+	h := handler{srv: Service{schemaSrv: schemaSrv}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetSchemaVersions(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	schemaSrv.On("GetVersions", ctx, "invoice").Return([]*schemas.Schema{{Name: "invoice", Version: 1}, {Name: "invoice", Version: 2}}, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetSchemaVersions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp SchemaList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Schemas, 2)
+	schemaSrv.AssertExpectations(t)
+}
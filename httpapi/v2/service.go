@@ -2,18 +2,35 @@ package v2
 
 import (
 	"context"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/verifier"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/schemas"
+	"github.com/centrifuge/go-centrifuge/templates"
 )
 
 // Service is the entry point for all the V2 APIs.
 type Service struct {
-	pendingDocSrv pending.Service
-	tokenRegistry documents.TokenRegistry
+	pendingDocSrv  pending.Service
+	tokenRegistry  documents.TokenRegistry
+	schemaSrv      schemas.Service
+	templateSrv    templates.Service
+	anchorSrv      anchors.Service
+	idService      identity.Service
+	attachmentRepo documents.AttachmentRepository
+	p2pClient      documents.Client
+	eventBus       notification.EventBus
 }
 
 // CreateDocument creates a pending document from the given payload.
@@ -22,6 +39,28 @@ func (s Service) CreateDocument(ctx context.Context, req documents.UpdatePayload
 	return s.pendingDocSrv.Create(ctx, req)
 }
 
+// CreateDocumentFromTemplate creates a pending document from the given payload and the template.
+// Template placeholders are substituted with the params.
+func (s Service) CreateDocumentFromTemplate(
+	ctx context.Context, name string, params map[string]string, req documents.UpdatePayload) (documents.Model, error) {
+	tmpl, err := s.templateSrv.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	st, err := tmpl.Substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.pendingDocSrv.CreateFromTemplate(ctx, req, st)
+}
+
+// CloneDocument creates a new pending document from the latest committed version of the document.
+func (s Service) CloneDocument(ctx context.Context, docID []byte, opts documents.CloneOptions) (documents.Model, error) {
+	return s.pendingDocSrv.Clone(ctx, docID, opts)
+}
+
 // UpdateDocument updates a pending document with the given payload
 func (s Service) UpdateDocument(ctx context.Context, req documents.UpdatePayload) (documents.Model, error) {
 	return s.pendingDocSrv.Update(ctx, req)
@@ -32,6 +71,26 @@ func (s Service) Commit(ctx context.Context, docID []byte) (documents.Model, job
 	return s.pendingDocSrv.Commit(ctx, docID)
 }
 
+// RetryCommit resumes the anchoring of the committed document from the last stage completed by the failed job.
+func (s Service) RetryCommit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error) {
+	return s.pendingDocSrv.RetryCommit(ctx, docID)
+}
+
+// CommitDocuments validates all the pending documents and commits them within a single job.
+func (s Service) CommitDocuments(ctx context.Context, docIDs [][]byte) (jobs.JobID, error) {
+	return s.pendingDocSrv.CommitBatch(ctx, docIDs)
+}
+
+// GetBatchCommitStatus returns the status of the batch commit job and the progress of each of its documents.
+func (s Service) GetBatchCommitStatus(ctx context.Context, jobID jobs.JobID) (jobs.Status, []pending.BatchCommitStatus, error) {
+	return s.pendingDocSrv.GetBatchCommitStatus(ctx, jobID)
+}
+
+// DeletePendingDocument discards the pending document associated with docID.
+func (s Service) DeletePendingDocument(ctx context.Context, docID []byte) error {
+	return s.pendingDocSrv.Delete(ctx, docID)
+}
+
 // GetDocument returns the document associated with docID and status.
 func (s Service) GetDocument(ctx context.Context, docID []byte, status documents.Status) (documents.Model, error) {
 	return s.pendingDocSrv.Get(ctx, docID, status)
@@ -67,6 +126,52 @@ func (s Service) UpdateRole(ctx context.Context, docID, roleID []byte, dids []id
 	return s.pendingDocSrv.UpdateRole(ctx, docID, roleID, dids)
 }
 
+// SetRoleValidity sets the validity windows of the role and its read rules in the document
+func (s Service) SetRoleValidity(ctx context.Context, docID, roleID []byte, v pending.RoleValidity) (pending.RoleValidity, error) {
+	return s.pendingDocSrv.SetRoleValidity(ctx, docID, roleID, v)
+}
+
+// GrantAccessToken adds an access token to the pending document granting the grantee read access to the document tokenDocID.
+func (s Service) GrantAccessToken(ctx context.Context, docID []byte, grantee identity.DID, tokenDocID []byte) (*coredocumentpb.AccessToken, error) {
+	return s.pendingDocSrv.GrantAccessToken(ctx, docID, grantee, tokenDocID)
+}
+
+// GetAccessTokens returns the access tokens of the pending document.
+func (s Service) GetAccessTokens(ctx context.Context, docID []byte) ([]*coredocumentpb.AccessToken, error) {
+	return s.pendingDocSrv.GetAccessTokens(ctx, docID)
+}
+
+// RevokeAccessToken removes the access token from the pending document.
+func (s Service) RevokeAccessToken(ctx context.Context, docID, tokenID []byte) error {
+	return s.pendingDocSrv.RevokeAccessToken(ctx, docID, tokenID)
+}
+
+// GetDocumentWithAccessToken requests the document from the granter using the access token in the delegating document.
+func (s Service) GetDocumentWithAccessToken(
+	ctx context.Context, granter identity.DID, tokenID, docID, delegatingDocID []byte) (documents.Model, error) {
+	return s.pendingDocSrv.GetWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+}
+
+// SetApprovalPolicy sets the approval policy of the pending document.
+func (s Service) SetApprovalPolicy(ctx context.Context, docID []byte, p documents.ApprovalPolicy) (documents.ApprovalStatus, error) {
+	return s.pendingDocSrv.SetApprovalPolicy(ctx, docID, p)
+}
+
+// GetApprovalStatus returns the approval status of the pending document.
+func (s Service) GetApprovalStatus(ctx context.Context, docID []byte) (documents.ApprovalStatus, error) {
+	return s.pendingDocSrv.GetApprovalStatus(ctx, docID)
+}
+
+// Approve approves the pending versions of the document awaiting the approval of the account.
+func (s Service) Approve(ctx context.Context, docID []byte) ([]pending.PendingApproval, error) {
+	return s.pendingDocSrv.Approve(ctx, docID)
+}
+
+// ListPendingApprovals returns the pending documents awaiting the approval of the account.
+func (s Service) ListPendingApprovals(ctx context.Context) ([]pending.PendingApproval, error) {
+	return s.pendingDocSrv.ListPendingApprovals(ctx)
+}
+
 // AddTransitionRules adds new rules to the document
 func (s Service) AddTransitionRules(
 	ctx context.Context, docID []byte, addRules pending.AddTransitionRules) ([]*coredocumentpb.TransitionRule, error) {
@@ -82,3 +187,243 @@ func (s Service) GetTransitionRule(ctx context.Context, docID, ruleID []byte) (*
 func (s Service) DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error {
 	return s.pendingDocSrv.DeleteTransitionRule(ctx, docID, ruleID)
 }
+
+// ListDocuments returns the documents, owned by the account, that match the filter.
+func (s Service) ListDocuments(ctx context.Context, filter documents.ListFilter) ([]documents.Model, []byte, error) {
+	return s.pendingDocSrv.List(ctx, filter)
+}
+
+// GetDocumentVersions returns the committed versions of the document, latest first, starting at cursor if provided.
+func (s Service) GetDocumentVersions(ctx context.Context, docID, cursor []byte, limit int) ([]documents.VersionInfo, []byte, error) {
+	return s.pendingDocSrv.GetVersions(ctx, docID, cursor, limit)
+}
+
+// GetDocumentDiff returns the changes made in the version to compared to the version from.
+func (s Service) GetDocumentDiff(ctx context.Context, docID, from, to []byte) (documents.Diff, error) {
+	return s.pendingDocSrv.Diff(ctx, docID, from, to)
+}
+
+// GetDocumentForks returns the local versions of the document that lost against versions anchored by other collaborators.
+func (s Service) GetDocumentForks(ctx context.Context, docID []byte) ([]*documents.Fork, error) {
+	return s.pendingDocSrv.GetForks(ctx, docID)
+}
+
+// RebaseDocumentFork creates a pending document from the latest committed version with the changes of the forked version.
+func (s Service) RebaseDocumentFork(ctx context.Context, docID, versionID []byte) (documents.Model, error) {
+	return s.pendingDocSrv.Rebase(ctx, docID, versionID)
+}
+
+// SubscribeDocumentEvents subscribes to the events of the documents owned by the account.
+// Events of all the documents of the account are delivered if no docIDs are provided.
+func (s Service) SubscribeDocumentEvents(ctx context.Context, docIDs [][]byte) (*notification.Subscription, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.eventBus.Subscribe(did, docIDs...), nil
+}
+
+// ListInbox returns the versions, received from the collaborators, that match the filter.
+func (s Service) ListInbox(ctx context.Context, filter documents.InboxFilter) ([]*documents.InboxItem, []byte, error) {
+	return s.pendingDocSrv.ListInbox(ctx, filter)
+}
+
+// MarkInboxItemRead marks the inbox item as read.
+func (s Service) MarkInboxItemRead(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	return s.pendingDocSrv.MarkInboxItemRead(ctx, id)
+}
+
+// AckInboxItem marks the inbox item as acknowledged.
+func (s Service) AckInboxItem(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	return s.pendingDocSrv.AckInboxItem(ctx, id)
+}
+
+// ValidateDocument runs the commit checks against the pending document and returns every failure.
+func (s Service) ValidateDocument(ctx context.Context, docID []byte) ([]documents.ValidationFailure, error) {
+	return s.pendingDocSrv.Validate(ctx, docID)
+}
+
+// ExportDocumentBundle returns the bundle of the committed version of the document.
+func (s Service) ExportDocumentBundle(ctx context.Context, docID, versionID []byte) ([]byte, error) {
+	return s.pendingDocSrv.ExportBundle(ctx, docID, versionID)
+}
+
+// ImportDocumentBundle validates the bundle and stores the anchored version of the document.
+func (s Service) ImportDocumentBundle(ctx context.Context, data []byte) (documents.Model, error) {
+	return s.pendingDocSrv.ImportBundle(ctx, data)
+}
+
+// AddAttachment stores the content and adds the attachment to the pending document.
+func (s Service) AddAttachment(ctx context.Context, docID []byte, name, mimeType string, content []byte) (documents.Attachment, error) {
+	att, err := documents.NewAttachment(name, mimeType, content)
+	if err != nil {
+		return att, err
+	}
+
+	// content is stored first so that the pending document never references missing content
+	_, err = s.attachmentRepo.Create(content)
+	if err != nil {
+		return att, err
+	}
+
+	_, err = s.pendingDocSrv.AddAttachment(ctx, docID, att)
+	return att, err
+}
+
+// GetAttachment returns the attachment of the pending or the latest committed version of the document along with its content.
+// Content missing locally is requested from the author of the version, verified against the attachment, and stored.
+func (s Service) GetAttachment(ctx context.Context, docID, hash []byte) (att documents.Attachment, content []byte, err error) {
+	model, err := s.pendingDocSrv.Get(ctx, docID, documents.Pending)
+	if err != nil {
+		model, err = s.pendingDocSrv.Get(ctx, docID, documents.Committed)
+		if err != nil {
+			return att, nil, documents.ErrDocumentNotFound
+		}
+	}
+
+	att, err = documents.FindAttachment(model, hash)
+	if err != nil {
+		return att, nil, err
+	}
+
+	content, err = s.attachmentRepo.Get(hash)
+	if err == nil {
+		return att, content, nil
+	}
+
+	author, err := model.Author()
+	if err != nil {
+		return att, nil, errors.NewTypedError(documents.ErrAttachmentNotFound, err)
+	}
+
+	resp, err := s.p2pClient.GetAttachmentRequest(ctx, author, &p2pcommon.GetAttachmentRequest{
+		DocumentRequest: &p2ppb.GetDocumentRequest{
+			DocumentIdentifier: docID,
+			AccessType:         p2ppb.AccessType_ACCESS_TYPE_REQUESTER_VERIFICATION,
+		},
+		Hash: hash,
+	})
+	if err != nil {
+		return att, nil, errors.NewTypedError(documents.ErrAttachmentNotFound, err)
+	}
+
+	err = att.Verify(resp.Content)
+	if err != nil {
+		return att, nil, err
+	}
+
+	_, err = s.attachmentRepo.Create(resp.Content)
+	if err != nil {
+		return att, nil, err
+	}
+
+	return att, resp.Content, nil
+}
+
+// CreateSchema creates the next version of the schema.
+func (s Service) CreateSchema(ctx context.Context, schema schemas.Schema) (*schemas.Schema, error) {
+	return s.schemaSrv.Create(ctx, schema)
+}
+
+// ListSchemas returns the latest version of every schema of the account.
+func (s Service) ListSchemas(ctx context.Context) ([]*schemas.Schema, error) {
+	return s.schemaSrv.List(ctx)
+}
+
+// GetSchema returns the version of the schema. Latest version is returned if the version is 0.
+func (s Service) GetSchema(ctx context.Context, name string, version int) (*schemas.Schema, error) {
+	return s.schemaSrv.Get(ctx, name, version)
+}
+
+// GetSchemaVersions returns all the versions of the schema.
+func (s Service) GetSchemaVersions(ctx context.Context, name string) ([]*schemas.Schema, error) {
+	return s.schemaSrv.GetVersions(ctx, name)
+}
+
+// CreateTemplate registers the template for the account.
+func (s Service) CreateTemplate(ctx context.Context, template templates.Template) (*templates.Template, error) {
+	return s.templateSrv.Create(ctx, template)
+}
+
+// ListTemplates returns all the templates of the account.
+func (s Service) ListTemplates(ctx context.Context) ([]*templates.Template, error) {
+	return s.templateSrv.List(ctx)
+}
+
+// GetTemplate returns the template of the account.
+func (s Service) GetTemplate(ctx context.Context, name string) (*templates.Template, error) {
+	return s.templateSrv.Get(ctx, name)
+}
+
+// DeleteTemplate deletes the template of the account.
+func (s Service) DeleteTemplate(ctx context.Context, name string) error {
+	return s.templateSrv.Delete(ctx, name)
+}
+
+// VerifyProof recomputes the document root and returns the verdicts of the field proofs, anchor and signatures.
+// Signatures are not bound to the signatures root, so their verdicts don't affect the overall verdict.
+// Signer keys are validated at the anchor time, or now if the version is not anchored.
+// An error is returned only if the roots don't add up.
+func (s Service) VerifyProof(req VerifyProofRequest) (resp VerifyProofResponse, err error) {
+	if len(req.FieldProofs) < 1 {
+		return resp, errors.NewTypedError(verifier.ErrInvalidFieldProof, errors.New("no field proofs"))
+	}
+
+	p := verifier.DocumentProof{
+		DocumentID:     req.DocumentID,
+		VersionID:      req.VersionID,
+		FieldProofs:    req.FieldProofs,
+		LeftDataRoot:   req.LeftDataRoot,
+		RightDataRoot:  req.RightDataRoot,
+		SigningRoot:    req.SigningRoot,
+		SignaturesRoot: req.SignaturesRoot,
+	}
+
+	docRoot, fields, err := verifier.VerifyFields(p)
+	if err != nil {
+		return resp, err
+	}
+
+	resp = VerifyProofResponse{
+		DocumentRoot: docRoot,
+		Valid:        true,
+		Fields:       []FieldVerdict{},
+		Signatures:   []SignatureVerdict{},
+	}
+
+	for _, f := range fields {
+		resp.Fields = append(resp.Fields, FieldVerdict{Property: f.Property, Valid: f.Valid(), Error: errString(f.Err)})
+		resp.Valid = resp.Valid && f.Valid()
+	}
+
+	anchoredAt, err := verifier.CheckAnchor(s.anchorSrv, req.VersionID, docRoot)
+	resp.Anchored, resp.AnchorError = err == nil, errString(err)
+	resp.Valid = resp.Valid && resp.Anchored
+
+	var sigs []verifier.Signature
+	for _, sig := range req.Signatures {
+		sigs = append(sigs, verifier.Signature{
+			SignerID:            sig.SignerID,
+			PublicKey:           sig.PublicKey,
+			Signature:           sig.Signature,
+			TransitionValidated: sig.TransitionValidated,
+		})
+	}
+
+	resp.KeysValidatedAt = time.Now().UTC()
+	if resp.Anchored {
+		resp.KeysValidatedAt = anchoredAt
+	}
+
+	for _, v := range verifier.VerifySignatures(s.idService, req.SigningRoot, sigs, resp.KeysValidatedAt) {
+		resp.Signatures = append(resp.Signatures, SignatureVerdict{SignerID: v.SignerID, Valid: v.Valid(), Error: errString(v.Err)})
+	}
+
+	return resp, nil
+}
+
+// SetSignaturePolicy sets the signature policy of the pending document.
+func (s Service) SetSignaturePolicy(ctx context.Context, docID []byte, p documents.SignaturePolicy) (documents.SignaturePolicy, error) {
+	return s.pendingDocSrv.SetSignaturePolicy(ctx, docID, p)
+}
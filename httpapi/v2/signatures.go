@@ -0,0 +1,73 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// SignaturePolicyRequest decides if the pending document is anchored when collaborators fail to sign it.
+type SignaturePolicyRequest = documents.SignaturePolicy
+
+// SetSignaturePolicy sets the signature policy of the pending document.
+// @summary Sets the signature policy of the pending document.
+// @description Decides if the document is anchored when collaborators fail to sign it on commit.
+// @description best_effort anchors the document with the signatures collected. Default policy.
+// @description all fails the commit unless all the signers sign the document.
+// @description required fails the commit unless the required signers sign the document.
+// @id set_signature_policy
+// @tags Documents
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param If-Match header string false "ETag of the pending document the update is based on"
+// @param document_id path string true "Document Identifier"
+// @param body body v2.SignaturePolicyRequest true "Signature Policy Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 412 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} documents.SignaturePolicy
+// @router /v2/documents/{document_id}/signature_policy [put]
+func (h handler) SetSignaturePolicy(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	var req SignaturePolicyRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	ctx, p := withPrecondition(r)
+	policy, err := h.srv.SetSignaturePolicy(ctx, docID, req)
+	if err != nil {
+		code = preconditionFailed(err, http.StatusBadRequest)
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	setETag(w, p.ETag)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, policy)
+}
@@ -0,0 +1,85 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/pending"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_SetSignaturePolicy(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("PUT", "/documents/{document_id}/signature_policy", b).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h := handler{}
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// invalid body
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid policy
+	p := SignaturePolicyRequest{
+		Mode:            documents.SignaturePolicyRequired,
+		RequiredSigners: []identity.DID{testingidentity.GenerateRandomDID()},
+	}
+	d, err := json.Marshal(p)
+	assert.NoError(t, err)
+	psrv := new(pending.MockService)
+	h.srv.pendingDocSrv = psrv
+	psrv.On("SetSignaturePolicy", mock.Anything, docID, p).Return(nil, documents.ErrInvalidSignaturePolicy).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), documents.ErrInvalidSignaturePolicy.Error())
+
+	// missing document
+	psrv.On("SetSignaturePolicy", mock.Anything, docID, p).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// pending document modified since If-Match
+	psrv.On("SetSignaturePolicy", mock.Anything, docID, p).Return(nil, pending.ErrETagMismatch).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+
+	// success
+	psrv.On("SetSignaturePolicy", mock.Anything, docID, p).Return(p, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.SetSignaturePolicy(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp SignaturePolicyRequest
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, p, resp)
+	psrv.AssertExpectations(t)
+}
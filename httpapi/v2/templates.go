@@ -0,0 +1,171 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/templates"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// TemplateNameParam is the key for template name in the API path.
+	TemplateNameParam = "template_name"
+
+	// TemplateQueryParam is the key for template name in the create document query.
+	TemplateQueryParam = "template"
+)
+
+// TemplateRequest defines the payload to create a template.
+type TemplateRequest struct {
+	Name            string                     `json:"name"`
+	Scheme          string                     `json:"scheme" enums:"generic,entity,invoice"`
+	Roles           []templates.Role           `json:"roles"`
+	TransitionRules []templates.TransitionRule `json:"transition_rules"`
+	ReadRules       []templates.ReadRule       `json:"read_rules"`
+	Attributes      []templates.Attribute      `json:"attributes"`
+}
+
+// Template is an alias to the templates.Template.
+// Aliased here to fix the swagger generation issues.
+type Template = templates.Template
+
+// TemplateList holds the list of templates.
+type TemplateList struct {
+	Templates []*Template `json:"templates"`
+}
+
+// CreateTemplate creates a new template.
+// @summary Creates a new document template.
+// @description Creates a new document template with the scheme, roles, rules, and default attributes.
+// @description Role collaborators and attribute values can contain placeholders like ${buyer} that are substituted on document creation.
+// @id create_template
+// @tags Templates
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.TemplateRequest true "Template Create Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 409 {object} httputils.HTTPError
+// @success 201 {object} v2.Template
+// @router /v2/templates [post]
+func (h handler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req TemplateRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	t, err := h.srv.CreateTemplate(r.Context(), templates.Template{
+		Name:            req.Name,
+		Scheme:          req.Scheme,
+		Roles:           req.Roles,
+		TransitionRules: req.TransitionRules,
+		ReadRules:       req.ReadRules,
+		Attributes:      req.Attributes,
+	})
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(templates.ErrTemplateExists, err) {
+			code = http.StatusConflict
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, t)
+}
+
+// ListTemplates returns all the templates of the account.
+// @summary Returns all the templates of the account.
+// @description Returns all the templates of the account.
+// @id list_templates
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} v2.TemplateList
+// @router /v2/templates [get]
+func (h handler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	ts, err := h.srv.ListTemplates(r.Context())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp := TemplateList{Templates: []*Template{}}
+	resp.Templates = append(resp.Templates, ts...)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// GetTemplate returns the template.
+// @summary Returns the template.
+// @description Returns the template.
+// @id get_template
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template_name path string true "Template Name"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Template
+// @router /v2/templates/{template_name} [get]
+func (h handler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	t, err := h.srv.GetTemplate(r.Context(), chi.URLParam(r, TemplateNameParam))
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, t)
+}
+
+// DeleteTemplate deletes the template.
+// @summary Deletes the template.
+// @description Deletes the template. Documents created from the template are not affected.
+// @id delete_template
+// @tags Templates
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param template_name path string true "Template Name"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 204 {object} nil
+// @router /v2/templates/{template_name} [delete]
+func (h handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	err = h.srv.DeleteTemplate(r.Context(), chi.URLParam(r, TemplateNameParam))
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
@@ -0,0 +1,211 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/templates"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testTemplate() templates.Template {
+	return templates.Template{
+		Name:      "purchase-order",
+		Scheme:    "generic",
+		Roles:     []templates.Role{{Key: "buyer", Collaborators: []string{"${buyer}"}}},
+		ReadRules: []templates.ReadRule{{RoleKey: "buyer"}},
+		Attributes: []templates.Attribute{
+			{Label: "status", Type: documents.AttrString, Value: "draft"},
+		},
+	}
+}
+
+func TestHandler_CreateTemplate(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/templates", b).WithContext(ctx)
+	}
+
+	// invalid body
+	ctx := context.Background()
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, bytes.NewReader([]byte("invalid")))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid template
+	tmpl := testTemplate()
+	req := TemplateRequest{
+		Name:       tmpl.Name,
+		Scheme:     tmpl.Scheme,
+		Roles:      tmpl.Roles,
+		ReadRules:  tmpl.ReadRules,
+		Attributes: tmpl.Attributes,
+	}
+	d, err := json.Marshal(req)
+	assert.NoError(t, err)
+	templateSrv := new(templates.MockService)
+	templateSrv.On("Create", ctx, tmpl).Return(nil, templates.ErrInvalidTemplate).Once()
+	h.srv.templateSrv = templateSrv
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), templates.ErrInvalidTemplate.Error())
+
+	// already exists
+	templateSrv.On("Create", ctx, tmpl).Return(nil, templates.ErrTemplateExists).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	// success
+	templateSrv.On("Create", ctx, tmpl).Return(&tmpl, nil).Once()
+	w, r = getHTTPReqAndResp(ctx, bytes.NewReader(d))
+	h.CreateTemplate(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp Template
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, tmpl.Name, resp.Name)
+	assert.Equal(t, tmpl.Roles, resp.Roles)
+	templateSrv.AssertExpectations(t)
+}
+
+func TestHandler_ListTemplates(t *testing.T) {
+	ctx := context.Background()
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/templates", nil).WithContext(ctx)
+	}
+
+	// failed
+	templateSrv := new(templates.MockService)
+	templateSrv.On("List", ctx).Return(nil, errors.New("failed")).Once()
+	h := handler{srv: Service{templateSrv: templateSrv}}
+	w, r := getHTTPReqAndResp()
+	h.ListTemplates(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// empty
+	templateSrv.On("List", ctx).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListTemplates(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"templates":[]`)
+
+	// success
+	templateSrv.On("List", ctx).Return([]*templates.Template{{Name: "po"}, {Name: "invoice"}}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListTemplates(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp TemplateList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Templates, 2)
+	templateSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetTemplate(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{TemplateNameParam}
+	rctx.URLParams.Values = []string{"po"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/templates/{template_name}", nil).WithContext(ctx)
+	}
+
+	// missing template
+	templateSrv := new(templates.MockService)
+	templateSrv.On("Get", ctx, "po").Return(nil, templates.ErrTemplateNotFound).Once()
+	h := handler{srv: Service{templateSrv: templateSrv}}
+	w, r := getHTTPReqAndResp()
+	h.GetTemplate(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), templates.ErrTemplateNotFound.Error())
+
+	// success
+	templateSrv.On("Get", ctx, "po").Return(&templates.Template{Name: "po"}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetTemplate(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp Template
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "po", resp.Name)
+	templateSrv.AssertExpectations(t)
+}
+
+func TestHandler_DeleteTemplate(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{TemplateNameParam}
+	rctx.URLParams.Values = []string{"po"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("DELETE", "/templates/{template_name}", nil).WithContext(ctx)
+	}
+
+	// missing template
+	templateSrv := new(templates.MockService)
+	templateSrv.On("Delete", ctx, "po").Return(templates.ErrTemplateNotFound).Once()
+	h := handler{srv: Service{templateSrv: templateSrv}}
+	w, r := getHTTPReqAndResp()
+	h.DeleteTemplate(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	templateSrv.On("Delete", ctx, "po").Return(nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.DeleteTemplate(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	templateSrv.AssertExpectations(t)
+}
+
+func TestHandler_CreateDocument_FromTemplate(t *testing.T) {
+	ctx := context.Background()
+	getHTTPReqAndResp := func(b io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents?template=po", b).WithContext(ctx)
+	}
+
+	buyer := testingidentity.GenerateRandomDID()
+	d, err := json.Marshal(map[string]interface{}{
+		"data":   documentData(),
+		"params": map[string]string{"buyer": buyer.String()},
+	})
+	assert.NoError(t, err)
+
+	// missing template
+	templateSrv := new(templates.MockService)
+	pendingSrv := new(pending.MockService)
+	h := handler{srv: Service{templateSrv: templateSrv, pendingDocSrv: pendingSrv}}
+	templateSrv.On("Get", ctx, "po").Return(nil, templates.ErrTemplateNotFound).Once()
+	w, r := getHTTPReqAndResp(bytes.NewReader(d))
+	h.CreateDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// missing params
+	tmpl := testTemplate()
+	templateSrv.On("Get", ctx, "po").Return(&tmpl, nil)
+	w, r = getHTTPReqAndResp(bytes.NewReader([]byte(`{"data": {}}`)))
+	h.CreateDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), templates.ErrTemplateParams.Error())
+
+	// substituted template is used
+	st, err := tmpl.Substitute(map[string]string{"buyer": buyer.String()})
+	assert.NoError(t, err)
+	pendingSrv.On("CreateFromTemplate", ctx, mock.Anything, st).Return(nil, errors.New("failed to create document")).Once()
+	w, r = getHTTPReqAndResp(bytes.NewReader(d))
+	h.CreateDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to create document")
+	templateSrv.AssertExpectations(t)
+	pendingSrv.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// ValidationResponse holds the failures of the checks run against the pending document.
+type ValidationResponse struct {
+	// Valid is true if the pending document passed all the checks.
+	Valid    bool                          `json:"valid"`
+	Failures []documents.ValidationFailure `json:"failures"`
+}
+
+// ValidateDocument runs the commit checks against the pending document without committing it.
+// @summary Validates the pending document without committing it.
+// @description Runs the checks applied on commit, by the node and its collaborators, against the pending document
+// @description and the latest committed version. Pending document is not modified.
+// @description Returns every failure along with the check that failed.
+// @id validate_document
+// @tags Documents
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.ValidationResponse
+// @router /v2/documents/{document_id}/validate [post]
+func (h handler) ValidateDocument(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	failures, err := h.srv.ValidateDocument(r.Context(), docID)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			code = http.StatusNotFound
+		}
+
+		log.Error(err)
+		return
+	}
+
+	resp := ValidationResponse{Valid: len(failures) == 0, Failures: failures}
+	if resp.Failures == nil {
+		resp.Failures = []documents.ValidationFailure{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,77 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ValidateDocument(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/documents/{document_id}/validate", nil).WithContext(ctx)
+	}
+
+	// invalid doc id
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	w, r := getHTTPReqAndResp(ctx)
+	h := handler{}
+	h.ValidateDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing pending document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	srv := new(pending.MockService)
+	h.srv = Service{pendingDocSrv: srv}
+	srv.On("Validate", ctx, docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ValidateDocument(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// failed to validate
+	srv.On("Validate", ctx, docID).Return(nil, errors.New("failed")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ValidateDocument(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// valid
+	srv.On("Validate", ctx, docID).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ValidateDocument(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ValidationResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Valid)
+	assert.Empty(t, resp.Failures)
+
+	// invalid
+	failures := []documents.ValidationFailure{
+		{Check: documents.CheckApprovals, Error: "1 of 2 required approvals"},
+		{Check: documents.CheckTransition, Error: "invalid document state transition"},
+	}
+	srv.On("Validate", ctx, docID).Return(failures, nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.ValidateDocument(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.False(t, resp.Valid)
+	assert.Equal(t, failures, resp.Failures)
+	srv.AssertExpectations(t)
+}
@@ -66,7 +66,12 @@ func GetServers(ctx map[string]interface{}) ([]Server, error) {
 		return nil, errors.New("queue server not initialized")
 	}
 
+	sweeper, ok := ctx[bootstrap.BootstrappedPendingDocumentSweeper]
+	if !ok {
+		return nil, errors.New("pending document sweeper not initialized")
+	}
+
 	var servers []Server
-	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server))
+	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server), sweeper.(Server))
 	return servers, nil
 }
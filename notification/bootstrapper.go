@@ -0,0 +1,13 @@
+package notification
+
+// BootstrappedEventBus is the key to the EventBus in bootstrap context.
+const BootstrappedEventBus = "BootstrappedEventBus"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap adds the EventBus shared by the services of the node into context.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	ctx[BootstrappedEventBus] = NewEventBus()
+	return nil
+}
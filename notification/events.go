@@ -0,0 +1,155 @@
+package notification
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+)
+
+// DocumentEventType is the type of the document event.
+type DocumentEventType string
+
+// Document event types published on the event bus.
+const (
+	// PendingVersion is published when a pending version of the document is created or updated.
+	PendingVersion DocumentEventType = "pending_version"
+
+	// VersionCommitted is published when the version of the document is anchored by the account.
+	VersionCommitted DocumentEventType = "committed"
+
+	// VersionReceived is published when an anchored version of the document is received from a collaborator.
+	VersionReceived DocumentEventType = "received"
+
+	// SignatureReceived is published when a collaborator signs the version of the document.
+	SignatureReceived DocumentEventType = "signature"
+
+	// SignatureRequested is published when the account signs the version of the document requested by a collaborator.
+	SignatureRequested DocumentEventType = "signature_requested"
+
+	// NFTAdded is published when an NFT is added in the committed or received version of the document.
+	NFTAdded DocumentEventType = "nft"
+)
+
+// subscriptionBuffer is the number of events buffered per subscription.
+// Events are dropped for the subscriber once the buffer is full.
+const subscriptionBuffer = 64
+
+// DocumentEvent is a change of a document owned by the account.
+type DocumentEvent struct {
+	Type         DocumentEventType  `json:"type"`
+	AccountID    identity.DID       `json:"account_id" swaggertype:"primitive,string"`
+	DocumentID   byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	VersionID    byteutils.HexBytes `json:"version_id" swaggertype:"primitive,string"`
+	Scheme       string             `json:"scheme,omitempty"`
+	Collaborator *identity.DID      `json:"collaborator,omitempty" swaggertype:"primitive,string"`
+	Registry     string             `json:"registry,omitempty"`
+	TokenID      byteutils.HexBytes `json:"token_id,omitempty" swaggertype:"primitive,string"`
+	Recorded     time.Time          `json:"recorded" swaggertype:"primitive,string"`
+}
+
+// EventBus delivers the document events to the subscribers of the account.
+type EventBus interface {
+	// Publish delivers the event to the matching subscriptions without blocking.
+	Publish(event DocumentEvent)
+
+	// Subscribe returns a subscription to the events of the documents, owned by the account.
+	// If no documentIDs are provided, events of all the documents of the account are delivered.
+	Subscribe(accountID identity.DID, documentIDs ...[]byte) *Subscription
+}
+
+// Subscription receives the events of the subscribed documents until closed.
+type Subscription struct {
+	accountID   identity.DID
+	documentIDs [][]byte
+	events      chan DocumentEvent
+	close       func()
+	once        sync.Once
+}
+
+// Events returns the channel delivering the events. Channel is closed once the subscription is closed.
+func (s *Subscription) Events() <-chan DocumentEvent {
+	return s.events
+}
+
+// Close stops the delivery of the events.
+func (s *Subscription) Close() {
+	s.once.Do(s.close)
+}
+
+// matches returns true if the event is subscribed to.
+func (s *Subscription) matches(event DocumentEvent) bool {
+	if !s.accountID.Equal(event.AccountID) {
+		return false
+	}
+
+	if len(s.documentIDs) == 0 {
+		return true
+	}
+
+	for _, id := range s.documentIDs {
+		if bytes.Equal(id, event.DocumentID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type eventBus struct {
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[uint64]*Subscription
+}
+
+// NewEventBus returns an in-memory implementation of the EventBus.
+func NewEventBus() EventBus {
+	return &eventBus{subs: make(map[uint64]*Subscription)}
+}
+
+// Publish delivers the event to the matching subscriptions.
+// Slow subscribers miss the event instead of blocking the publisher.
+func (b *eventBus) Publish(event DocumentEvent) {
+	if event.Recorded.IsZero() {
+		event.Recorded = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, sub := range b.subs {
+		if !sub.matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			log.Warningf("dropped %s event of document %s for a slow subscriber", event.Type, event.DocumentID.String())
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events of the documents, owned by the account.
+func (b *eventBus) Subscribe(accountID identity.DID, documentIDs ...[]byte) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	sub := &Subscription{
+		accountID:   accountID,
+		documentIDs: documentIDs,
+		events:      make(chan DocumentEvent, subscriptionBuffer),
+	}
+
+	sub.close = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+		close(sub.events)
+	}
+
+	b.subs[id] = sub
+	return sub
+}
@@ -0,0 +1,55 @@
+// +build unit
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	did := testingidentity.GenerateRandomDID()
+	docID := utils.RandomSlice(32)
+	all := bus.Subscribe(did)
+	one := bus.Subscribe(did, docID)
+
+	// other account
+	bus.Publish(DocumentEvent{Type: PendingVersion, AccountID: testingidentity.GenerateRandomDID(), DocumentID: docID})
+
+	// other document
+	other := DocumentEvent{Type: PendingVersion, AccountID: did, DocumentID: utils.RandomSlice(32)}
+	bus.Publish(other)
+
+	// subscribed document
+	bus.Publish(DocumentEvent{Type: VersionCommitted, AccountID: did, DocumentID: docID})
+
+	event := <-all.Events()
+	assert.Equal(t, other.DocumentID, event.DocumentID)
+	assert.False(t, event.Recorded.IsZero())
+	event = <-all.Events()
+	assert.Equal(t, VersionCommitted, event.Type)
+	event = <-one.Events()
+	assert.Equal(t, VersionCommitted, event.Type)
+	assert.Equal(t, docID, []byte(event.DocumentID))
+	assert.Len(t, one.Events(), 0)
+
+	// closed subscription
+	one.Close()
+	one.Close()
+	_, ok := <-one.Events()
+	assert.False(t, ok)
+	bus.Publish(DocumentEvent{Type: VersionReceived, AccountID: did, DocumentID: docID})
+	event = <-all.Events()
+	assert.Equal(t, VersionReceived, event.Type)
+
+	// slow subscriber misses the events
+	for i := 0; i < subscriptionBuffer+1; i++ {
+		bus.Publish(DocumentEvent{Type: PendingVersion, AccountID: did, DocumentID: docID})
+	}
+	assert.Len(t, all.Events(), subscriptionBuffer)
+	all.Close()
+}
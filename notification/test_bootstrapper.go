@@ -0,0 +1,11 @@
+// +build unit integration
+
+package notification
+
+func (b Bootstrapper) TestBootstrap(ctx map[string]interface{}) error {
+	return b.Bootstrap(ctx)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
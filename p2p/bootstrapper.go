@@ -7,7 +7,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p/receiver"
+	"github.com/centrifuge/go-centrifuge/pending"
 )
 
 // Bootstrapper implements Bootstrapper with p2p details
@@ -40,8 +42,21 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("token registry is not initialised")
 	}
 
+	attachmentRepo, ok := ctx[documents.BootstrappedAttachmentRepository].(documents.AttachmentRepository)
+	if !ok {
+		return errors.New("attachment repository not initialised")
+	}
+
+	events, ok := ctx[notification.BootstrappedEventBus].(notification.EventBus)
+	if !ok {
+		return errors.New("event bus not initialised")
+	}
+
 	ctx[bootstrap.BootstrappedPeer] = &peer{config: cfgService, idService: idService, handlerCreator: func() *receiver.Handler {
-		return receiver.New(cfgService, receiver.HandshakeValidator(cfg.GetNetworkID(), idService), docSrv, tokenRegistry, idService)
+		// pending documents are bootstrapped after the peer
+		approvals, _ := ctx[pending.BootstrappedPendingDocumentService].(documents.ApprovalReceiver)
+		return receiver.New(
+			cfgService, receiver.HandshakeValidator(cfg.GetNetworkID(), idService), docSrv, tokenRegistry, idService, attachmentRepo, approvals, events)
 	}}
 	return nil
 }
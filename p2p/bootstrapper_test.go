@@ -3,6 +3,7 @@
 package p2p
 
 import (
+	"os"
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
@@ -11,6 +12,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/node"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/testingutils/documents"
@@ -33,9 +36,24 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	cs.On("GetConfig").Return(&configstore.NodeConfig{}, nil)
 	ids := new(testingcommons.MockIdentityService)
 	m[identity.BootstrappedDIDService] = ids
-	m[documents.BootstrappedDocumentService] = documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), ids, nil, nil)
+	m[documents.BootstrappedDocumentService] = documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), ids, nil, nil, notification.NewEventBus())
 	m[bootstrap.BootstrappedNFTService] = new(testingdocuments.MockRegistry)
 
+	// no attachment repository
+	err = b.Bootstrap(m)
+	assert.Error(t, err)
+
+	randomPath := leveldb.GetRandomTestStoragePath()
+	defer os.RemoveAll(randomPath)
+	db, err := leveldb.NewLevelDBStorage(randomPath)
+	assert.NoError(t, err)
+	m[documents.BootstrappedAttachmentRepository] = documents.NewAttachmentRepository(leveldb.NewLevelDBBlobStore(db))
+
+	// no event bus
+	err = b.Bootstrap(m)
+	assert.Error(t, err)
+
+	m[notification.BootstrappedEventBus] = notification.NewEventBus()
 	err = b.Bootstrap(m)
 	assert.Nil(t, err)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/p2p/common"
+	"github.com/centrifuge/go-centrifuge/p2p/receiver"
 	"github.com/centrifuge/go-centrifuge/version"
 	"github.com/golang/protobuf/proto"
 	libp2pPeer "github.com/libp2p/go-libp2p-peer"
@@ -165,6 +166,177 @@ func (s *peer) GetDocumentRequest(ctx context.Context, requesterID identity.DID,
 	return r, nil
 }
 
+// GetAttachmentRequest requests the content of a document attachment from the collaborator.
+func (s *peer) GetAttachmentRequest(ctx context.Context, requesterID identity.DID, in *p2pcommon.GetAttachmentRequest) (*p2pcommon.GetAttachmentResponse, error) {
+	nc, err := s.config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peerCtx, cancel := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
+	defer cancel()
+
+	tc, err := s.config.GetAccount(requesterID[:])
+	if err == nil {
+		// this is a local account
+		h := s.handlerCreator()
+		// the following context has to be different from the parent context since its initiating a local peer call
+		localCtx, err := contextutil.New(peerCtx, tc)
+		if err != nil {
+			return nil, err
+		}
+
+		return h.GetAttachment(localCtx, in, sender)
+	}
+
+	err = s.idService.Exists(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// this is a remote account
+	pid, err := s.getPeerID(ctx, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetAttachment, in)
+	if err != nil {
+		return nil, err
+	}
+
+	recv, err := s.mes.SendMessage(
+		ctx, pid,
+		envelope,
+		p2pcommon.ProtocolForDID(&requesterID))
+	if err != nil {
+		return nil, err
+	}
+
+	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle client error
+	if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
+		return nil, p2pcommon.ConvertClientError(recvEnvelope)
+	}
+
+	if !p2pcommon.MessageTypeGetAttachmentRep.Equals(recvEnvelope.Header.Type) {
+		return nil, errors.New("the received get attachment response is incorrect")
+	}
+
+	r := new(p2pcommon.GetAttachmentResponse)
+	err = proto.Unmarshal(recvEnvelope.Body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// RequestApproval sends the pending version to the approver to request its approval.
+func (s *peer) RequestApproval(ctx context.Context, approverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error) {
+	return s.sendApprovalMessage(ctx, approverID, in, p2pcommon.MessageTypeRequestApproval, p2pcommon.MessageTypeRequestApprovalRep,
+		func(h *receiver.Handler, ctx context.Context, sender identity.DID) (*p2ppb.AnchorDocumentResponse, error) {
+			return h.RequestApproval(ctx, in, sender)
+		})
+}
+
+// SendApproval sends the pending version, approved by the account, back to its owner.
+func (s *peer) SendApproval(ctx context.Context, ownerID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error) {
+	return s.sendApprovalMessage(ctx, ownerID, in, p2pcommon.MessageTypeSendApproval, p2pcommon.MessageTypeSendApprovalRep,
+		func(h *receiver.Handler, ctx context.Context, sender identity.DID) (*p2ppb.AnchorDocumentResponse, error) {
+			return h.SendApproval(ctx, in, sender)
+		})
+}
+
+// sendApprovalMessage sends the approval message to the receiver and returns the response of the receiver.
+// local handles the message when the receiver is an account on this node.
+func (s *peer) sendApprovalMessage(
+	ctx context.Context,
+	receiverID identity.DID,
+	in *p2ppb.AnchorDocumentRequest,
+	reqType, repType p2pcommon.MessageType,
+	local func(h *receiver.Handler, ctx context.Context, sender identity.DID) (*p2ppb.AnchorDocumentResponse, error)) (*p2ppb.AnchorDocumentResponse, error) {
+	nc, err := s.config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	selfDID, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peerCtx, cancel := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
+	defer cancel()
+
+	tc, err := s.config.GetAccount(receiverID[:])
+	if err == nil {
+		// this is a local account
+		h := s.handlerCreator()
+		// the following context has to be different from the parent context since its initiating a local peer call
+		localCtx, err := contextutil.New(peerCtx, tc)
+		if err != nil {
+			return nil, err
+		}
+		return local(h, localCtx, selfDID)
+	}
+
+	err = s.idService.Exists(ctx, receiverID)
+	if err != nil {
+		return nil, err
+	}
+
+	// this is a remote account
+	pid, err := s.getPeerID(ctx, receiverID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), reqType, in)
+	if err != nil {
+		return nil, err
+	}
+
+	recv, err := s.mes.SendMessage(
+		ctx, pid,
+		envelope,
+		p2pcommon.ProtocolForDID(&receiverID))
+	if err != nil {
+		return nil, err
+	}
+
+	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle client error
+	if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
+		return nil, p2pcommon.ConvertClientError(recvEnvelope)
+	}
+
+	if !repType.Equals(recvEnvelope.Header.Type) {
+		return nil, errors.New("the received %s response is incorrect", reqType)
+	}
+
+	r := new(p2ppb.AnchorDocumentResponse)
+	err = proto.Unmarshal(recvEnvelope.Body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
 // getPeerID returns peerID to contact the remote peer
 func (s *peer) getPeerID(ctx context.Context, id identity.DID) (libp2pPeer.ID, error) {
 	lastB58Key, err := s.idService.CurrentP2PKey(id)
@@ -232,7 +404,7 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 
 		resp, err = h.RequestDocumentSignature(localPeerCtx, &p2ppb.SignatureRequest{Document: &cd}, sender)
 		if err != nil {
-			return nil, err
+			return nil, errors.NewTypedError(documents.ErrSignatureRejected, err)
 		}
 		header = &p2ppb.Header{NodeVersion: version.GetVersion().String()}
 	} else {
@@ -261,22 +433,22 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 		}
 		// handle client error
 		if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
-			return nil, p2pcommon.ConvertClientError(recvEnvelope)
+			return nil, errors.NewTypedError(documents.ErrSignatureRejected, p2pcommon.ConvertClientError(recvEnvelope))
 		}
 		if !p2pcommon.MessageTypeRequestSignatureRep.Equals(recvEnvelope.Header.Type) {
-			return nil, errors.New("the received request signature response is incorrect")
+			return nil, errors.NewTypedError(documents.ErrSignatureRejected, errors.New("the received request signature response is incorrect"))
 		}
 		resp = new(p2ppb.SignatureResponse)
 		err = proto.Unmarshal(recvEnvelope.Body, resp)
 		if err != nil {
-			return nil, err
+			return nil, errors.NewTypedError(documents.ErrSignatureRejected, err)
 		}
 		header = recvEnvelope.Header
 	}
 
 	err = s.validateSignatureResp(model, collaborator, header, resp)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewTypedError(documents.ErrSignatureRejected, err)
 	}
 
 	log.Infof("Signature successfully received from %s\n", collaborator)
@@ -288,8 +460,24 @@ type signatureResponseWrap struct {
 	err  error
 }
 
+// signatureFailureStatus returns the status of the signature that failed to be collected with err.
+func signatureFailureStatus(ctx context.Context, err error) documents.SignatureStatus {
+	switch {
+	case errors.IsOfType(documents.ErrSignatureRejected, err):
+		return documents.SignatureRejected
+	case ctx.Err() != nil:
+		return documents.SignatureTimedOut
+	default:
+		return documents.SignatureUnreachable
+	}
+}
+
 func (s *peer) getSignatureAsync(ctx context.Context, model documents.Model, collaborator, sender identity.DID, out chan<- signatureResponseWrap) {
 	resp, err := s.getSignatureForDocument(ctx, model, collaborator, sender)
+	if err != nil {
+		err = documents.NewSignatureCollectionError(collaborator, signatureFailureStatus(ctx, err), err)
+	}
+
 	out <- signatureResponseWrap{
 		resp: resp,
 		err:  err,
@@ -9,6 +9,7 @@ import (
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/documents/generic"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -77,6 +78,7 @@ func TestGetSignatureForDocument_fail_version_check(t *testing.T) {
 	m.AssertExpectations(t)
 	assert.Error(t, err, "must fail")
 	assert.Contains(t, err.Error(), "Incompatible version")
+	assert.True(t, errors.IsOfType(documents.ErrSignatureRejected, err))
 	assert.Nil(t, resp, "must be nil")
 }
 
@@ -107,6 +109,16 @@ func TestGetSignatureForDocument_fail_did(t *testing.T) {
 
 }
 
+func TestSignatureFailureStatus(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, documents.SignatureRejected, signatureFailureStatus(ctx, errors.NewTypedError(documents.ErrSignatureRejected, errors.New("invalid signature"))))
+	assert.Equal(t, documents.SignatureUnreachable, signatureFailureStatus(ctx, errors.New("failed to connect")))
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, documents.SignatureTimedOut, signatureFailureStatus(ctx, errors.New("failed to connect")))
+}
+
 func getIDMocks(ctx context.Context, did identity.DID) *testingcommons.MockIdentityService {
 	idService := &testingcommons.MockIdentityService{}
 	idService.On("CurrentP2PKey", did).Return("5dsgvJGnvAfiR3K6HCBc4hcokSfmjj", nil)
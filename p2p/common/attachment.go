@@ -0,0 +1,36 @@
+package p2pcommon
+
+import (
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/golang/protobuf/proto"
+)
+
+// GetAttachmentRequest requests the content of an attachment of a document.
+// Access to the document is checked the same way as for GetDocumentRequest.
+type GetAttachmentRequest struct {
+	DocumentRequest *p2ppb.GetDocumentRequest `protobuf:"bytes,1,opt,name=document_request,json=documentRequest,proto3" json:"document_request,omitempty"`
+	Hash            []byte                    `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+// Reset resets the request to its zero value.
+func (m *GetAttachmentRequest) Reset() { *m = GetAttachmentRequest{} }
+
+// String returns the text representation of the request.
+func (m *GetAttachmentRequest) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the request as a protobuf message.
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+// GetAttachmentResponse holds the content of the requested attachment.
+type GetAttachmentResponse struct {
+	Content []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+// Reset resets the response to its zero value.
+func (m *GetAttachmentResponse) Reset() { *m = GetAttachmentResponse{} }
+
+// String returns the text representation of the response.
+func (m *GetAttachmentResponse) String() string { return proto.CompactTextString(m) }
+
+// ProtoMessage marks the response as a protobuf message.
+func (*GetAttachmentResponse) ProtoMessage() {}
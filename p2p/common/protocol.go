@@ -41,6 +41,18 @@ const (
 	MessageTypeGetDoc MessageType = "MessageTypeGetDoc"
 	//MessageTypeGetDocRep defines GetAnchoredDoc response type
 	MessageTypeGetDocRep MessageType = "MessageTypeGetDocRep"
+	// MessageTypeGetAttachment defines GetAttachment type
+	MessageTypeGetAttachment MessageType = "MessageTypeGetAttachment"
+	// MessageTypeGetAttachmentRep defines GetAttachment response type
+	MessageTypeGetAttachmentRep MessageType = "MessageTypeGetAttachmentRep"
+	// MessageTypeRequestApproval defines RequestApproval type
+	MessageTypeRequestApproval MessageType = "MessageTypeRequestApproval"
+	// MessageTypeRequestApprovalRep defines RequestApproval response type
+	MessageTypeRequestApprovalRep MessageType = "MessageTypeRequestApprovalRep"
+	// MessageTypeSendApproval defines SendApproval type
+	MessageTypeSendApproval MessageType = "MessageTypeSendApproval"
+	// MessageTypeSendApprovalRep defines SendApproval response type
+	MessageTypeSendApprovalRep MessageType = "MessageTypeSendApprovalRep"
 )
 
 //MessageTypes map for MessageTypeFromString function
@@ -53,6 +65,12 @@ var messageTypes = map[string]MessageType{
 	"MessageTypeSendAnchoredDocRep":  "MessageTypeSendAnchoredDocRep",
 	"MessageTypeGetDoc":              "MessageTypeGetDoc",
 	"MessageTypeGetDocRep":           "MessageTypeGetDocRep",
+	"MessageTypeGetAttachment":       "MessageTypeGetAttachment",
+	"MessageTypeGetAttachmentRep":    "MessageTypeGetAttachmentRep",
+	"MessageTypeRequestApproval":     "MessageTypeRequestApproval",
+	"MessageTypeRequestApprovalRep":  "MessageTypeRequestApprovalRep",
+	"MessageTypeSendApproval":        "MessageTypeSendApproval",
+	"MessageTypeSendApprovalRep":     "MessageTypeSendApprovalRep",
 }
 
 // Equals compares if string is of a particular MessageType
@@ -12,6 +12,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/utils/timeutils"
 	"github.com/ethereum/go-ethereum/common"
@@ -30,6 +31,9 @@ type Handler struct {
 	docSrv             documents.Service
 	tokenRegistry      documents.TokenRegistry
 	srvDID             identity.Service
+	attachmentRepo     documents.AttachmentRepository
+	approvals          documents.ApprovalReceiver
+	events             notification.EventBus
 }
 
 // New returns an implementation of P2PServiceServer
@@ -38,13 +42,19 @@ func New(
 	handshakeValidator ValidatorGroup,
 	docSrv documents.Service,
 	tokenRegistry documents.TokenRegistry,
-	srvDID identity.Service) *Handler {
+	srvDID identity.Service,
+	attachmentRepo documents.AttachmentRepository,
+	approvals documents.ApprovalReceiver,
+	events notification.EventBus) *Handler {
 	return &Handler{
 		config:             config,
 		handshakeValidator: handshakeValidator,
 		docSrv:             docSrv,
 		tokenRegistry:      tokenRegistry,
 		srvDID:             srvDID,
+		attachmentRepo:     attachmentRepo,
+		approvals:          approvals,
+		events:             events,
 	}
 }
 
@@ -94,6 +104,12 @@ func (srv *Handler) HandleInterceptor(ctx context.Context, peer peer.ID, protoc
 		return srv.HandleSendAnchoredDocument(ctx, peer, protoc, envelope)
 	case p2pcommon.MessageTypeGetDoc:
 		return srv.HandleGetDocument(ctx, peer, protoc, envelope)
+	case p2pcommon.MessageTypeGetAttachment:
+		return srv.HandleGetAttachment(ctx, peer, protoc, envelope)
+	case p2pcommon.MessageTypeRequestApproval:
+		return srv.HandleRequestApproval(ctx, peer, protoc, envelope)
+	case p2pcommon.MessageTypeSendApproval:
+		return srv.HandleSendApproval(ctx, peer, protoc, envelope)
 	default:
 		return srv.convertToErrorEnvelop(errors.New("MessageType [%s] not found", envelope.Header.Type))
 	}
@@ -149,9 +165,31 @@ func (srv *Handler) RequestDocumentSignature(ctx context.Context, sigReq *p2ppb.
 		return nil, err
 	}
 
+	srv.publishSignatureRequest(ctx, model, collaborator)
 	return &p2ppb.SignatureResponse{Signatures: signatures}, nil
 }
 
+// publishSignatureRequest publishes the version signed by the account on request of the collaborator.
+func (srv *Handler) publishSignatureRequest(ctx context.Context, model documents.Model, collaborator identity.DID) {
+	if srv.events == nil {
+		return
+	}
+
+	self, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return
+	}
+
+	srv.events.Publish(notification.DocumentEvent{
+		Type:         notification.SignatureRequested,
+		AccountID:    self,
+		DocumentID:   model.ID(),
+		VersionID:    model.CurrentVersion(),
+		Scheme:       model.Scheme(),
+		Collaborator: &collaborator,
+	})
+}
+
 // HandleSendAnchoredDocument handles the SendAnchoredDocument message
 func (srv *Handler) HandleSendAnchoredDocument(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
 	m := new(p2ppb.AnchorDocumentRequest)
@@ -251,6 +289,159 @@ func (srv *Handler) GetDocument(ctx context.Context, docReq *p2ppb.GetDocumentRe
 	return &p2ppb.GetDocumentResponse{Document: &cd}, nil
 }
 
+// HandleGetAttachment handles the GetAttachment message
+func (srv *Handler) HandleGetAttachment(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	m := new(p2pcommon.GetAttachmentRequest)
+	err := proto.Unmarshal(msg.Body, m)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	requesterDID, err := identity.NewDIDFromBytes(msg.Header.SenderId)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	res, err := srv.GetAttachment(ctx, m, requesterDID)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	nc, err := srv.config.GetConfig()
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	p2pEnv, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetAttachmentRep, res)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return p2pEnv, nil
+}
+
+// GetAttachment returns the content of the attachment if the requester can read the latest version of the document
+// and the attachment is referenced from it.
+func (srv *Handler) GetAttachment(ctx context.Context, attReq *p2pcommon.GetAttachmentRequest, requester identity.DID) (*p2pcommon.GetAttachmentResponse, error) {
+	if attReq == nil || attReq.DocumentRequest == nil {
+		return nil, errors.New("nil attachment request provided")
+	}
+
+	model, err := srv.docSrv.GetCurrentVersion(ctx, attReq.DocumentRequest.DocumentIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = srv.validateDocumentAccess(ctx, attReq.DocumentRequest, model, requester); err != nil {
+		return nil, err
+	}
+
+	if _, err = documents.FindAttachment(model, attReq.Hash); err != nil {
+		return nil, err
+	}
+
+	content, err := srv.attachmentRepo.Get(attReq.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p2pcommon.GetAttachmentResponse{Content: content}, nil
+}
+
+// HandleRequestApproval handles the RequestApproval message
+func (srv *Handler) HandleRequestApproval(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	return srv.handleApprovalMessage(ctx, msg, p2pcommon.MessageTypeRequestApprovalRep, srv.RequestApproval)
+}
+
+// HandleSendApproval handles the SendApproval message
+func (srv *Handler) HandleSendApproval(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	return srv.handleApprovalMessage(ctx, msg, p2pcommon.MessageTypeSendApprovalRep, srv.SendApproval)
+}
+
+// handleApprovalMessage unmarshals the approval message, handles it with handle and returns the response of repType.
+func (srv *Handler) handleApprovalMessage(
+	ctx context.Context,
+	msg *p2ppb.Envelope,
+	repType p2pcommon.MessageType,
+	handle func(ctx context.Context, docReq *p2ppb.AnchorDocumentRequest, sender identity.DID) (*p2ppb.AnchorDocumentResponse, error)) (*pb.P2PEnvelope, error) {
+	m := new(p2ppb.AnchorDocumentRequest)
+	err := proto.Unmarshal(msg.Body, m)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	sender, err := identity.NewDIDFromBytes(msg.Header.SenderId)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	res, err := handle(ctx, m, sender)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	nc, err := srv.config.GetConfig()
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	p2pEnv, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), repType, res)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return p2pEnv, nil
+}
+
+// RequestApproval receives the pending version of the owner awaiting the approval of the account.
+func (srv *Handler) RequestApproval(ctx context.Context, docReq *p2ppb.AnchorDocumentRequest, owner identity.DID) (*p2ppb.AnchorDocumentResponse, error) {
+	model, err := srv.deriveApprovalDocument(docReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.approvals.ReceiveApprovalRequest(ctx, model, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p2ppb.AnchorDocumentResponse{Accepted: true}, nil
+}
+
+// SendApproval receives the pending version of the account approved by the approver.
+// Only the approval of the approver is taken from the received version.
+func (srv *Handler) SendApproval(ctx context.Context, docReq *p2ppb.AnchorDocumentRequest, approver identity.DID) (*p2ppb.AnchorDocumentResponse, error) {
+	model, err := srv.deriveApprovalDocument(docReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.approvals.ReceiveApproval(ctx, model, approver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p2ppb.AnchorDocumentResponse{Accepted: true}, nil
+}
+
+// deriveApprovalDocument derives the pending version from the approval message.
+func (srv *Handler) deriveApprovalDocument(docReq *p2ppb.AnchorDocumentRequest) (documents.Model, error) {
+	if srv.approvals == nil {
+		return nil, errors.New("approvals are not supported")
+	}
+
+	if docReq == nil || docReq.Document == nil {
+		return nil, errors.New("nil document provided")
+	}
+
+	model, err := srv.docSrv.DeriveFromCoreDocument(*docReq.Document)
+	if err != nil {
+		return nil, errors.New("failed to derive from core doc: %v", err)
+	}
+
+	return model, nil
+}
+
 // validateDocumentAccess validates the GetDocument request against the AccessType indicated in the request
 func (srv *Handler) validateDocumentAccess(ctx context.Context, docReq *p2ppb.GetDocumentRequest, m documents.Model, peer identity.DID) error {
 	// checks which access type is relevant for the request
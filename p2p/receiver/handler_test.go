@@ -23,6 +23,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
@@ -42,11 +43,12 @@ import (
 )
 
 var (
-	handler       *Handler
-	registry      *documents.ServiceRegistry
-	cfg           config.Configuration
-	mockIDService *testingcommons.MockIdentityService
-	defaultPID    libp2pPeer.ID
+	handler        *Handler
+	registry       *documents.ServiceRegistry
+	cfg            config.Configuration
+	mockIDService  *testingcommons.MockIdentityService
+	defaultPID     libp2pPeer.ID
+	attachmentRepo documents.AttachmentRepository
 )
 
 func TestMain(m *testing.M) {
@@ -64,6 +66,7 @@ func TestMain(m *testing.M) {
 		&queue.Bootstrapper{},
 		jobsv1.Bootstrapper{},
 		&anchors.Bootstrapper{},
+		notification.Bootstrapper{},
 		documents.Bootstrapper{},
 	}
 	errors.MaskErrs = false
@@ -74,11 +77,13 @@ func TestMain(m *testing.M) {
 	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
 	cfgService := ctx[config.BootstrappedConfigStorage].(config.Service)
 	registry = ctx[documents.BootstrappedRegistry].(*documents.ServiceRegistry)
-	docSrv := documents.DefaultService(cfg, nil, nil, registry, mockIDService, nil, nil)
+	docSrv := documents.DefaultService(cfg, nil, nil, registry, mockIDService, nil, nil, notification.NewEventBus())
 	_, pub, _ := crypto.GenerateEd25519Key(rand.Reader)
 	defaultPID, _ = libp2pPeer.IDFromPublicKey(pub)
 	mockIDService.On("ValidateKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	handler = New(cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, new(testingdocuments.MockRegistry), mockIDService)
+	attachmentRepo = ctx[documents.BootstrappedAttachmentRepository].(documents.AttachmentRepository)
+	handler = New(
+		cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, new(testingdocuments.MockRegistry), mockIDService, attachmentRepo, nil, notification.NewEventBus())
 	result := m.Run()
 	bootstrap.RunTestTeardown(ibootstappers)
 	os.Exit(result)
@@ -91,7 +96,7 @@ func TestHandler_HandleInterceptor_noConfig(t *testing.T) {
 	assert.NoError(t, err)
 	fkRepo := configstore.NewDBRepository(leveldb.NewLevelDBRepository(db))
 	fkCfg := configstore.DefaultService(fkRepo, mockIDService)
-	hndlr := New(fkCfg, nil, nil, nil, nil)
+	hndlr := New(fkCfg, nil, nil, nil, nil, nil, nil, notification.NewEventBus())
 	resp, err := hndlr.HandleInterceptor(context.Background(), libp2pPeer.ID("SomePeer"), protocol.ID("protocolX"), &protocolpb.P2PEnvelope{})
 	assert.NoError(t, err)
 	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
@@ -260,6 +265,59 @@ func TestP2PService_basicChecks(t *testing.T) {
 
 }
 
+func TestHandler_GetAttachment(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	h := New(nil, nil, docSrv, nil, mockIDService, attachmentRepo, nil, notification.NewEventBus())
+	requester := testingidentity.GenerateRandomDID()
+	docID := utils.RandomSlice(32)
+	content := utils.RandomSlice(64)
+	att, err := documents.NewAttachment("invoice.pdf", "application/pdf", content)
+	assert.NoError(t, err)
+	attr, err := documents.NewAttachmentAttribute(att)
+	assert.NoError(t, err)
+	req := &p2pcommon.GetAttachmentRequest{
+		DocumentRequest: &p2ppb.GetDocumentRequest{
+			DocumentIdentifier: docID,
+			AccessType:         p2ppb.AccessType_ACCESS_TYPE_REQUESTER_VERIFICATION,
+		},
+		Hash: att.Hash,
+	}
+
+	// nil request
+	_, err = h.GetAttachment(context.Background(), nil, requester)
+	assert.Error(t, err)
+
+	// no read access
+	model := new(testingdocuments.MockModel)
+	model.On("AccountCanRead", requester).Return(false).Once()
+	docSrv.On("GetCurrentVersion", docID).Return(model, nil)
+	_, err = h.GetAttachment(context.Background(), req, requester)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrAccessDenied, err))
+
+	// attachment not referenced by the document
+	model.On("AccountCanRead", requester).Return(true)
+	model.On("GetAttributes").Return(nil).Once()
+	_, err = h.GetAttachment(context.Background(), req, requester)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrAttachmentNotFound, err))
+
+	// content missing
+	model.On("GetAttributes").Return([]documents.Attribute{attr})
+	_, err = h.GetAttachment(context.Background(), req, requester)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrAttachmentNotFound, err))
+
+	// success
+	_, err = attachmentRepo.Create(content)
+	assert.NoError(t, err)
+	resp, err := h.GetAttachment(context.Background(), req, requester)
+	assert.NoError(t, err)
+	assert.Equal(t, content, resp.Content)
+	docSrv.AssertExpectations(t)
+	model.AssertExpectations(t)
+}
+
 func TestConvertToErrorEnvelope(t *testing.T) {
 	errPayload := errors.New("Error for P2P")
 	envelope, err := handler.convertToErrorEnvelop(errPayload)
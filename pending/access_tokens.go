@@ -0,0 +1,89 @@
+package pending
+
+import (
+	"bytes"
+	"context"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// GrantAccessToken adds an access token to the pending document granting the grantee read access to the document tokenDocID.
+// The document tokenDocID is expected to be committed already.
+func (s service) GrantAccessToken(ctx context.Context, docID []byte, grantee identity.DID, tokenDocID []byte) (*coredocumentpb.AccessToken, error) {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.docSrv.GetCurrentVersion(ctx, tokenDocID); err != nil {
+		return nil, documents.ErrDocumentNotFound
+	}
+
+	at, err := doc.GrantAccessToken(ctx, documents.AccessTokenParams{
+		Grantee:            grantee.String(),
+		DocumentIdentifier: hexutil.Encode(tokenDocID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return at, s.update(ctx, accID[:], docID, doc)
+}
+
+// GetAccessTokens returns the access tokens of the pending document.
+func (s service) GetAccessTokens(ctx context.Context, docID []byte) ([]*coredocumentpb.AccessToken, error) {
+	doc, _, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.GetAccessTokens()
+}
+
+// RevokeAccessToken removes the access token from the pending document.
+// Revocation takes effect once the pending document is committed.
+func (s service) RevokeAccessToken(ctx context.Context, docID, tokenID []byte) error {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	err = doc.RevokeAccessToken(tokenID)
+	if err != nil {
+		return err
+	}
+
+	return s.update(ctx, accID[:], docID, doc)
+}
+
+// GetWithAccessToken requests the document from the granter using the access token in the delegating document.
+// The delegating document, holding the access token, is expected to be committed and shared with the granter.
+func (s service) GetWithAccessToken(ctx context.Context, granter identity.DID, tokenID, docID, delegatingDocID []byte) (documents.Model, error) {
+	resp, err := s.processor.RequestDocumentWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentNotFound, err)
+	}
+
+	if resp == nil || resp.Document == nil {
+		return nil, documents.ErrDocumentInvalid
+	}
+
+	doc, err := s.docSrv.DeriveFromCoreDocument(*resp.Document)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalid, err)
+	}
+
+	if !bytes.Equal(doc.ID(), docID) {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalid, errors.New("received a different document"))
+	}
+
+	if err := s.receivedValidator().Validate(nil, doc); err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalid, err)
+	}
+
+	return doc, nil
+}
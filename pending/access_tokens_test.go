@@ -0,0 +1,138 @@
+// +build unit
+
+package pending
+
+import (
+	"context"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	p2ppb "github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_GrantAccessToken(t *testing.T) {
+	s := service{}
+	docID, tokenDocID := utils.RandomSlice(32), utils.RandomSlice(32)
+	grantee := testingidentity.GenerateRandomDID()
+
+	// missing did from context
+	_, err := s.GrantAccessToken(context.Background(), docID, grantee, tokenDocID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing token document
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	d := new(documents.MockModel)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(d, nil)
+	s.pendingRepo = repo
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", tokenDocID).Return(nil, documents.ErrDocumentNotFound).Once()
+	s.docSrv = docSrv
+	_, err = s.GrantAccessToken(ctx, docID, grantee, tokenDocID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// success
+	params := documents.AccessTokenParams{Grantee: grantee.String(), DocumentIdentifier: hexutil.Encode(tokenDocID)}
+	at := &coredocumentpb.AccessToken{Identifier: utils.RandomSlice(32)}
+	docSrv.On("GetCurrentVersion", tokenDocID).Return(new(documents.MockModel), nil).Once()
+	d.On("GrantAccessToken", ctx, params).Return(at, nil).Once()
+	repo.On("Update", did[:], docID, d).Return(nil).Once()
+	gat, err := s.GrantAccessToken(ctx, docID, grantee, tokenDocID)
+	assert.NoError(t, err)
+	assert.Equal(t, at, gat)
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
+func TestService_RevokeAccessToken(t *testing.T) {
+	s := service{}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID, tokenID := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := new(documents.MockModel)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(d, nil)
+	s.pendingRepo = repo
+
+	// missing token
+	d.On("RevokeAccessToken", tokenID).Return(documents.ErrAccessTokenNotFound).Once()
+	err := s.RevokeAccessToken(ctx, docID, tokenID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrAccessTokenNotFound, err))
+
+	// success
+	d.On("RevokeAccessToken", tokenID).Return(nil).Once()
+	repo.On("Update", did[:], docID, d).Return(nil).Once()
+	assert.NoError(t, s.RevokeAccessToken(ctx, docID, tokenID))
+
+	// list
+	ats := []*coredocumentpb.AccessToken{{Identifier: utils.RandomSlice(32)}}
+	d.On("GetAccessTokens").Return(ats, nil).Once()
+	gats, err := s.GetAccessTokens(ctx, docID)
+	assert.NoError(t, err)
+	assert.Equal(t, ats, gats)
+	repo.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
+func TestService_GetWithAccessToken(t *testing.T) {
+	ctx := context.Background()
+	granter := testingidentity.GenerateRandomDID()
+	tokenID, docID, delegatingDocID := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+	proc := new(testingdocuments.MockRequestProcessor)
+	docSrv := new(testingdocuments.MockService)
+	valid := true
+	s := service{docSrv: docSrv, processor: proc, receivedValidator: func() documents.ValidatorGroup {
+		return documents.ValidatorGroup{documents.ValidatorFunc(func(_, _ documents.Model) error {
+			if !valid {
+				return errors.New("not anchored")
+			}
+
+			return nil
+		})}
+	}}
+
+	// request failed
+	proc.On("RequestDocumentWithAccessToken", granter, tokenID, docID, delegatingDocID).Return(nil, errors.New("denied")).Once()
+	_, err := s.GetWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// different document
+	cd := &coredocumentpb.CoreDocument{DocumentIdentifier: docID}
+	resp := &p2ppb.GetDocumentResponse{Document: cd}
+	proc.On("RequestDocumentWithAccessToken", granter, tokenID, docID, delegatingDocID).Return(resp, nil)
+	d := new(documents.MockModel)
+	d.On("ID").Return(utils.RandomSlice(32)).Once()
+	docSrv.On("DeriveFromCoreDocument", mock.Anything).Return(d, nil)
+	_, err = s.GetWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// invalid document
+	d.On("ID").Return(docID)
+	valid = false
+	_, err = s.GetWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+
+	// success
+	valid = true
+	m, err := s.GetWithAccessToken(ctx, granter, tokenID, docID, delegatingDocID)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+	proc.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
@@ -0,0 +1,297 @@
+package pending
+
+import (
+	"context"
+	"time"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/golang/protobuf/proto"
+)
+
+// PendingApproval holds the approval status of a pending version owned by an account.
+type PendingApproval struct {
+	Owner      identity.DID
+	DocumentID []byte
+	VersionID  []byte
+	Status     documents.ApprovalStatus
+}
+
+// SetApprovalPolicy requires the pending version to be approved by the members of the role before it can be committed.
+// The pending version is sent to the members of the role to request their approval.
+func (s service) SetApprovalPolicy(ctx context.Context, docID []byte, p documents.ApprovalPolicy) (documents.ApprovalStatus, error) {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return documents.ApprovalStatus{}, err
+	}
+
+	err = documents.SetApprovalPolicy(doc, p)
+	if err != nil {
+		return documents.ApprovalStatus{}, err
+	}
+
+	status, err := documents.GetApprovalStatus(doc, p)
+	if err != nil {
+		return status, err
+	}
+
+	err = s.update(ctx, accID[:], docID, doc)
+	if err != nil {
+		return status, err
+	}
+
+	s.requestApprovals(ctx, doc, p)
+	return status, nil
+}
+
+// requestApprovals sends the pending version to the active members of the approval role.
+// Failures are logged since the approval requests are sent again when the policy is set again.
+func (s service) requestApprovals(ctx context.Context, doc documents.Model, p documents.ApprovalPolicy) {
+	role, err := doc.GetRole(p.RoleKey)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	now := time.Now().UTC()
+	var approvers []identity.DID
+	for _, c := range role.Collaborators {
+		approver, err := identity.NewDIDFromBytes(c)
+		if err != nil || !documents.IsApprover(doc, p, approver, now) {
+			continue
+		}
+
+		approvers = append(approvers, approver)
+	}
+
+	if len(approvers) < 1 {
+		return
+	}
+
+	err = s.processor.RequestApprovals(ctx, doc, approvers)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// GetApprovalStatus returns the approval policy and the approvers of the pending version.
+func (s service) GetApprovalStatus(ctx context.Context, docID []byte) (documents.ApprovalStatus, error) {
+	doc, _, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return documents.ApprovalStatus{}, err
+	}
+
+	p, ok, err := documents.GetApprovalPolicy(doc)
+	if err != nil {
+		return documents.ApprovalStatus{}, err
+	}
+
+	if !ok {
+		return documents.ApprovalStatus{}, documents.ErrApprovalPolicyMissing
+	}
+
+	return documents.GetApprovalStatus(doc, p)
+}
+
+// ReceiveApprovalRequest stores the pending version of the owner awaiting the approval of the account.
+// Requests from owners that are not collaborators of the pending version are rejected.
+func (s service) ReceiveApprovalRequest(ctx context.Context, model documents.Model, owner identity.DID) error {
+	self, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return contextutil.ErrDIDMissingFromContext
+	}
+
+	ok, err := model.IsDIDCollaborator(owner)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.NewTypedError(documents.ErrInvalidApproval, errors.New("%s is not a collaborator of the document", owner.String()))
+	}
+
+	p, ok, err := documents.GetApprovalPolicy(model)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return documents.ErrApprovalPolicyMissing
+	}
+
+	if !documents.IsApprover(model, p, self, time.Now().UTC()) {
+		return errors.NewTypedError(documents.ErrInvalidApproval, errors.New("%s is not an approver of the document", self.String()))
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(&cd)
+	if err != nil {
+		return err
+	}
+
+	return s.pendingRepo.StoreApprovalRequest(self[:], &ApprovalRequest{Owner: owner, DocumentID: model.ID(), Document: data})
+}
+
+// ReceiveApproval adds the approval of the approver, taken from the model, to the pending version of the account.
+// The approval is added only if its signature is valid and the pending version was not changed since the approval was requested.
+func (s service) ReceiveApproval(ctx context.Context, model documents.Model, approver identity.DID) error {
+	doc, accID, err := s.getDocumentAndAccount(ctx, model.ID())
+	if err != nil {
+		return err
+	}
+
+	etag, err := ETag(doc)
+	if err != nil {
+		return err
+	}
+
+	approval, err := documents.GetApproval(model, approver)
+	if err != nil {
+		return err
+	}
+
+	err = documents.ValidateApproval(s.idService, doc.ID(), approval, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	err = documents.AddApproval(doc, approval)
+	if err != nil {
+		return err
+	}
+
+	err = s.pendingRepo.UpdateIfMatch(accID[:], doc.ID(), doc, etag)
+	if err != nil {
+		return err
+	}
+
+	s.publishPending(accID[:], doc)
+	return nil
+}
+
+// Approve approves, on behalf of the account, the pending versions of the document awaiting its approval.
+// The approval is sent to the owner of each pending version.
+func (s service) Approve(ctx context.Context, docID []byte) ([]PendingApproval, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	approver, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, err := s.pendingRepo.GetApprovalRequests(approver[:], docID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reqs) < 1 {
+		return nil, documents.ErrDocumentNotFound
+	}
+
+	var approvals []PendingApproval
+	for _, req := range reqs {
+		doc, err := s.deriveApprovalRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		attr, err := documents.NewApproval(doc, approver, acc)
+		if err != nil {
+			return nil, err
+		}
+
+		err = doc.AddAttributes(documents.CollaboratorsAccess{}, false, attr)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.processor.SendApproval(ctx, req.Owner, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.pendingRepo.DeleteApprovalRequest(approver[:], req.DocumentID, req.Owner)
+		if err != nil {
+			return nil, err
+		}
+
+		pa, err := toPendingApproval(req.Owner, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, pa)
+	}
+
+	return approvals, nil
+}
+
+// ListPendingApprovals returns the pending versions, of the other accounts, awaiting the approval of the account.
+func (s service) ListPendingApprovals(ctx context.Context) ([]PendingApproval, error) {
+	approver, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	reqs, err := s.pendingRepo.GetApprovalRequests(approver[:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var approvals []PendingApproval
+	for _, req := range reqs {
+		doc, err := s.deriveApprovalRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		pa, err := toPendingApproval(req.Owner, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, pa)
+	}
+
+	return approvals, nil
+}
+
+// deriveApprovalRequest derives the pending version of the approval request.
+func (s service) deriveApprovalRequest(req *ApprovalRequest) (documents.Model, error) {
+	cd := new(coredocumentpb.CoreDocument)
+	err := proto.Unmarshal(req.Document, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.docSrv.DeriveFromCoreDocument(*cd)
+}
+
+func toPendingApproval(owner identity.DID, doc documents.Model) (pa PendingApproval, err error) {
+	p, _, err := documents.GetApprovalPolicy(doc)
+	if err != nil {
+		return pa, err
+	}
+
+	status, err := documents.GetApprovalStatus(doc, p)
+	if err != nil {
+		return pa, err
+	}
+
+	return PendingApproval{
+		Owner:      owner,
+		DocumentID: doc.ID(),
+		VersionID:  doc.CurrentVersion(),
+		Status:     status,
+	}, nil
+}
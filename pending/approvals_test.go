@@ -0,0 +1,299 @@
+// +build unit
+
+package pending
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func approvalPolicyAttribute(t *testing.T, p documents.ApprovalPolicy) documents.Attribute {
+	d, err := json.Marshal(p)
+	assert.NoError(t, err)
+	attr, err := documents.NewStringAttribute("_approval_policy", documents.AttrString, string(d))
+	assert.NoError(t, err)
+	return attr
+}
+
+func TestService_SetApprovalPolicy(t *testing.T) {
+	s := service{}
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1}
+
+	// missing did from context
+	docID := utils.RandomSlice(32)
+	_, err := s.SetApprovalPolicy(context.Background(), docID, p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing doc
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(nil, errors.New("failed")).Once()
+	s.pendingRepo = repo
+	_, err = s.SetApprovalPolicy(ctx, docID, p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// missing role
+	d := new(documents.MockModel)
+	repo.On("Get", did[:], docID).Return(d, nil)
+	d.On("GetRole", p.RoleKey.Bytes()).Return(nil, documents.ErrRoleNotExist).Once()
+	_, err = s.SetApprovalPolicy(ctx, docID, p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApprovalPolicy, err))
+
+	// success
+	approver := testingidentity.GenerateRandomDID()
+	role := &coredocumentpb.Role{RoleKey: p.RoleKey, Collaborators: [][]byte{approver[:]}}
+	d.On("GetRole", p.RoleKey.Bytes()).Return(role, nil).Twice()
+	d.On("RoleValidity", p.RoleKey.Bytes()).Return(documents.Validity{}, documents.Validity{}, nil).Once()
+	d.On("AddAttributes", documents.CollaboratorsAccess{}, false, []documents.Attribute{approvalPolicyAttribute(t, p)}).Return(nil).Once()
+	d.On("GetAttributes").Return(nil)
+	d.On("CalculateSigningRoot").Return(utils.RandomSlice(32), nil).Once()
+	repo.On("Update", did[:], docID, d).Return(nil).Once()
+	proc := new(testingdocuments.MockRequestProcessor)
+	proc.On("RequestApprovals", d, []identity.DID{approver}).Return(errors.New("failed to send")).Once()
+	s.processor = proc
+	st, err := s.SetApprovalPolicy(ctx, docID, p)
+	assert.NoError(t, err)
+	assert.Equal(t, p, st.Policy)
+	assert.False(t, st.Approved())
+	repo.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
+func TestService_GetApprovalStatus(t *testing.T) {
+	s := service{}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID := utils.RandomSlice(32)
+	repo := new(mockRepo)
+	d := new(documents.MockModel)
+	repo.On("Get", did[:], docID).Return(d, nil)
+	s.pendingRepo = repo
+
+	// missing policy
+	d.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute).Once()
+	_, err := s.GetApprovalStatus(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrApprovalPolicyMissing, err))
+
+	// success
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 2}
+	d.On("GetAttribute", mock.Anything).Return(approvalPolicyAttribute(t, p), nil).Once()
+	d.On("GetAttributes").Return(nil)
+	d.On("CalculateSigningRoot").Return(utils.RandomSlice(32), nil).Once()
+	st, err := s.GetApprovalStatus(ctx, docID)
+	assert.NoError(t, err)
+	assert.Equal(t, p, st.Policy)
+	assert.Len(t, st.Approvers, 0)
+	d.AssertExpectations(t)
+}
+
+// approvalModel returns a mocked pending version requiring the approval of the approver.
+func approvalModel(t *testing.T, approver identity.DID, p documents.ApprovalPolicy, docID, root []byte) *documents.MockModel {
+	d := new(documents.MockModel)
+	role := &coredocumentpb.Role{RoleKey: p.RoleKey, Collaborators: [][]byte{approver[:]}}
+	d.On("ID").Return(docID)
+	d.On("CurrentVersion").Return(utils.RandomSlice(32))
+	d.On("GetAttributes").Return(nil)
+	d.On("CalculateSigningRoot").Return(root, nil)
+	d.On("GetAttribute", mock.Anything).Return(approvalPolicyAttribute(t, p), nil)
+	d.On("GetRole", p.RoleKey.Bytes()).Return(role, nil)
+	d.On("RoleValidity", p.RoleKey.Bytes()).Return(documents.Validity{}, documents.Validity{}, nil)
+	return d
+}
+
+func TestService_ReceiveApprovalRequest(t *testing.T) {
+	repo := new(mockRepo)
+	s := service{pendingRepo: repo}
+	owner := testingidentity.GenerateRandomDID()
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1}
+	docID := utils.RandomSlice(32)
+
+	// missing did from context
+	d := approvalModel(t, did, p, docID, utils.RandomSlice(32))
+	err := s.ReceiveApprovalRequest(context.Background(), d, owner)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// owner not a collaborator
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	d.On("IsDIDCollaborator", owner).Return(false, nil).Once()
+	err = s.ReceiveApprovalRequest(ctx, d, owner)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApproval, err))
+
+	// not an approver
+	other := approvalModel(t, testingidentity.GenerateRandomDID(), p, docID, utils.RandomSlice(32))
+	other.On("IsDIDCollaborator", owner).Return(true, nil).Once()
+	err = s.ReceiveApprovalRequest(ctx, other, owner)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApproval, err))
+
+	// success
+	d.On("IsDIDCollaborator", owner).Return(true, nil).Once()
+	d.On("PackCoreDocument").Return(coredocumentpb.CoreDocument{DocumentIdentifier: docID}, nil).Once()
+	repo.On("StoreApprovalRequest", did[:], mock.MatchedBy(func(req *ApprovalRequest) bool {
+		return req.Owner.Equal(owner) && bytes.Equal(req.DocumentID, docID) && len(req.Document) > 0
+	})).Return(nil).Once()
+	assert.NoError(t, s.ReceiveApprovalRequest(ctx, d, owner))
+	repo.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
+func TestService_ReceiveApproval(t *testing.T) {
+	repo := new(mockRepo)
+	idSrv := new(testingcommons.MockIdentityService)
+	s := service{pendingRepo: repo, idService: idSrv}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	acc, err := contextutil.Account(ctx)
+	assert.NoError(t, err)
+	approver := testingidentity.GenerateRandomDID()
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1}
+	docID, root := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := approvalModel(t, approver, p, docID, root)
+	d.On("JSON").Return([]byte("{}"), nil)
+	repo.On("Get", did[:], docID).Return(d, nil)
+
+	// missing approval
+	received := new(documents.MockModel)
+	received.On("ID").Return(docID)
+	received.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute).Once()
+	err = s.ReceiveApproval(ctx, received, approver)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApproval, err))
+
+	// invalid signature
+	approval, err := documents.NewApproval(d, approver, acc)
+	assert.NoError(t, err)
+	received.On("GetAttribute", mock.Anything).Return(approval, nil).Once()
+	idSrv.On("ValidateSignature", approver, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("key not linked to identity")).Once()
+	err = s.ReceiveApproval(ctx, received, approver)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApproval, err))
+
+	// approval of another version
+	idSrv.On("ValidateSignature", approver, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	approval, err = documents.NewApproval(approvalModel(t, approver, p, docID, root), approver, acc)
+	assert.NoError(t, err)
+	received.On("GetAttribute", mock.Anything).Return(approval, nil).Once()
+	err = s.ReceiveApproval(ctx, received, approver)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidApproval, err))
+
+	// draft changed since the approval was requested
+	approval, err = documents.NewApproval(d, approver, acc)
+	assert.NoError(t, err)
+	received.On("GetAttribute", mock.Anything).Return(approval, nil)
+	d.On("AddAttributes", documents.CollaboratorsAccess{}, false, []documents.Attribute{approval}).Return(nil)
+	repo.On("UpdateIfMatch", did[:], docID, d, mock.Anything).Return(ErrETagMismatch).Once()
+	err = s.ReceiveApproval(ctx, received, approver)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrETagMismatch, err))
+
+	// success
+	repo.On("UpdateIfMatch", did[:], docID, d, mock.Anything).Return(nil).Once()
+	assert.NoError(t, s.ReceiveApproval(ctx, received, approver))
+	repo.AssertExpectations(t)
+	idSrv.AssertExpectations(t)
+}
+
+func TestService_Approve(t *testing.T) {
+	s := service{}
+
+	// missing account from context
+	docID := utils.RandomSlice(32)
+	_, err := s.Approve(context.Background(), docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// nothing to approve
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("GetApprovalRequests", did[:], docID).Return(nil, nil).Once()
+	s.pendingRepo = repo
+	_, err = s.Approve(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// failed to send the approval
+	owner := testingidentity.GenerateRandomDID()
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1}
+	d := approvalModel(t, did, p, docID, utils.RandomSlice(32))
+	d.On("AddAttributes", documents.CollaboratorsAccess{}, false, mock.Anything).Return(nil)
+	cd := coredocumentpb.CoreDocument{DocumentIdentifier: docID}
+	data, err := proto.Marshal(&cd)
+	assert.NoError(t, err)
+	req := &ApprovalRequest{Owner: owner, DocumentID: docID, Document: data}
+	repo.On("GetApprovalRequests", did[:], docID).Return([]*ApprovalRequest{req}, nil)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("DeriveFromCoreDocument", mock.Anything).Return(d, nil)
+	proc := new(testingdocuments.MockRequestProcessor)
+	proc.On("SendApproval", owner, d).Return(errors.New("failed to send")).Once()
+	s.docSrv, s.processor = docSrv, proc
+	_, err = s.Approve(ctx, docID)
+	assert.Error(t, err)
+
+	// success
+	proc.On("SendApproval", owner, d).Return(nil).Once()
+	repo.On("DeleteApprovalRequest", did[:], docID, owner).Return(nil).Once()
+	approvals, err := s.Approve(ctx, docID)
+	assert.NoError(t, err)
+	assert.Len(t, approvals, 1)
+	assert.Equal(t, owner, approvals[0].Owner)
+	assert.Equal(t, docID, approvals[0].DocumentID)
+	assert.Equal(t, p, approvals[0].Status.Policy)
+	repo.AssertExpectations(t)
+	proc.AssertExpectations(t)
+}
+
+func TestService_ListPendingApprovals(t *testing.T) {
+	s := service{}
+
+	// missing did from context
+	_, err := s.ListPendingApprovals(context.Background())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// failed to get the requests
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("GetApprovalRequests", did[:], []byte(nil)).Return(nil, errors.New("failed")).Once()
+	s.pendingRepo = repo
+	_, err = s.ListPendingApprovals(ctx)
+	assert.Error(t, err)
+
+	// success
+	owner := testingidentity.GenerateRandomDID()
+	p := documents.ApprovalPolicy{RoleKey: utils.RandomSlice(32), Threshold: 1}
+	docID := utils.RandomSlice(32)
+	d := approvalModel(t, did, p, docID, utils.RandomSlice(32))
+	repo.On("GetApprovalRequests", did[:], []byte(nil)).Return([]*ApprovalRequest{{Owner: owner, DocumentID: docID}}, nil).Once()
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("DeriveFromCoreDocument", mock.Anything).Return(d, nil).Once()
+	s.docSrv = docSrv
+	approvals, err := s.ListPendingApprovals(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, approvals, 1)
+	assert.Equal(t, owner, approvals[0].Owner)
+	assert.Equal(t, docID, approvals[0].DocumentID)
+	repo.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
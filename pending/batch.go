@@ -0,0 +1,257 @@
+package pending
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrInvalidBatch must be used when one or more documents of the batch cannot be committed.
+	ErrInvalidBatch = errors.Error("invalid batch of documents")
+
+	// batchCommitConcurrency is the maximum number of documents of a batch that are anchored at the same time.
+	batchCommitConcurrency = 10
+
+	// batchCommitTaskPrefix is the prefix of the task name of a document in the batch commit job.
+	batchCommitTaskPrefix = "commit document "
+)
+
+// BatchCommitStatus holds the progress of a document committed in a batch.
+type BatchCommitStatus struct {
+	DocumentID []byte
+
+	// JobID is the anchor job of the document. Nil until the document is committed.
+	JobID jobs.JobID
+
+	Status  jobs.Status
+	Message string
+}
+
+// batchCommitTaskName returns the task name of the document in the batch commit job.
+func batchCommitTaskName(docID []byte) string {
+	return batchCommitTaskPrefix + hexutil.Encode(docID)
+}
+
+// validateBatch validates all the pending documents against their latest committed versions.
+// Errors out if any of the documents cannot be committed.
+func (s service) validateBatch(ctx context.Context, did identity.DID, docIDs [][]byte) (models []documents.Model, err error) {
+	if len(docIDs) < 1 {
+		return nil, errors.NewTypedError(ErrInvalidBatch, errors.New("no documents"))
+	}
+
+	seen := make(map[string]struct{})
+	for _, id := range docIDs {
+		hid := hexutil.Encode(id)
+		if _, ok := seen[hid]; ok {
+			err = errors.AppendError(err, errors.New("%s: duplicate document", hid))
+			continue
+		}
+		seen[hid] = struct{}{}
+
+		doc, gerr := s.pendingRepo.Get(did[:], id)
+		if gerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", hid, documents.ErrDocumentNotFound))
+			continue
+		}
+
+		old, gerr := s.latestVersion(ctx, id)
+		if gerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", hid, gerr))
+			continue
+		}
+
+		if aerr := documents.ValidateApprovals(old, doc); aerr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", hid, aerr))
+			continue
+		}
+
+		if verr := s.docSrv.Validate(ctx, doc, old); verr != nil {
+			err = errors.AppendError(err, errors.New("%s: %v", hid, verr))
+			continue
+		}
+
+		models = append(models, doc)
+	}
+
+	if err != nil {
+		return nil, errors.NewTypedError(ErrInvalidBatch, err)
+	}
+
+	return models, nil
+}
+
+// CommitBatch validates all the documents first and commits them within a single job.
+// Nothing is committed if any of the documents is invalid.
+func (s service) CommitBatch(ctx context.Context, docIDs [][]byte) (jobs.JobID, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return jobs.NilJobID(), contextutil.ErrDIDMissingFromContext
+	}
+
+	models, err := s.validateBatch(ctx, did, docIDs)
+	if err != nil {
+		return jobs.NilJobID(), err
+	}
+
+	jobID, _, err := s.jobManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "commit documents",
+		s.batchCommitJob(contextutil.Copy(ctx), models))
+	if err != nil {
+		return jobs.NilJobID(), err
+	}
+
+	return jobID, nil
+}
+
+// batchCommitJob commits the documents, with bounded concurrency, and waits for their anchor jobs.
+// Anchor job of each document is added to the job along with the document progress.
+func (s service) batchCommitJob(ctx context.Context, models []documents.Model) func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+	return func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+		// progress is informational, documents are committed regardless
+		for _, m := range models {
+			if err := jobMan.UpdateTaskStatus(accountID, jobID, jobs.Pending, batchCommitTaskName(m.ID()), "validated"); err != nil {
+				log.Error(err)
+			}
+		}
+
+		// job is updated by read-modify-write, so the updates of the documents are serialised
+		var mu sync.Mutex
+		update := func(docID []byte, childID jobs.JobID, status jobs.Status, msg string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !jobs.JobIDEqual(childID, jobs.NilJobID()) {
+				if err := jobMan.UpdateJobWithValue(accountID, jobID, hexutil.Encode(docID), childID.Bytes()); err != nil {
+					log.Error(err)
+				}
+			}
+
+			if err := jobMan.UpdateTaskStatus(accountID, jobID, status, batchCommitTaskName(docID), msg); err != nil {
+				log.Error(err)
+			}
+		}
+
+		var wg sync.WaitGroup
+		var failed int
+		var fmu sync.Mutex
+		sem := make(chan struct{}, batchCommitConcurrency)
+		for _, m := range models {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(m documents.Model) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				childID, err := s.commitAndWait(ctx, accountID, jobMan, m, func(childID jobs.JobID) {
+					update(m.ID(), childID, jobs.Pending, "anchoring")
+				})
+				if err != nil {
+					log.Error(err)
+					update(m.ID(), childID, jobs.Failed, err.Error())
+					fmu.Lock()
+					failed++
+					fmu.Unlock()
+					return
+				}
+
+				update(m.ID(), childID, jobs.Success, "committed")
+			}(m)
+		}
+
+		wg.Wait()
+		if failed > 0 {
+			errOut <- errors.New("failed to commit %d of %d documents", failed, len(models))
+			return
+		}
+
+		errOut <- nil
+	}
+}
+
+// commitAndWait removes the document from the pending documents, commits it, and waits for the anchor job to complete.
+// The document is removed only if it is not updated since it was validated, so that no update is lost,
+// and is restored if the commit fails.
+func (s service) commitAndWait(ctx context.Context, accountID identity.DID, jobMan jobs.Manager, model documents.Model, started func(childID jobs.JobID)) (jobs.JobID, error) {
+	etag, err := ETag(model)
+	if err != nil {
+		return jobs.NilJobID(), err
+	}
+
+	err = s.pendingRepo.DeleteIfMatch(accountID[:], model.ID(), etag)
+	if err != nil {
+		return jobs.NilJobID(), err
+	}
+
+	childID, err := s.docSrv.Commit(ctx, model)
+	if err != nil {
+		if cerr := s.pendingRepo.Create(accountID[:], model.ID(), model); cerr != nil {
+			log.Error(cerr)
+		}
+
+		return jobs.NilJobID(), err
+	}
+
+	started(childID)
+	return childID, jobMan.WaitForJob(accountID, childID)
+}
+
+// GetBatchCommitStatus returns the status of the batch commit job and the progress of each of its documents.
+func (s service) GetBatchCommitStatus(ctx context.Context, jobID jobs.JobID) (status jobs.Status, docs []BatchCommitStatus, err error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return status, nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	job, err := s.jobManager.GetJob(did, jobID)
+	if err != nil {
+		return status, nil, errors.NewTypedError(jobs.ErrJobsMissing, err)
+	}
+
+	msgs := make(map[string]string)
+	for _, l := range job.Logs {
+		msgs[l.Action] = l.Message
+	}
+
+	for task, st := range job.TaskStatus {
+		if !strings.HasPrefix(task, batchCommitTaskPrefix) {
+			continue
+		}
+
+		hid := strings.TrimPrefix(task, batchCommitTaskPrefix)
+		docID, err := hexutil.Decode(hid)
+		if err != nil {
+			return status, nil, err
+		}
+
+		childID := jobs.NilJobID()
+		if v, ok := job.Values[hid]; ok {
+			childID, err = jobs.FromString(hexutil.Encode(v.Value))
+			if err != nil {
+				return status, nil, err
+			}
+		}
+
+		docs = append(docs, BatchCommitStatus{
+			DocumentID: docID,
+			JobID:      childID,
+			Status:     st,
+			Message:    msgs[task],
+		})
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return bytes.Compare(docs[i].DocumentID, docs[j].DocumentID) < 0
+	})
+
+	return job.Status, docs, nil
+}
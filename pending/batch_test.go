@@ -0,0 +1,134 @@
+// +build unit
+
+package pending
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_CommitBatch(t *testing.T) {
+	jobMan := ctx[jobs.BootstrappedService].(jobs.Manager)
+	s := service{jobManager: jobMan}
+
+	// missing did
+	cctx := context.Background()
+	_, err := s.CommitBatch(cctx, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// no documents
+	cctx = testingconfig.CreateAccountContext(t, cfg)
+	_, err = s.CommitBatch(cctx, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBatch, err))
+
+	// missing and duplicate documents
+	id1, id2 := utils.RandomSlice(32), utils.RandomSlice(32)
+	repo := new(mockRepo)
+	docSrv := new(testingdocuments.MockService)
+	s.pendingRepo = repo
+	s.docSrv = docSrv
+	doc1 := new(documents.MockModel)
+	doc1.On("ID").Return(id1)
+	doc1.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute)
+	repo.On("Get", did[:], id1).Return(doc1, nil)
+	repo.On("Get", did[:], id2).Return(nil, errors.New("not found")).Once()
+	docSrv.On("GetCurrentVersion", id1).Return(nil, documents.ErrDocumentNotFound)
+	docSrv.On("Validate", cctx, doc1, mock.Anything).Return(nil)
+	_, err = s.CommitBatch(cctx, [][]byte{id1, id2, id1})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBatch, err))
+	assert.Contains(t, err.Error(), "duplicate document")
+	docSrv.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+
+	// invalid document
+	doc2 := new(documents.MockModel)
+	doc2.On("ID").Return(id2)
+	doc2.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute)
+	repo.On("Get", did[:], id2).Return(doc2, nil)
+	docSrv.On("GetCurrentVersion", id2).Return(nil, documents.ErrDocumentNotFound)
+	docSrv.On("Validate", cctx, doc2, mock.Anything).Return(errors.New("invalid document")).Once()
+	_, err = s.CommitBatch(cctx, [][]byte{id1, id2})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidBatch, err))
+	assert.Contains(t, err.Error(), "invalid document")
+	docSrv.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+
+	// one of the documents fails to commit
+	childID, done, err := jobMan.ExecuteWithinJob(cctx, did, jobs.NilJobID(), "anchor document", func(_ identity.DID, _ jobs.JobID, _ jobs.Manager, errOut chan<- error) {
+		errOut <- nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	docSrv.On("Validate", cctx, doc2, mock.Anything).Return(nil)
+	doc1.On("JSON").Return([]byte("doc1"), nil)
+	doc2.On("JSON").Return([]byte("doc2"), nil)
+	etag1, err := ETag(doc1)
+	assert.NoError(t, err)
+	etag2, err := ETag(doc2)
+	assert.NoError(t, err)
+	docSrv.On("Commit", mock.Anything, doc1).Return(childID, nil).Once()
+	docSrv.On("Commit", mock.Anything, doc2).Return(nil, errors.New("failed to commit")).Once()
+	repo.On("DeleteIfMatch", did[:], id1, etag1).Return(nil).Once()
+	repo.On("DeleteIfMatch", did[:], id2, etag2).Return(nil).Once()
+	repo.On("Create", did[:], id2, doc2).Return(nil).Once()
+	jobID, err := s.CommitBatch(cctx, [][]byte{id1, id2})
+	assert.NoError(t, err)
+	assert.Error(t, jobMan.WaitForJob(did, jobID))
+
+	st, docs, err := s.GetBatchCommitStatus(cctx, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Failed, st)
+	assert.Len(t, docs, 2)
+	progress := make(map[string]BatchCommitStatus)
+	for _, d := range docs {
+		progress[string(d.DocumentID)] = d
+	}
+	assert.Equal(t, jobs.Success, progress[string(id1)].Status)
+	assert.Equal(t, childID, progress[string(id1)].JobID)
+	assert.Equal(t, jobs.Failed, progress[string(id2)].Status)
+	assert.Equal(t, jobs.NilJobID(), progress[string(id2)].JobID)
+	assert.Contains(t, progress[string(id2)].Message, "failed to commit")
+
+	// document updated during the batch
+	repo.On("DeleteIfMatch", did[:], id1, etag1).Return(ErrETagMismatch).Once()
+	jobID, err = s.CommitBatch(cctx, [][]byte{id1})
+	assert.NoError(t, err)
+	assert.Error(t, jobMan.WaitForJob(did, jobID))
+	_, docs, err = s.GetBatchCommitStatus(cctx, jobID)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 1)
+	assert.Equal(t, jobs.Failed, docs[0].Status)
+	assert.Contains(t, docs[0].Message, ErrETagMismatch.Error())
+	docSrv.AssertNumberOfCalls(t, "Commit", 2)
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestService_GetBatchCommitStatus(t *testing.T) {
+	jobMan := ctx[jobs.BootstrappedService].(jobs.Manager)
+	s := service{jobManager: jobMan}
+
+	// missing did
+	_, _, err := s.GetBatchCommitStatus(context.Background(), jobs.NewJobID())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing job
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	_, _, err = s.GetBatchCommitStatus(cctx, jobs.NewJobID())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+}
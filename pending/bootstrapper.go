@@ -1,8 +1,13 @@
 package pending
 
 import (
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -25,7 +30,41 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	if !ok {
 		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
 	}
+
+	cfg, ok := ctx[bootstrap.BootstrappedConfig].(Config)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", bootstrap.BootstrappedConfig)
+	}
+
+	jobManager, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", jobs.BootstrappedService)
+	}
+
+	processor, ok := ctx[documents.BootstrappedAnchorProcessor].(documents.DocumentRequestProcessor)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", documents.BootstrappedAnchorProcessor)
+	}
+
+	anchorSrv, ok := ctx[anchors.BootstrappedAnchorService].(anchors.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", anchors.BootstrappedAnchorService)
+	}
+
+	didService, ok := ctx[identity.BootstrappedDIDService].(identity.Service)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", identity.BootstrappedDIDService)
+	}
+
+	events, ok := ctx[notification.BootstrappedEventBus].(notification.EventBus)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", notification.BootstrappedEventBus)
+	}
+
 	repo := NewRepository(ldb)
-	ctx[BootstrappedPendingDocumentService] = DefaultService(docSrv, repo)
+	ctx[BootstrappedPendingDocumentService] = DefaultService(docSrv, repo, jobManager, processor, events, didService, func() documents.ValidatorGroup {
+		return documents.PostAnchoredValidator(didService, anchorSrv)
+	})
+	ctx[bootstrap.BootstrappedPendingDocumentSweeper] = NewSweeper(cfg, repo)
 	return nil
 }
@@ -5,10 +5,19 @@ package pending
 import (
 	"testing"
 
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testinganchors "github.com/centrifuge/go-centrifuge/testingutils/anchors"
+	testingcommons "github.com/centrifuge/go-centrifuge/testingutils/commons"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +36,33 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	ctx[documents.BootstrappedDocumentService] = new(testingdocuments.MockService)
 	assert.Error(t, b.Bootstrap(ctx))
 
-	// success
+	// missing config
 	ctx[storage.BootstrappedDB] = repo
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// missing job manager
+	ctx[bootstrap.BootstrappedConfig] = new(testingconfig.MockConfig)
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// missing processor
+	ctx[jobs.BootstrappedService] = new(testingjobs.MockJobManager)
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// missing anchor service
+	ctx[documents.BootstrappedAnchorProcessor] = new(testingdocuments.MockRequestProcessor)
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// missing identity service
+	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// missing event bus
+	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// success
+	ctx[notification.BootstrappedEventBus] = notification.NewEventBus()
 	assert.NoError(t, b.Bootstrap(ctx))
+	assert.NotNil(t, ctx[BootstrappedPendingDocumentService])
+	assert.NotNil(t, ctx[bootstrap.BootstrappedPendingDocumentSweeper])
 }
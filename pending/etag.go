@@ -0,0 +1,95 @@
+package pending
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// ErrETagMismatch is a sentinel error used when the pending document was modified since the ETag was issued.
+const ErrETagMismatch = errors.Error("pending document has been modified")
+
+// anyETag matches the ETag of any existing pending document.
+const anyETag = "*"
+
+type contextKey string
+
+const preconditionKey = contextKey("precondition")
+
+// Precondition carries the ETag the pending document is expected to have before an update.
+// Once the update succeeds, ETag is set to the ETag of the updated document.
+type Precondition struct {
+	// IfMatch is the expected ETag of the pending document. Empty or "*" matches any document.
+	IfMatch string
+
+	// ETag of the pending document after the update.
+	ETag string
+}
+
+// WithPrecondition returns a context carrying the precondition of the update.
+func WithPrecondition(ctx context.Context, p *Precondition) context.Context {
+	return context.WithValue(ctx, preconditionKey, p)
+}
+
+// getPrecondition returns the precondition in the context, if any.
+func getPrecondition(ctx context.Context) *Precondition {
+	p, ok := ctx.Value(preconditionKey).(*Precondition)
+	if !ok {
+		return nil
+	}
+
+	return p
+}
+
+// ETag returns the ETag of the pending document derived from its current state.
+func ETag(model documents.Model) (string, error) {
+	data, err := model.JSON()
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := crypto.Sha256Hash(data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", hash)), nil
+}
+
+// etagMatches returns true if the ETag matches the expected one.
+// Expected ETag is accepted with or without the quotes.
+func etagMatches(etag, expected string) bool {
+	expected = strings.TrimSpace(expected)
+	if expected == "" || expected == anyETag {
+		return true
+	}
+
+	return strings.Trim(etag, `"`) == strings.Trim(expected, `"`)
+}
+
+// update updates the pending document honoring the precondition in the context and publishes the new state.
+func (s service) update(ctx context.Context, accountID, docID []byte, doc documents.Model) error {
+	p := getPrecondition(ctx)
+	if p == nil {
+		err := s.pendingRepo.Update(accountID, docID, doc)
+		if err != nil {
+			return err
+		}
+
+		s.publishPending(accountID, doc)
+		return nil
+	}
+
+	err := s.pendingRepo.UpdateIfMatch(accountID, docID, doc, p.IfMatch)
+	if err != nil {
+		return err
+	}
+
+	s.publishPending(accountID, doc)
+	p.ETag, err = ETag(doc)
+	return err
+}
@@ -0,0 +1,27 @@
+package pending
+
+import (
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+)
+
+// publishPending publishes the new state of the pending document to the subscribers of the account.
+func (s service) publishPending(accountID []byte, doc documents.Model) {
+	if s.events == nil {
+		return
+	}
+
+	did, err := identity.NewDIDFromBytes(accountID)
+	if err != nil {
+		return
+	}
+
+	s.events.Publish(notification.DocumentEvent{
+		Type:       notification.PendingVersion,
+		AccountID:  did,
+		DocumentID: doc.ID(),
+		VersionID:  doc.CurrentVersion(),
+		Scheme:     doc.Scheme(),
+	})
+}
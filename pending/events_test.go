@@ -0,0 +1,36 @@
+// +build unit
+
+package pending
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_publishPending(t *testing.T) {
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+	doc := new(documents.MockModel)
+	doc.On("ID").Return(docID)
+	doc.On("CurrentVersion").Return(versionID)
+	doc.On("Scheme").Return("generic")
+
+	// no event bus
+	s := service{}
+	s.publishPending(did[:], doc)
+
+	bus := notification.NewEventBus()
+	sub := bus.Subscribe(did, docID)
+	defer sub.Close()
+	s.events = bus
+	s.publishPending(did[:], doc)
+	event := <-sub.Events()
+	assert.Equal(t, notification.PendingVersion, event.Type)
+	assert.Equal(t, did, event.AccountID)
+	assert.Equal(t, versionID, []byte(event.VersionID))
+	assert.Equal(t, "generic", event.Scheme)
+	doc.AssertExpectations(t)
+}
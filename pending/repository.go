@@ -1,8 +1,15 @@
 package pending
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -10,6 +17,12 @@ import (
 const (
 	// DocPrefix holds the generic prefix of a document in DB
 	DocPrefix string = "pending_document_"
+
+	// TouchPrefix holds the prefix of the last touched time of a document in DB
+	TouchPrefix string = "pending_touched_"
+
+	// ApprovalRequestPrefix holds the prefix of the approval requests received by an account in DB
+	ApprovalRequestPrefix string = "pending_approval_request_"
 )
 
 // Repository defines the required methods for a document repository.
@@ -26,17 +39,92 @@ type Repository interface {
 	// Will error out when the model doesn't exist in the DB.
 	Update(accountID, id []byte, model documents.Model) error
 
+	// UpdateIfMatch updates the model only if the ETag of the stored model matches etag.
+	// Check and update are atomic. Empty etag or "*" matches any stored model.
+	UpdateIfMatch(accountID, id []byte, model documents.Model, etag string) error
+
 	// Delete deletes the data associated with account and ID.
 	Delete(accountID, id []byte) error
+
+	// DeleteIfMatch deletes the model only if the ETag of the stored model matches etag.
+	// Check and delete are atomic. Empty etag or "*" matches any stored model.
+	DeleteIfMatch(accountID, id []byte, etag string) error
+
+	// List returns the pending documents, owned by accountID, that match the filter.
+	// next is the cursor to the next page and is empty when there are no more documents.
+	List(accountID []byte, filter documents.ListFilter) (models []documents.Model, next []byte, err error)
+
+	// DeleteUntouched deletes the documents, of all the accounts, that are not created or updated since before.
+	DeleteUntouched(before time.Time) (count int, err error)
+
+	// StoreApprovalRequest stores the approval request received by accountID.
+	// A request of the same owner for the same document is replaced.
+	StoreApprovalRequest(accountID []byte, req *ApprovalRequest) error
+
+	// GetApprovalRequests returns the approval requests received by accountID for the document.
+	// All the approval requests received by accountID are returned if docID is empty.
+	GetApprovalRequests(accountID, docID []byte) ([]*ApprovalRequest, error)
+
+	// DeleteApprovalRequest deletes the approval request of the owner for the document received by accountID.
+	DeleteApprovalRequest(accountID, docID []byte, owner identity.DID) error
+}
+
+// ApprovalRequest is a pending version, of another account, awaiting the approval of the account.
+type ApprovalRequest struct {
+	Owner      identity.DID `json:"owner"`
+	DocumentID []byte       `json:"document_id"`
+
+	// Document is the proto marshaled core document of the pending version.
+	Document []byte `json:"document"`
+}
+
+// JSON returns json marshaled ApprovalRequest.
+func (a *ApprovalRequest) JSON() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// FromJSON loads the data into ApprovalRequest.
+func (a *ApprovalRequest) FromJSON(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// Type returns the reflect.Type of the ApprovalRequest.
+func (a *ApprovalRequest) Type() reflect.Type {
+	return reflect.TypeOf(a)
+}
+
+// lastTouched holds the time at which the pending document was last created or updated.
+type lastTouched struct {
+	Time time.Time `json:"time"`
+}
+
+// JSON returns json marshaled lastTouched.
+func (t *lastTouched) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON loads the data into lastTouched.
+func (t *lastTouched) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// Type returns the reflect.Type of the lastTouched.
+func (t *lastTouched) Type() reflect.Type {
+	return reflect.TypeOf(t)
 }
 
-// NewRepository creates an instance of the pending document Repository
+// NewRepository registers the lastTouched and ApprovalRequest models and creates an instance of the pending document Repository
 func NewRepository(db storage.Repository) Repository {
+	db.Register(new(lastTouched))
+	db.Register(new(ApprovalRequest))
 	return &repo{db: db}
 }
 
 type repo struct {
 	db storage.Repository
+
+	// mu serialises the writes so that the checks made before a write, ETag or last touched time, hold when writing.
+	mu sync.Mutex
 }
 
 // getKey returns document_+accountID+id
@@ -45,6 +133,22 @@ func (r *repo) getKey(accountID, id []byte) []byte {
 	return append([]byte(DocPrefix), []byte(hexKey)...)
 }
 
+// getTouchKey returns the last touched key of the document key.
+func getTouchKey(key []byte) []byte {
+	return append([]byte(TouchPrefix), key[len(DocPrefix):]...)
+}
+
+// touch sets the last touched time of the document key to now.
+func (r *repo) touch(key []byte) error {
+	tkey := getTouchKey(key)
+	t := &lastTouched{Time: time.Now().UTC()}
+	if r.db.Exists(tkey) {
+		return r.db.Update(tkey, t)
+	}
+
+	return r.db.Create(tkey, t)
+}
+
 // Get returns the Model associated with ID, owned by accountID
 func (r *repo) Get(accountID, id []byte) (documents.Model, error) {
 	key := r.getKey(accountID, id)
@@ -62,18 +166,216 @@ func (r *repo) Get(accountID, id []byte) (documents.Model, error) {
 // Create creates the model if not present in the DB.
 // should error out if the document exists.
 func (r *repo) Create(accountID, id []byte, model documents.Model) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	key := r.getKey(accountID, id)
-	return r.db.Create(key, model)
+	err := r.db.Create(key, model)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(key)
 }
 
 // Update strictly updates the model.
 // Will error out when the model doesn't exist in the DB.
 func (r *repo) Update(accountID, id []byte, model documents.Model) error {
-	key := r.getKey(accountID, id)
-	return r.db.Update(key, model)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.update(r.getKey(accountID, id), model)
+}
+
+// UpdateIfMatch updates the model only if the ETag of the stored model matches etag.
+// Check and update are atomic. Empty etag or "*" matches any stored model.
+func (r *repo) UpdateIfMatch(accountID, id []byte, model documents.Model, etag string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.Get(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	current, err := ETag(stored)
+	if err != nil {
+		return err
+	}
+
+	if !etagMatches(current, etag) {
+		return ErrETagMismatch
+	}
+
+	return r.update(r.getKey(accountID, id), model)
+}
+
+// DeleteIfMatch deletes the model only if the ETag of the stored model matches etag.
+// Check and delete are atomic. Empty etag or "*" matches any stored model.
+func (r *repo) DeleteIfMatch(accountID, id []byte, etag string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, err := r.Get(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	current, err := ETag(stored)
+	if err != nil {
+		return err
+	}
+
+	if !etagMatches(current, etag) {
+		return ErrETagMismatch
+	}
+
+	return r.delete(r.getKey(accountID, id))
+}
+
+// update updates the document key and its last touched time.
+func (r *repo) update(key []byte, model documents.Model) error {
+	err := r.db.Update(key, model)
+	if err != nil {
+		return err
+	}
+
+	return r.touch(key)
 }
 
+// Delete deletes the data associated with account and ID.
 func (r *repo) Delete(accountID, id []byte) error {
-	key := r.getKey(accountID, id)
-	return r.db.Delete(key)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.delete(r.getKey(accountID, id))
+}
+
+// delete deletes the document key along with its last touched time.
+func (r *repo) delete(key []byte) error {
+	err := r.db.Delete(key)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Delete(getTouchKey(key))
+}
+
+// List returns the pending documents, owned by accountID, that match the filter.
+// Pending documents are keyed by document ID, so the cursor is the document ID to start from.
+func (r *repo) List(accountID []byte, filter documents.ListFilter) (models []documents.Model, next []byte, err error) {
+	var start []byte
+	if len(filter.Cursor) > 0 {
+		start = r.getKey(accountID, filter.Cursor)
+	}
+
+	limit := filter.GetLimit()
+	err = r.db.Iterate(DocPrefix+hexutil.Encode(accountID), start, func(key []byte, model storage.Model) bool {
+		m, ok := model.(documents.Model)
+		if !ok || !filter.Matches(m) {
+			return true
+		}
+
+		if len(models) == limit {
+			next = m.ID()
+			return false
+		}
+
+		models = append(models, m)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return models, next, nil
+}
+
+// DeleteUntouched deletes the documents, of all the accounts, that are not created or updated since before.
+// Documents stored without the last touched time are touched now instead, so that they are kept for another ttl.
+// Writes are held off until the sweep completes so that a document touched during the sweep is not deleted.
+func (r *repo) DeleteUntouched(before time.Time) (count int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	touched := make(map[string]time.Time)
+	err = r.db.Iterate(TouchPrefix, nil, func(key []byte, model storage.Model) bool {
+		t, ok := model.(*lastTouched)
+		if ok {
+			touched[strings.TrimPrefix(string(key), TouchPrefix)] = t.Time
+		}
+
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var keys, untouched [][]byte
+	err = r.db.Iterate(DocPrefix, nil, func(key []byte, model storage.Model) bool {
+		t, ok := touched[strings.TrimPrefix(string(key), DocPrefix)]
+		if !ok {
+			untouched = append(untouched, key)
+			return true
+		}
+
+		if t.Before(before) {
+			keys = append(keys, key)
+		}
+
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range untouched {
+		err = r.touch(key)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for _, key := range keys {
+		err = r.delete(key)
+		if err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// getApprovalRequestKey returns approval_request_+accountID+docID+owner
+func getApprovalRequestKey(accountID, docID, owner []byte) []byte {
+	id := append(append(append([]byte{}, accountID...), docID...), owner...)
+	return append([]byte(ApprovalRequestPrefix), []byte(hexutil.Encode(id))...)
+}
+
+// StoreApprovalRequest stores the approval request received by accountID.
+// A request of the same owner for the same document is replaced.
+func (r *repo) StoreApprovalRequest(accountID []byte, req *ApprovalRequest) error {
+	key := getApprovalRequestKey(accountID, req.DocumentID, req.Owner[:])
+	if r.db.Exists(key) {
+		return r.db.Update(key, req)
+	}
+
+	return r.db.Create(key, req)
+}
+
+// GetApprovalRequests returns the approval requests received by accountID for the document.
+// All the approval requests received by accountID are returned if docID is empty.
+func (r *repo) GetApprovalRequests(accountID, docID []byte) (reqs []*ApprovalRequest, err error) {
+	prefix := string(getApprovalRequestKey(accountID, docID, nil))
+	err = r.db.Iterate(prefix, nil, func(key []byte, model storage.Model) bool {
+		req, ok := model.(*ApprovalRequest)
+		if ok {
+			reqs = append(reqs, req)
+		}
+
+		return true
+	})
+
+	return reqs, err
+}
+
+// DeleteApprovalRequest deletes the approval request of the owner for the document received by accountID.
+func (r *repo) DeleteApprovalRequest(accountID, docID []byte, owner identity.DID) error {
+	return r.db.Delete(getApprovalRequestKey(accountID, docID, owner[:]))
 }
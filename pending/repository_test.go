@@ -16,6 +16,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
@@ -72,6 +73,7 @@ type doc struct {
 	DocID, Current, Next []byte
 	SomeString           string `json:"some_string"`
 	Time                 time.Time
+	DocScheme            string
 }
 
 type unknownDoc struct {
@@ -118,6 +120,18 @@ func (m *doc) Timestamp() (time.Time, error) {
 	return m.Time, nil
 }
 
+func (m *doc) Scheme() string {
+	return m.DocScheme
+}
+
+func (m *doc) GetStatus() documents.Status {
+	return documents.Pending
+}
+
+func (m *doc) Author() (identity.DID, error) {
+	return identity.DID{}, errors.New("author not set")
+}
+
 func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 	repor := getRepository(ctx)
 
@@ -167,3 +181,190 @@ func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 		assert.Contains(t, err.Error(), "is not a model object")
 	}
 }
+
+func TestRepo_UpdateIfMatch(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(&doc{})
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!", DocID: id}
+
+	// missing document
+	etag, err := ETag(d)
+	assert.NoError(t, err)
+	assert.Error(t, r.UpdateIfMatch(accountID, id, d, etag))
+
+	assert.NoError(t, r.Create(accountID, id, d))
+	m, err := r.Get(accountID, id)
+	assert.NoError(t, err)
+	getag, err := ETag(m)
+	assert.NoError(t, err)
+	assert.Equal(t, etag, getag)
+
+	// success
+	d.SomeString = "Hello, World!"
+	assert.NoError(t, r.UpdateIfMatch(accountID, id, d, etag))
+	netag, err := ETag(d)
+	assert.NoError(t, err)
+	assert.NotEqual(t, etag, netag)
+
+	// stale etag
+	d.SomeString = "Hello, Stale!"
+	err = r.UpdateIfMatch(accountID, id, d, etag)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrETagMismatch, err))
+	m, err = r.Get(accountID, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", m.(*doc).SomeString)
+
+	// any etag
+	assert.NoError(t, r.UpdateIfMatch(accountID, id, d, "*"))
+	assert.NoError(t, r.UpdateIfMatch(accountID, id, d, ""))
+}
+
+func TestRepo_DeleteIfMatch(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(&doc{})
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!", DocID: id}
+	etag, err := ETag(d)
+	assert.NoError(t, err)
+
+	// missing document
+	assert.Error(t, r.DeleteIfMatch(accountID, id, etag))
+
+	// stale etag
+	assert.NoError(t, r.Create(accountID, id, d))
+	assert.NoError(t, r.Update(accountID, id, &doc{SomeString: "Hello, World!", DocID: id}))
+	err = r.DeleteIfMatch(accountID, id, etag)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrETagMismatch, err))
+	_, err = r.Get(accountID, id)
+	assert.NoError(t, err)
+
+	// success
+	assert.NoError(t, r.DeleteIfMatch(accountID, id, "*"))
+	_, err = r.Get(accountID, id)
+	assert.Error(t, err)
+}
+
+func TestRepo_List(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(&doc{})
+	acc := utils.RandomSlice(20)
+
+	// no documents
+	models, next, err := r.List(acc, documents.ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+	assert.Empty(t, next)
+
+	for i := 0; i < 3; i++ {
+		id := utils.RandomSlice(32)
+		d := &doc{DocID: id, Current: id, Time: time.Now().UTC(), DocScheme: "generic"}
+		if i == 0 {
+			d.DocScheme = "entity"
+		}
+		assert.NoError(t, r.Create(acc, id, d))
+	}
+
+	// all documents
+	models, next, err = r.List(acc, documents.ListFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, models, 3)
+	assert.Empty(t, next)
+
+	// scheme filter
+	models, _, err = r.List(acc, documents.ListFilter{Scheme: "generic"})
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+
+	// pagination
+	models, next, err = r.List(acc, documents.ListFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, models, 2)
+	assert.NotEmpty(t, next)
+	models, next, err = r.List(acc, documents.ListFilter{Limit: 2, Cursor: next})
+	assert.NoError(t, err)
+	assert.Len(t, models, 1)
+	assert.Empty(t, next)
+}
+
+func TestRepo_DeleteUntouched(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(&doc{})
+	acc := utils.RandomSlice(20)
+
+	// touched document
+	id := utils.RandomSlice(32)
+	assert.NoError(t, r.Create(acc, id, &doc{DocID: id}))
+	assert.True(t, r.(*repo).db.Exists(getTouchKey(r.(*repo).getKey(acc, id))))
+
+	// document without the last touched time is touched now
+	oid := utils.RandomSlice(32)
+	key := r.(*repo).getKey(acc, oid)
+	assert.NoError(t, r.(*repo).db.Create(key, &doc{DocID: oid, Time: time.Now().UTC().Add(-time.Hour)}))
+
+	count, err := r.DeleteUntouched(time.Now().UTC().Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	_, err = r.Get(acc, oid)
+	assert.NoError(t, err)
+	assert.True(t, r.(*repo).db.Exists(getTouchKey(key)))
+	_, err = r.Get(acc, id)
+	assert.NoError(t, err)
+
+	// update touches the document
+	before := time.Now().UTC()
+	assert.NoError(t, r.Update(acc, id, &doc{DocID: id, SomeString: "updated"}))
+	count, err = r.DeleteUntouched(before)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = r.DeleteUntouched(time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, count > 0)
+	_, err = r.Get(acc, id)
+	assert.Error(t, err)
+	assert.False(t, r.(*repo).db.Exists(getTouchKey(r.(*repo).getKey(acc, id))))
+}
+
+func TestRepo_ApprovalRequests(t *testing.T) {
+	r := getRepository(ctx)
+	acc, docID := utils.RandomSlice(20), utils.RandomSlice(32)
+	owner1, owner2 := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+
+	// no requests
+	reqs, err := r.GetApprovalRequests(acc, docID)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 0)
+
+	// requests of two owners
+	assert.NoError(t, r.StoreApprovalRequest(acc, &ApprovalRequest{Owner: owner1, DocumentID: docID, Document: []byte{1}}))
+	assert.NoError(t, r.StoreApprovalRequest(acc, &ApprovalRequest{Owner: owner2, DocumentID: docID, Document: []byte{1}}))
+	assert.NoError(t, r.StoreApprovalRequest(acc, &ApprovalRequest{Owner: owner1, DocumentID: utils.RandomSlice(32)}))
+	assert.NoError(t, r.StoreApprovalRequest(utils.RandomSlice(20), &ApprovalRequest{Owner: owner1, DocumentID: docID}))
+	reqs, err = r.GetApprovalRequests(acc, docID)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 2)
+	reqs, err = r.GetApprovalRequests(acc, nil)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 3)
+
+	// replace the request of the owner
+	assert.NoError(t, r.StoreApprovalRequest(acc, &ApprovalRequest{Owner: owner1, DocumentID: docID, Document: []byte{2}}))
+	reqs, err = r.GetApprovalRequests(acc, docID)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 2)
+	for _, req := range reqs {
+		if req.Owner.Equal(owner1) {
+			assert.Equal(t, []byte{2}, req.Document)
+		}
+	}
+
+	// delete
+	assert.NoError(t, r.DeleteApprovalRequest(acc, docID, owner1))
+	reqs, err = r.GetApprovalRequests(acc, docID)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 1)
+	assert.Equal(t, owner2, reqs[0].Owner)
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/templates"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 )
 
@@ -30,12 +32,61 @@ type Service interface {
 	// Create creates a pending document from the payload
 	Create(ctx context.Context, payload documents.UpdatePayload) (documents.Model, error)
 
+	// CreateFromTemplate creates a pending document from the payload using the scheme, default attributes,
+	// roles and rules of the template. Template is expected to be substituted already.
+	CreateFromTemplate(ctx context.Context, payload documents.UpdatePayload, tmpl templates.Template) (documents.Model, error)
+
+	// Clone creates a new pending document from the latest committed version of the document.
+	// Attributes, roles, and rules are copied unless dropped by the options.
+	Clone(ctx context.Context, docID []byte, opts documents.CloneOptions) (documents.Model, error)
+
 	// Commit validates, shares and anchors document
 	Commit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error)
 
+	// CommitBatch validates all the documents first and commits them within a single job.
+	// Nothing is committed if any of the documents is invalid.
+	CommitBatch(ctx context.Context, docIDs [][]byte) (jobs.JobID, error)
+
+	// GetBatchCommitStatus returns the status of the batch commit job and the progress of each of its documents.
+	GetBatchCommitStatus(ctx context.Context, jobID jobs.JobID) (jobs.Status, []BatchCommitStatus, error)
+
+	// Delete discards the pending document associated with docID.
+	Delete(ctx context.Context, docID []byte) error
+
+	// SetApprovalPolicy requires the pending version to be approved by the members of the role before it can be committed.
+	SetApprovalPolicy(ctx context.Context, docID []byte, p documents.ApprovalPolicy) (documents.ApprovalStatus, error)
+
+	// GetApprovalStatus returns the approval policy and the approvers of the pending version.
+	GetApprovalStatus(ctx context.Context, docID []byte) (documents.ApprovalStatus, error)
+
+	// Approve approves, on behalf of the account, the pending versions of the document awaiting its approval.
+	// The approval is sent to the owner of each pending version.
+	Approve(ctx context.Context, docID []byte) ([]PendingApproval, error)
+
+	// ListPendingApprovals returns the pending versions, of the other accounts, awaiting the approval of the account.
+	ListPendingApprovals(ctx context.Context) ([]PendingApproval, error)
+
+	// ApprovalReceiver receives the approval requests and the approvals from the other accounts.
+	documents.ApprovalReceiver
+
+	// GrantAccessToken adds an access token to the pending document granting the grantee read access to the document tokenDocID.
+	GrantAccessToken(ctx context.Context, docID []byte, grantee identity.DID, tokenDocID []byte) (*coredocumentpb.AccessToken, error)
+
+	// GetAccessTokens returns the access tokens of the pending document.
+	GetAccessTokens(ctx context.Context, docID []byte) ([]*coredocumentpb.AccessToken, error)
+
+	// RevokeAccessToken removes the access token from the pending document.
+	RevokeAccessToken(ctx context.Context, docID, tokenID []byte) error
+
+	// GetWithAccessToken requests the document from the granter using the access token in the delegating document.
+	GetWithAccessToken(ctx context.Context, granter identity.DID, tokenID, docID, delegatingDocID []byte) (documents.Model, error)
+
 	// AddSignedAttribute signs the value using the account keys and adds the attribute to the pending document.
 	AddSignedAttribute(ctx context.Context, docID []byte, label string, value []byte) (documents.Model, error)
 
+	// AddAttachment adds the attribute referencing the attachment to the pending document.
+	AddAttachment(ctx context.Context, docID []byte, att documents.Attachment) (documents.Model, error)
+
 	// RemoveCollaborators removes collaborators from the document.
 	RemoveCollaborators(ctx context.Context, docID []byte, dids []identity.DID) (documents.Model, error)
 
@@ -48,6 +99,9 @@ type Service interface {
 	// UpdateRole updates a role in the given document
 	UpdateRole(ctx context.Context, docID, roleID []byte, collabs []identity.DID) (*coredocumentpb.Role, error)
 
+	// SetRoleValidity restricts the role and its read rules to the given validity windows.
+	SetRoleValidity(ctx context.Context, docID, roleID []byte, v RoleValidity) (RoleValidity, error)
+
 	// AddTransitionRules creates transition rules to the given document.
 	// The access is only given to the roleKey which is expected to be present already.
 	AddTransitionRules(ctx context.Context, docID []byte, addRules AddTransitionRules) ([]*coredocumentpb.TransitionRule, error)
@@ -57,19 +111,83 @@ type Service interface {
 
 	// DeleteTransitionRule deletes the transition rule associated with ruleID in th document.
 	DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error
+
+	// List returns the documents, owned by the account, that match the filter.
+	// Pending documents are listed along with the committed documents unless filtered by status.
+	// next is the cursor to the next page and is empty when there are no more documents.
+	List(ctx context.Context, filter documents.ListFilter) (models []documents.Model, next []byte, err error)
+
+	// GetVersions returns the committed versions of the document, latest first, starting at cursor if provided.
+	GetVersions(ctx context.Context, docID, cursor []byte, limit int) (versions []documents.VersionInfo, next []byte, err error)
+
+	// ExportBundle packs the committed version of the document into a bundle verifiable without a node.
+	// Latest committed version is exported if the versionID is empty.
+	ExportBundle(ctx context.Context, docID, versionID []byte) ([]byte, error)
+
+	// ImportBundle validates the anchored version in the bundle and stores it as a read-only committed version.
+	ImportBundle(ctx context.Context, data []byte) (documents.Model, error)
+
+	// Diff returns the changes made in the version to compared to the version from.
+	// If to is empty, pending version is used if present, else the latest committed version.
+	// If from is empty, previous version of to is used.
+	Diff(ctx context.Context, docID, from, to []byte) (documents.Diff, error)
+
+	// GetForks returns the local versions of the document that lost against versions anchored by other collaborators.
+	GetForks(ctx context.Context, docID []byte) ([]*documents.Fork, error)
+
+	// Rebase creates a pending document from the latest committed version of the document
+	// with the changes made in the forked version versionID.
+	Rebase(ctx context.Context, docID, versionID []byte) (documents.Model, error)
+
+	// ListInbox returns the received versions in the inbox of the account, oldest first, that match the filter.
+	// Listing does not change the read state of the items.
+	ListInbox(ctx context.Context, filter documents.InboxFilter) (items []*documents.InboxItem, next []byte, err error)
+
+	// MarkInboxItemRead marks the item in the inbox of the account as read.
+	MarkInboxItemRead(ctx context.Context, id []byte) (*documents.InboxItem, error)
+
+	// AckInboxItem marks the item in the inbox of the account as acknowledged.
+	AckInboxItem(ctx context.Context, id []byte) (*documents.InboxItem, error)
+
+	// Validate runs the checks applied on commit against the pending document without committing it
+	// and returns every failure.
+	Validate(ctx context.Context, docID []byte) ([]documents.ValidationFailure, error)
+
+	// SetSignaturePolicy sets the policy deciding if the pending document is anchored when collaborators fail to sign it.
+	SetSignaturePolicy(ctx context.Context, docID []byte, p documents.SignaturePolicy) (documents.SignaturePolicy, error)
+
+	// RetryCommit resumes the anchoring of the committed document from the last stage completed by the failed job.
+	RetryCommit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error)
 }
 
 // service implements Service
 type service struct {
-	docSrv      documents.Service
-	pendingRepo Repository
+	docSrv            documents.Service
+	pendingRepo       Repository
+	jobManager        jobs.Manager
+	processor         documents.DocumentRequestProcessor
+	receivedValidator func() documents.ValidatorGroup
+	events            notification.EventBus
+	idService         identity.Service
 }
 
 // DefaultService returns the default implementation of the service
-func DefaultService(docSrv documents.Service, repo Repository) Service {
+func DefaultService(
+	docSrv documents.Service,
+	repo Repository,
+	jobManager jobs.Manager,
+	processor documents.DocumentRequestProcessor,
+	events notification.EventBus,
+	idService identity.Service,
+	receivedValidator func() documents.ValidatorGroup) Service {
 	return service{
-		docSrv:      docSrv,
-		pendingRepo: repo,
+		docSrv:            docSrv,
+		pendingRepo:       repo,
+		jobManager:        jobManager,
+		processor:         processor,
+		receivedValidator: receivedValidator,
+		events:            events,
+		idService:         idService,
 	}
 }
 
@@ -103,6 +221,20 @@ func (s service) Get(ctx context.Context, docID []byte, status documents.Status)
 	return s.pendingRepo.Get(did[:], docID)
 }
 
+// latestVersion returns the latest committed version of the document or nil if the document is not committed yet.
+func (s service) latestVersion(ctx context.Context, docID []byte) (documents.Model, error) {
+	old, err := s.docSrv.GetCurrentVersion(ctx, docID)
+	if err != nil {
+		if errors.IsOfType(documents.ErrDocumentNotFound, err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return old, nil
+}
+
 // GetVersion return the specific version of the document
 // We try to fetch the version from the document service, if found return
 // else look in pending repo for specific version.
@@ -128,6 +260,58 @@ func (s service) GetVersion(ctx context.Context, docID, versionID []byte) (docum
 // Create creates either a new document or next version of an anchored document and stores the document.
 // errors out if there an pending document created already
 func (s service) Create(ctx context.Context, payload documents.UpdatePayload) (documents.Model, error) {
+	return s.create(ctx, payload, nil)
+}
+
+// CreateFromTemplate prepares the payload with the template defaults, creates the document,
+// and adds the roles and rules of the template to the document before storing it.
+func (s service) CreateFromTemplate(ctx context.Context, payload documents.UpdatePayload, tmpl templates.Template) (documents.Model, error) {
+	if err := tmpl.Prepare(&payload); err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, payload, tmpl.ApplyRules)
+}
+
+// Clone creates a new pending document with a new ID from the latest committed version of the document.
+func (s service) Clone(ctx context.Context, docID []byte, opts documents.CloneOptions) (documents.Model, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	src, err := s.docSrv.GetCurrentVersion(ctx, docID)
+	if err != nil {
+		return nil, documents.ErrDocumentNotFound
+	}
+
+	cd, err := src.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := documents.ClonePayload(src, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, payload, func(doc documents.Model) error {
+		err := doc.CopyRules(ctx, cd, opts)
+		if err != nil || opts.DropSignedAttributes {
+			return err
+		}
+
+		attrs, err := documents.CloneSignedAttributes(acc, src, doc)
+		if err != nil || len(attrs) < 1 {
+			return err
+		}
+
+		return doc.AddAttributes(documents.CollaboratorsAccess{}, false, attrs...)
+	})
+}
+
+// create derives the document from the payload, applies the optional changes and stores the document.
+func (s service) create(ctx context.Context, payload documents.UpdatePayload, apply func(doc documents.Model) error) (documents.Model, error) {
 	accID, err := contextutil.AccountDID(ctx)
 	if err != nil {
 		return nil, contextutil.ErrDIDMissingFromContext
@@ -146,9 +330,21 @@ func (s service) Create(ctx context.Context, payload documents.UpdatePayload) (d
 		return nil, err
 	}
 
+	if apply != nil {
+		if err := apply(doc); err != nil {
+			return nil, err
+		}
+	}
+
 	// we create one document per ID. hence, we use ID instead of current version
 	// since its common to all document versions.
-	return doc, s.pendingRepo.Create(accID[:], doc.ID(), doc)
+	err = s.pendingRepo.Create(accID[:], doc.ID(), doc)
+	if err != nil {
+		return doc, err
+	}
+
+	s.publishPending(accID[:], doc)
+	return doc, nil
 }
 
 // Update updates a pending document from the payload
@@ -168,7 +364,7 @@ func (s service) Update(ctx context.Context, payload documents.UpdatePayload) (d
 		return nil, err
 	}
 	doc := mp.(documents.Model)
-	return doc, s.pendingRepo.Update(accID[:], doc.ID(), doc)
+	return doc, s.update(ctx, accID[:], doc.ID(), doc)
 }
 
 // Commit triggers validations, state change and anchor job
@@ -178,6 +374,16 @@ func (s service) Commit(ctx context.Context, docID []byte) (documents.Model, job
 		return nil, jobs.NilJobID(), err
 	}
 
+	old, err := s.latestVersion(ctx, docID)
+	if err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
+	err = documents.ValidateApprovals(old, doc)
+	if err != nil {
+		return nil, jobs.NilJobID(), err
+	}
+
 	jobID, err := s.docSrv.Commit(ctx, doc)
 	if err != nil {
 		return nil, jobs.NilJobID(), err
@@ -186,6 +392,17 @@ func (s service) Commit(ctx context.Context, docID []byte) (documents.Model, job
 	return doc, jobID, s.pendingRepo.Delete(accID[:], docID)
 }
 
+// Delete discards the pending document associated with docID.
+// Committed versions of the document are not affected.
+func (s service) Delete(ctx context.Context, docID []byte) error {
+	_, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	return s.pendingRepo.Delete(accID[:], docID)
+}
+
 func (s service) AddSignedAttribute(ctx context.Context, docID []byte, label string, value []byte) (documents.Model, error) {
 	acc, err := contextutil.Account(ctx)
 	if err != nil {
@@ -202,6 +419,11 @@ func (s service) AddSignedAttribute(ctx context.Context, docID []byte, label str
 		return nil, err
 	}
 
+	// reserved attributes hold the document metadata and are only set through their own APIs
+	if documents.IsReservedAttribute(label) {
+		return nil, errors.NewTypedError(documents.ErrReservedAttribute, errors.New("%s", label))
+	}
+
 	// we use currentVersion here since the version is not anchored yet
 	attr, err := documents.NewSignedAttribute(label, did, acc, model.ID(), model.CurrentVersion(), value)
 	if err != nil {
@@ -213,7 +435,28 @@ func (s service) AddSignedAttribute(ctx context.Context, docID []byte, label str
 		return nil, err
 	}
 
-	return model, s.pendingRepo.Update(acc.GetIdentityID(), docID, model)
+	return model, s.update(ctx, acc.GetIdentityID(), docID, model)
+}
+
+// AddAttachment adds the attribute referencing the attachment to the pending document.
+// Attachment with the same name is replaced.
+func (s service) AddAttachment(ctx context.Context, docID []byte, att documents.Attachment) (documents.Model, error) {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	attr, err := documents.NewAttachmentAttribute(att)
+	if err != nil {
+		return nil, err
+	}
+
+	err = doc.AddAttributes(documents.CollaboratorsAccess{}, false, attr)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, s.update(ctx, accID[:], docID, doc)
 }
 
 // RemoveCollaborators removes dids from the given document.
@@ -228,7 +471,7 @@ func (s service) RemoveCollaborators(ctx context.Context, docID []byte, dids []i
 		return nil, err
 	}
 
-	return doc, s.pendingRepo.Update(accID[:], docID, doc)
+	return doc, s.update(ctx, accID[:], docID, doc)
 }
 
 func (s service) GetRole(ctx context.Context, docID, roleID []byte) (*coredocumentpb.Role, error) {
@@ -262,7 +505,7 @@ func (s service) AddRole(ctx context.Context, docID []byte, roleKey string, coll
 		return nil, err
 	}
 
-	return r, s.pendingRepo.Update(accID[:], docID, doc)
+	return r, s.update(ctx, accID[:], docID, doc)
 }
 
 // UpdateRole updates a role in the given document
@@ -277,7 +520,43 @@ func (s service) UpdateRole(ctx context.Context, docID, roleID []byte, collabs [
 		return nil, err
 	}
 
-	return r, s.pendingRepo.Update(accID[:], docID, doc)
+	return r, s.update(ctx, accID[:], docID, doc)
+}
+
+// RoleValidity holds the validity windows of a role and its read rules.
+// Nil windows are left unchanged.
+type RoleValidity struct {
+	Role      *documents.Validity `json:"role,omitempty"`
+	ReadRules *documents.Validity `json:"read_rules,omitempty"`
+}
+
+// SetRoleValidity sets the validity windows of the role and its read rules and returns the resulting windows.
+func (s service) SetRoleValidity(ctx context.Context, docID, roleID []byte, v RoleValidity) (RoleValidity, error) {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return RoleValidity{}, err
+	}
+
+	if v.Role != nil {
+		err = doc.SetRoleValidity(roleID, *v.Role)
+		if err != nil {
+			return RoleValidity{}, err
+		}
+	}
+
+	if v.ReadRules != nil {
+		err = doc.SetReadRuleValidity(roleID, *v.ReadRules)
+		if err != nil {
+			return RoleValidity{}, err
+		}
+	}
+
+	role, read, err := doc.RoleValidity(roleID)
+	if err != nil {
+		return RoleValidity{}, err
+	}
+
+	return RoleValidity{Role: &role, ReadRules: &read}, s.update(ctx, accID[:], docID, doc)
 }
 
 // AttributeRule contains Attribute key label for which the rule has to be created
@@ -289,6 +568,10 @@ type AttributeRule struct {
 
 	// roleID is 32 byte role ID in hex. RoleID should already be part of the document.
 	RoleID byteutils.HexBytes `json:"role_id" swaggertype:"primitive,string"`
+
+	// Constraint optionally constrains the values of the attribute for all the collaborators.
+	// Attribute can only be constrained once.
+	Constraint *documents.ValueConstraint `json:"constraint,omitempty"`
 }
 
 // AddTransitionRules contains list of attribute rules to be created.
@@ -314,10 +597,17 @@ func (s service) AddTransitionRules(ctx context.Context, docID []byte, addRules
 			return nil, err
 		}
 
+		if r.Constraint != nil {
+			err = documents.AddValueConstraint(doc, key, *r.Constraint)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		rules = append(rules, rule)
 	}
 
-	return rules, s.pendingRepo.Update(accID[:], docID, doc)
+	return rules, s.update(ctx, accID[:], docID, doc)
 }
 
 func (s service) GetTransitionRule(ctx context.Context, docID, ruleID []byte) (*coredocumentpb.TransitionRule, error) {
@@ -350,5 +640,197 @@ func (s service) DeleteTransitionRule(ctx context.Context, docID, ruleID []byte)
 		return err
 	}
 
-	return s.pendingRepo.Update(did[:], docID, doc)
+	return s.update(ctx, did[:], docID, doc)
+}
+
+// List returns the documents, owned by the account, that match the filter.
+// If the status is pending, we return the pending documents from the pending repo.
+// If the status is empty, we merge the pending documents with the committed documents.
+// else, we defer List to document service.
+func (s service) List(ctx context.Context, filter documents.ListFilter) (models []documents.Model, next []byte, err error) {
+	if filter.Status != documents.Pending && filter.Status != "" {
+		return s.docSrv.List(ctx, filter)
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if filter.Status == documents.Pending {
+		return s.pendingRepo.List(did[:], filter)
+	}
+
+	return s.listAll(ctx, did, filter)
+}
+
+// listAll merges the committed and the pending documents of the account, in document ID order, into a page.
+// Both sources are listed in document ID order, so a page is merged from at most limit+1 documents of each.
+// A document both committed and pending is listed once with its pending version.
+func (s service) listAll(ctx context.Context, did identity.DID, filter documents.ListFilter) (models []documents.Model, next []byte, err error) {
+	limit := filter.GetLimit()
+	filter.Limit = limit + 1
+	committed, _, err := s.docSrv.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending, _, err := s.pendingRepo.List(did[:], filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var i, j int
+	for i < len(committed) || j < len(pending) {
+		var m documents.Model
+		switch {
+		case j == len(pending):
+			m, i = committed[i], i+1
+		case i == len(committed):
+			m, j = pending[j], j+1
+		default:
+			c := bytes.Compare(committed[i].ID(), pending[j].ID())
+			if c == 0 {
+				i++
+			}
+
+			if c < 0 {
+				m, i = committed[i], i+1
+			} else {
+				m, j = pending[j], j+1
+			}
+		}
+
+		if len(models) == limit {
+			return models, m.ID(), nil
+		}
+
+		models = append(models, m)
+	}
+
+	return models, nil, nil
+}
+
+// GetVersions returns the committed versions of the document, latest first, starting at cursor if provided.
+// Pending version is not part of the version chain until committed.
+func (s service) GetVersions(ctx context.Context, docID, cursor []byte, limit int) (versions []documents.VersionInfo, next []byte, err error) {
+	return s.docSrv.GetVersions(ctx, docID, cursor, limit)
+}
+
+// ExportBundle packs the committed version of the document into a bundle verifiable without a node.
+// Pending version cannot be exported since it is not anchored.
+func (s service) ExportBundle(ctx context.Context, docID, versionID []byte) ([]byte, error) {
+	return s.docSrv.ExportBundle(ctx, docID, versionID)
+}
+
+// ImportBundle validates the anchored version in the bundle and stores it as a read-only committed version.
+// Pending documents cannot be created from the versions of a read-only document.
+func (s service) ImportBundle(ctx context.Context, data []byte) (documents.Model, error) {
+	return s.docSrv.ImportBundle(ctx, data)
+}
+
+// Diff returns the changes made in the version to compared to the version from.
+// If to is empty, pending version is used if present, else the latest committed version.
+// If from is empty, previous version of to is used.
+func (s service) Diff(ctx context.Context, docID, from, to []byte) (diff documents.Diff, err error) {
+	var newDoc documents.Model
+	if len(to) == 0 {
+		newDoc, err = s.Get(ctx, docID, documents.Pending)
+		if err != nil {
+			newDoc, err = s.Get(ctx, docID, documents.Committed)
+		}
+	} else {
+		newDoc, err = s.GetVersion(ctx, docID, to)
+	}
+	if err != nil {
+		return diff, documents.ErrDocumentNotFound
+	}
+
+	if len(from) == 0 {
+		from = newDoc.PreviousVersion()
+	}
+
+	if len(from) == 0 {
+		return diff, errors.NewTypedError(documents.ErrDocumentNotFound, errors.New("document has no previous version"))
+	}
+
+	oldDoc, err := s.GetVersion(ctx, docID, from)
+	if err != nil {
+		return diff, documents.ErrDocumentNotFound
+	}
+
+	return documents.DiffVersions(oldDoc, newDoc)
+}
+
+// GetForks returns the local versions of the document that lost against versions anchored by other collaborators.
+func (s service) GetForks(ctx context.Context, docID []byte) ([]*documents.Fork, error) {
+	return s.docSrv.GetForks(ctx, docID)
+}
+
+// Rebase creates a pending document from the latest committed version of the document
+// with the changes made in the forked version versionID since its previous version.
+func (s service) Rebase(ctx context.Context, docID, versionID []byte) (documents.Model, error) {
+	rb, err := s.docSrv.Rebase(ctx, docID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.create(ctx, rb.Payload, rb.Apply)
+}
+
+// ListInbox returns the received versions in the inbox of the account, oldest first, that match the filter.
+func (s service) ListInbox(ctx context.Context, filter documents.InboxFilter) ([]*documents.InboxItem, []byte, error) {
+	return s.docSrv.ListInbox(ctx, filter)
+}
+
+// MarkInboxItemRead marks the item in the inbox of the account as read.
+func (s service) MarkInboxItemRead(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	return s.docSrv.MarkInboxItemRead(ctx, id)
+}
+
+// AckInboxItem marks the item in the inbox of the account as acknowledged.
+func (s service) AckInboxItem(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	return s.docSrv.AckInboxItem(ctx, id)
+}
+
+// Validate runs the checks applied on commit against the pending document without committing it
+// and returns every failure.
+func (s service) Validate(ctx context.Context, docID []byte) ([]documents.ValidationFailure, error) {
+	doc, _, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := s.latestVersion(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := documents.NewValidationFailures(documents.CheckApprovals, documents.ValidateApprovals(old, doc))
+	dfs, err := s.docSrv.DryRun(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(failures, dfs...), nil
+}
+
+// SetSignaturePolicy sets the policy deciding if the pending document is anchored when collaborators fail to sign it.
+func (s service) SetSignaturePolicy(ctx context.Context, docID []byte, p documents.SignaturePolicy) (documents.SignaturePolicy, error) {
+	doc, accID, err := s.getDocumentAndAccount(ctx, docID)
+	if err != nil {
+		return documents.SignaturePolicy{}, err
+	}
+
+	err = documents.SetSignaturePolicy(doc, p)
+	if err != nil {
+		return documents.SignaturePolicy{}, err
+	}
+
+	return p, s.update(ctx, accID[:], docID, doc)
+}
+
+// RetryCommit resumes the anchoring of the committed document from the last stage completed by the failed job.
+func (s service) RetryCommit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error) {
+	return s.docSrv.RetryCommit(ctx, docID)
 }
@@ -5,6 +5,7 @@ package pending
 import (
 	"context"
 	"testing"
+	"time"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/contextutil"
@@ -12,6 +13,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/templates"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -36,6 +38,11 @@ func (m *mockRepo) Delete(accID, id []byte) error {
 	return args.Error(0)
 }
 
+func (m *mockRepo) DeleteIfMatch(accID, id []byte, etag string) error {
+	args := m.Called(accID, id, etag)
+	return args.Error(0)
+}
+
 func (m *mockRepo) Create(accID, id []byte, doc documents.Model) error {
 	args := m.Called(accID, id, doc)
 	return args.Error(0)
@@ -46,6 +53,34 @@ func (m *mockRepo) Update(accID, id []byte, doc documents.Model) error {
 	return args.Error(0)
 }
 
+func (m *mockRepo) UpdateIfMatch(accID, id []byte, doc documents.Model, etag string) error {
+	args := m.Called(accID, id, doc, etag)
+	return args.Error(0)
+}
+
+func (m *mockRepo) List(accID []byte, filter documents.ListFilter) ([]documents.Model, []byte, error) {
+	args := m.Called(accID, filter)
+	docs, _ := args.Get(0).([]documents.Model)
+	next, _ := args.Get(1).([]byte)
+	return docs, next, args.Error(2)
+}
+
+func (m *mockRepo) StoreApprovalRequest(accID []byte, req *ApprovalRequest) error {
+	args := m.Called(accID, req)
+	return args.Error(0)
+}
+
+func (m *mockRepo) GetApprovalRequests(accID, docID []byte) ([]*ApprovalRequest, error) {
+	args := m.Called(accID, docID)
+	reqs, _ := args.Get(0).([]*ApprovalRequest)
+	return reqs, args.Error(1)
+}
+
+func (m *mockRepo) DeleteApprovalRequest(accID, docID []byte, owner identity.DID) error {
+	args := m.Called(accID, docID, owner)
+	return args.Error(0)
+}
+
 func TestService_Commit(t *testing.T) {
 	s := service{}
 
@@ -66,8 +101,10 @@ func TestService_Commit(t *testing.T) {
 
 	// failed commit
 	doc := new(documents.MockModel)
+	doc.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute)
 	repo.On("Get", did[:], docID).Return(doc, nil)
 	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", docID).Return(nil, documents.ErrDocumentNotFound)
 	docSrv.On("Commit", ctx, doc).Return(nil, errors.New("failed to commit")).Once()
 	s.docSrv = docSrv
 	_, _, err = s.Commit(ctx, docID)
@@ -85,6 +122,33 @@ func TestService_Commit(t *testing.T) {
 	doc.AssertExpectations(t)
 }
 
+func TestService_Delete(t *testing.T) {
+	s := service{}
+
+	// missing did
+	ctx := context.Background()
+	docID := utils.RandomSlice(32)
+	err := s.Delete(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing model
+	ctx = testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found")).Once()
+	s.pendingRepo = repo
+	err = s.Delete(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// success
+	doc := new(documents.MockModel)
+	repo.On("Get", did[:], docID).Return(doc, nil).Once()
+	repo.On("Delete", did[:], docID).Return(nil).Once()
+	assert.NoError(t, s.Delete(ctx, docID))
+	repo.AssertExpectations(t)
+}
+
 func TestService_Create(t *testing.T) {
 	s := service{}
 
@@ -126,6 +190,106 @@ func TestService_Create(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestService_CreateFromTemplate(t *testing.T) {
+	s := service{}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	tmpl := templates.Template{
+		Name:      "purchase-order",
+		Scheme:    "generic",
+		Roles:     []templates.Role{{Key: "auditor", Collaborators: []string{did.String()}}},
+		ReadRules: []templates.ReadRule{{RoleKey: "auditor"}},
+		Attributes: []templates.Attribute{
+			{Label: "status", Type: documents.AttrString, Value: "draft"},
+		},
+	}
+
+	// scheme mismatch
+	payload := documents.UpdatePayload{CreatePayload: documents.CreatePayload{Scheme: "entity"}}
+	_, err := s.CreateFromTemplate(ctx, payload, tmpl)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(templates.ErrTemplateSchemeMismatch, err))
+
+	// failed to apply rules
+	payload.Scheme = ""
+	docSrv := new(testingdocuments.MockService)
+	s.docSrv = docSrv
+	doc := new(documents.MockModel)
+	docSrv.On("Derive", ctx, mock.Anything).Return(doc, nil)
+	doc.On("AddRole", "auditor", []identity.DID{did}).Return(nil, errors.New("failed to add role")).Once()
+	_, err = s.CreateFromTemplate(ctx, payload, tmpl)
+	assert.Error(t, err)
+
+	// success
+	role := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32)}
+	docID := utils.RandomSlice(32)
+	doc.On("AddRole", "auditor", []identity.DID{did}).Return(role, nil).Once()
+	doc.On("AddReadRuleForRole", role.RoleKey).Return(new(coredocumentpb.ReadRule), nil).Once()
+	doc.On("ID").Return(docID).Once()
+	repo := new(mockRepo)
+	repo.On("Create", did[:], docID, doc).Return(nil).Once()
+	s.pendingRepo = repo
+	gdoc, err := s.CreateFromTemplate(ctx, payload, tmpl)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, gdoc)
+	doc.AssertExpectations(t)
+	repo.AssertExpectations(t)
+
+	// derived from the prepared payload
+	dp := docSrv.Calls[0].Arguments.Get(1).(documents.UpdatePayload)
+	assert.Equal(t, "generic", dp.Scheme)
+	assert.Len(t, dp.Attributes, 1)
+}
+
+func TestService_Clone(t *testing.T) {
+	s := service{}
+	docID := utils.RandomSlice(32)
+	opts := documents.CloneOptions{DropCollaborators: true}
+
+	// missing did
+	_, err := s.Clone(context.Background(), docID, opts)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing document
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", docID).Return(nil, errors.New("missing")).Once()
+	s.docSrv = docSrv
+	_, err = s.Clone(ctx, docID, opts)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// failed to copy rules
+	cd := coredocumentpb.CoreDocument{DocumentIdentifier: docID}
+	src := new(documents.MockModel)
+	src.On("PackCoreDocument").Return(cd, nil)
+	src.On("Scheme").Return("generic")
+	src.On("GetData").Return(map[string]string{"comment": "hello"})
+	src.On("GetAttributes").Return(nil)
+	docSrv.On("GetCurrentVersion", docID).Return(src, nil)
+	payload, err := documents.ClonePayload(src, opts)
+	assert.NoError(t, err)
+	doc := new(documents.MockModel)
+	docSrv.On("Derive", ctx, payload).Return(doc, nil)
+	doc.On("CopyRules", ctx, cd, opts).Return(errors.New("failed to copy")).Once()
+	_, err = s.Clone(ctx, docID, opts)
+	assert.Error(t, err)
+
+	// success
+	newID := utils.RandomSlice(32)
+	doc.On("CopyRules", ctx, cd, opts).Return(nil).Once()
+	doc.On("ID").Return(newID)
+	repo := new(mockRepo)
+	repo.On("Create", did[:], newID, doc).Return(nil).Once()
+	s.pendingRepo = repo
+	gdoc, err := s.Clone(ctx, docID, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, gdoc)
+	doc.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
+
 func TestService_Get(t *testing.T) {
 	// not pending document
 	st := documents.Committed
@@ -229,6 +393,67 @@ func TestService_Update(t *testing.T) {
 	repo.On("Update", did[:], payload.DocumentID, oldModel).Return(nil).Once()
 	_, err = s.Update(ctx, payload)
 	assert.NoError(t, err)
+
+	// stale etag
+	p := &Precondition{IfMatch: `"stale"`}
+	pctx := WithPrecondition(ctx, p)
+	oldModel.On("ID").Return(payload.DocumentID).Once()
+	oldModel.On("Patch", payload).Return(nil).Once()
+	repo.On("UpdateIfMatch", did[:], payload.DocumentID, oldModel, p.IfMatch).Return(ErrETagMismatch).Once()
+	_, err = s.Update(pctx, payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrETagMismatch, err))
+	assert.Empty(t, p.ETag)
+
+	// success with etag
+	oldModel.On("ID").Return(payload.DocumentID).Once()
+	oldModel.On("Patch", payload).Return(nil).Once()
+	oldModel.On("JSON").Return([]byte(`{"some":"state"}`), nil).Once()
+	repo.On("UpdateIfMatch", did[:], payload.DocumentID, oldModel, p.IfMatch).Return(nil).Once()
+	_, err = s.Update(pctx, payload)
+	assert.NoError(t, err)
+	assert.Len(t, p.ETag, 66)
+	repo.AssertExpectations(t)
+	oldModel.AssertExpectations(t)
+}
+
+func TestService_AddAttachment(t *testing.T) {
+	s := service{}
+	docID := utils.RandomSlice(32)
+	att, err := documents.NewAttachment("invoice.pdf", "application/pdf", utils.RandomSlice(32))
+	assert.NoError(t, err)
+
+	// missing did
+	_, err = s.AddAttachment(context.Background(), docID, att)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing document
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	prepo := new(mockRepo)
+	prepo.On("Get", did[:], docID).Return(nil, errors.New("Missing")).Once()
+	s.pendingRepo = prepo
+	_, err = s.AddAttachment(ctx, docID, att)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// invalid attachment
+	doc := new(documents.MockModel)
+	prepo.On("Get", did[:], docID).Return(doc, nil)
+	_, err = s.AddAttachment(ctx, docID, documents.Attachment{Name: "invoice.pdf"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidAttachment, err))
+
+	// success
+	attr, err := documents.NewAttachmentAttribute(att)
+	assert.NoError(t, err)
+	doc.On("AddAttributes", documents.CollaboratorsAccess{}, false, []documents.Attribute{attr}).Return(nil).Once()
+	prepo.On("Update", did[:], docID, doc).Return(nil).Once()
+	doc1, err := s.AddAttachment(ctx, docID, att)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, doc1)
+	prepo.AssertExpectations(t)
+	doc.AssertExpectations(t)
 }
 
 func TestService_AddSignedAttribute(t *testing.T) {
@@ -262,6 +487,11 @@ func TestService_AddSignedAttribute(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(documents.ErrEmptyAttrLabel, err))
 
+	// reserved label
+	_, err = s.AddSignedAttribute(ctx, docID, "_approval_policy", value)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrReservedAttribute, err))
+
 	// failed to add attribute to document
 	doc.On("AddAttributes", mock.Anything, false, mock.Anything).Return(errors.New("failed to add")).Once()
 	_, err = s.AddSignedAttribute(ctx, docID, label, value)
@@ -426,6 +656,49 @@ func TestService_UpdateRole(t *testing.T) {
 	d.AssertExpectations(t)
 }
 
+func TestService_SetRoleValidity(t *testing.T) {
+	s := service{}
+	roleID := utils.RandomSlice(32)
+	nb := time.Now().UTC()
+	na := nb.Add(time.Hour)
+	v := RoleValidity{Role: &documents.Validity{NotBefore: &nb}, ReadRules: &documents.Validity{NotAfter: &na}}
+
+	// missing did from context
+	ctx := context.Background()
+	docID := utils.RandomSlice(32)
+	_, err := s.SetRoleValidity(ctx, docID, roleID, v)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// missing doc
+	ctx = testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("Get", did[:], docID).Return(nil, errors.New("failed")).Once()
+	s.pendingRepo = repo
+	_, err = s.SetRoleValidity(ctx, docID, roleID, v)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// missing role
+	d := new(documents.MockModel)
+	repo.On("Get", did[:], docID).Return(d, nil).Twice()
+	d.On("SetRoleValidity", roleID, *v.Role).Return(documents.ErrRoleNotExist).Once()
+	_, err = s.SetRoleValidity(ctx, docID, roleID, v)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrRoleNotExist, err))
+
+	// success
+	d.On("SetRoleValidity", roleID, *v.Role).Return(nil).Once()
+	d.On("SetReadRuleValidity", roleID, *v.ReadRules).Return(nil).Once()
+	d.On("RoleValidity", roleID).Return(*v.Role, *v.ReadRules, nil).Once()
+	repo.On("Update", did[:], docID, d).Return(nil).Once()
+	rv, err := s.SetRoleValidity(ctx, docID, roleID, v)
+	assert.NoError(t, err)
+	assert.Equal(t, v, rv)
+	repo.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
 func TestService_AddTransitionRules(t *testing.T) {
 	s := service{}
 	ctx := context.Background()
@@ -473,6 +746,25 @@ func TestService_AddTransitionRules(t *testing.T) {
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
 	d.AssertExpectations(t)
+
+	// invalid constraint
+	repo.On("Get", did[:], docID).Return(d, nil).Twice()
+	addRules.AttributeRules[0].Constraint = &documents.ValueConstraint{Type: documents.ConstraintEnum}
+	d.On("AddTransitionRuleForAttribute", addRules.AttributeRules[0].RoleID.Bytes(), mock.Anything).Return(
+		new(coredocumentpb.TransitionRule), nil).Twice()
+	_, err = s.AddTransitionRules(ctx, docID, addRules)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidValueConstraint, err))
+
+	// success with constraint
+	addRules.AttributeRules[0].Constraint.Values = []string{"draft", "approved"}
+	d.On("AttributeExists", mock.Anything).Return(false).Once()
+	d.On("AddAttributes", documents.CollaboratorsAccess{}, false, mock.Anything).Return(nil).Once()
+	repo.On("Update", did[:], docID, d).Return(nil).Once()
+	_, err = s.AddTransitionRules(ctx, docID, addRules)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	d.AssertExpectations(t)
 }
 
 func TestService_GetTransitionRule(t *testing.T) {
@@ -550,3 +842,243 @@ func TestService_DeleteTransitionRules(t *testing.T) {
 	repo.AssertExpectations(t)
 	d.AssertExpectations(t)
 }
+
+func TestService_List(t *testing.T) {
+	s := service{}
+	ctx := context.Background()
+
+	// committed documents are listed from document service
+	filter := documents.ListFilter{Status: documents.Committed}
+	docSrv := new(testingdocuments.MockService)
+	docs := []documents.Model{new(documents.MockModel)}
+	next := utils.RandomSlice(32)
+	docSrv.On("List", ctx, filter).Return(docs, next, nil).Once()
+	s.docSrv = docSrv
+	gdocs, gnext, err := s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, docs, gdocs)
+	assert.Equal(t, next, gnext)
+
+	// missing did from context
+	filter.Status = documents.Pending
+	_, _, err = s.List(ctx, filter)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// pending documents
+	ctx = testingconfig.CreateAccountContext(t, cfg)
+	repo := new(mockRepo)
+	repo.On("List", did[:], filter).Return(docs, nil, nil).Once()
+	s.pendingRepo = repo
+	gdocs, gnext, err = s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, docs, gdocs)
+	assert.Empty(t, gnext)
+
+	// committed and pending documents are merged in document ID order
+	model := func(id byte) documents.Model {
+		m := new(documents.MockModel)
+		m.On("ID").Return([]byte{id})
+		return m
+	}
+	c1, c3, p2, p3, p4 := model(1), model(3), model(2), model(3), model(4)
+	filter = documents.ListFilter{Limit: 3}
+	lfilter := documents.ListFilter{Limit: 4}
+	docSrv.On("List", ctx, lfilter).Return([]documents.Model{c1, c3}, nil, nil).Once()
+	repo.On("List", did[:], lfilter).Return([]documents.Model{p2, p3, p4}, nil, nil).Once()
+	gdocs, gnext, err = s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Len(t, gdocs, 3)
+	assert.True(t, gdocs[0] == c1 && gdocs[1] == p2 && gdocs[2] == p3)
+	assert.Equal(t, []byte{4}, gnext)
+
+	// last page
+	filter.Cursor = gnext
+	lfilter.Cursor = gnext
+	docSrv.On("List", ctx, lfilter).Return(nil, nil, nil).Once()
+	repo.On("List", did[:], lfilter).Return([]documents.Model{p4}, nil, nil).Once()
+	gdocs, gnext, err = s.List(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []documents.Model{p4}, gdocs)
+	assert.Empty(t, gnext)
+	repo.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_GetVersions(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	s := service{docSrv: docSrv}
+	ctx := context.Background()
+	docID := utils.RandomSlice(32)
+	versions := []documents.VersionInfo{{VersionID: docID}}
+	docSrv.On("GetVersions", ctx, docID, []byte(nil), 10).Return(versions, nil, nil).Once()
+	gv, next, err := s.GetVersions(ctx, docID, nil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Equal(t, versions, gv)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_Bundle(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	s := service{docSrv: docSrv}
+	ctx := context.Background()
+	docID, data := utils.RandomSlice(32), utils.RandomSlice(64)
+	docSrv.On("ExportBundle", ctx, docID, []byte(nil)).Return(data, nil).Once()
+	got, err := s.ExportBundle(ctx, docID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	doc := new(testingdocuments.MockModel)
+	docSrv.On("ImportBundle", ctx, data).Return(doc, nil).Once()
+	m, err := s.ImportBundle(ctx, data)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, m)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_Diff(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	repo := new(mockRepo)
+	s := service{docSrv: docSrv, pendingRepo: repo}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID, from, to := utils.RandomSlice(32), utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing pending and committed document
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found")).Once()
+	docSrv.On("GetCurrentVersion", docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	_, err := s.Diff(ctx, docID, nil, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// document with no previous version
+	doc := new(documents.MockModel)
+	doc.On("PreviousVersion").Return([]byte(nil)).Once()
+	docSrv.On("GetVersion", docID, to).Return(doc, nil).Once()
+	_, err = s.Diff(ctx, docID, nil, to)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// missing from version
+	repo.On("Get", did[:], docID).Return(doc, nil).Twice()
+	doc.On("CurrentVersion").Return(to).Once()
+	docSrv.On("GetVersion", docID, from).Return(nil, documents.ErrDocumentNotFound).Once()
+	_, err = s.Diff(ctx, docID, from, nil)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// different documents
+	oldDoc := new(documents.MockModel)
+	oldDoc.On("ID").Return(utils.RandomSlice(32)).Once()
+	doc.On("ID").Return(docID).Once()
+	docSrv.On("GetVersion", docID, to).Return(doc, nil).Once()
+	docSrv.On("GetVersion", docID, from).Return(oldDoc, nil).Once()
+	_, err = s.Diff(ctx, docID, from, to)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentInvalid, err))
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+	doc.AssertExpectations(t)
+	oldDoc.AssertExpectations(t)
+}
+
+func TestService_Rebase(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	repo := new(mockRepo)
+	s := service{docSrv: docSrv, pendingRepo: repo}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID, versionID := utils.RandomSlice(32), utils.RandomSlice(32)
+
+	// missing fork
+	docSrv.On("Rebase", ctx, docID, versionID).Return(nil, documents.ErrForkNotFound).Once()
+	_, err := s.Rebase(ctx, docID, versionID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrForkNotFound, err))
+
+	// pending document exists
+	rb := documents.Rebase{Payload: documents.UpdatePayload{
+		CreatePayload: documents.CreatePayload{Scheme: "generic"},
+		DocumentID:    docID,
+	}}
+	docSrv.On("Rebase", ctx, docID, versionID).Return(rb, nil)
+	repo.On("Get", did[:], docID).Return(new(documents.MockModel), nil).Once()
+	_, err = s.Rebase(ctx, docID, versionID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrPendingDocumentExists, err))
+
+	// success
+	doc := new(documents.MockModel)
+	doc.On("ID").Return(docID)
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found")).Once()
+	repo.On("Create", did[:], docID, doc).Return(nil).Once()
+	docSrv.On("Derive", ctx, rb.Payload).Return(doc, nil).Once()
+	gdoc, err := s.Rebase(ctx, docID, versionID)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, gdoc)
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestService_Validate(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	repo := new(mockRepo)
+	s := service{docSrv: docSrv, pendingRepo: repo}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID := utils.RandomSlice(32)
+
+	// missing document
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found")).Once()
+	_, err := s.Validate(ctx, docID)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// failed dry run
+	doc := new(documents.MockModel)
+	doc.On("GetAttribute", mock.Anything).Return(nil, documents.ErrCDAttribute)
+	repo.On("Get", did[:], docID).Return(doc, nil)
+	docSrv.On("GetCurrentVersion", docID).Return(nil, documents.ErrDocumentNotFound)
+	docSrv.On("DryRun", ctx, doc).Return(nil, documents.ErrDocumentSchemeUnknown).Once()
+	_, err = s.Validate(ctx, docID)
+	assert.Error(t, err)
+
+	// success
+	failures := []documents.ValidationFailure{{Check: documents.CheckTransition, Error: "invalid document state transition"}}
+	docSrv.On("DryRun", ctx, doc).Return(failures, nil).Once()
+	gfs, err := s.Validate(ctx, docID)
+	assert.NoError(t, err)
+	assert.Equal(t, failures, gfs)
+	docSrv.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestService_SetSignaturePolicy(t *testing.T) {
+	repo := new(mockRepo)
+	s := service{pendingRepo: repo}
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	docID := utils.RandomSlice(32)
+	p := documents.SignaturePolicy{Mode: documents.SignaturePolicyAll}
+
+	// missing document
+	repo.On("Get", did[:], docID).Return(nil, errors.New("not found")).Once()
+	_, err := s.SetSignaturePolicy(ctx, docID, p)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrDocumentNotFound, err))
+
+	// invalid policy
+	doc := new(documents.MockModel)
+	repo.On("Get", did[:], docID).Return(doc, nil)
+	_, err = s.SetSignaturePolicy(ctx, docID, documents.SignaturePolicy{Mode: "unknown"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(documents.ErrInvalidSignaturePolicy, err))
+
+	// success
+	attr, err := documents.NewStringAttribute("_signature_policy", documents.AttrString, `{"mode":"all","required_signers":null}`)
+	assert.NoError(t, err)
+	doc.On("AddAttributes", documents.CollaboratorsAccess{}, false, []documents.Attribute{attr}).Return(nil).Once()
+	repo.On("Update", did[:], docID, doc).Return(nil).Once()
+	sp, err := s.SetSignaturePolicy(ctx, docID, p)
+	assert.NoError(t, err)
+	assert.Equal(t, p, sp)
+	doc.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
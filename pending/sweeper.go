@@ -0,0 +1,81 @@
+package pending
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	logging "github.com/ipfs/go-log"
+)
+
+var log = logging.Logger("pending")
+
+// Config defines the configs required by the pending document sweeper.
+type Config interface {
+	// GetPendingDocumentTTL returns the duration after which untouched pending documents are discarded.
+	// Zero disables the sweeper.
+	GetPendingDocumentTTL() time.Duration
+
+	// GetPendingDocumentSweepInterval returns the interval at which the pending documents are checked for expiry.
+	GetPendingDocumentSweepInterval() time.Duration
+}
+
+// Sweeper periodically discards the pending documents that are not created or updated within the TTL.
+type Sweeper struct {
+	config Config
+	repo   Repository
+}
+
+// NewSweeper returns a new Sweeper.
+func NewSweeper(config Config, repo Repository) *Sweeper {
+	return &Sweeper{config: config, repo: repo}
+}
+
+// Name of the sweeper
+func (s *Sweeper) Name() string {
+	return "PendingDocumentSweeper"
+}
+
+// Start sweeps the pending documents at every interval until the context is done.
+// Returns immediately if the TTL is not set.
+func (s *Sweeper) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	ttl := s.config.GetPendingDocumentTTL()
+	if ttl <= 0 {
+		log.Info("Pending document sweeper is disabled")
+		return
+	}
+
+	interval := s.config.GetPendingDocumentSweepInterval()
+	if interval <= 0 {
+		startupErr <- errors.New("invalid pending document sweep interval: %v", interval)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	s.sweep(ttl)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Pending document sweeper stopped")
+			return
+		case <-ticker.C:
+			s.sweep(ttl)
+		}
+	}
+}
+
+// sweep deletes the pending documents that are not touched within the ttl.
+func (s *Sweeper) sweep(ttl time.Duration) {
+	count, err := s.repo.DeleteUntouched(time.Now().UTC().Add(-ttl))
+	if err != nil {
+		log.Errorf("failed to sweep pending documents: %v", err)
+		return
+	}
+
+	if count > 0 {
+		log.Infof("Discarded %d untouched pending documents", count)
+	}
+}
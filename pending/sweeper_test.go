@@ -0,0 +1,59 @@
+// +build unit
+
+package pending
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweeper_Start(t *testing.T) {
+	r := getRepository(ctx)
+	r.(*repo).db.Register(&doc{})
+
+	// disabled
+	cfg := new(testingconfig.MockConfig)
+	cfg.On("GetPendingDocumentTTL").Return(time.Duration(0)).Once()
+	s := NewSweeper(cfg, r)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	s.Start(context.Background(), &wg, make(chan error))
+	wg.Wait()
+	cfg.AssertExpectations(t)
+
+	// invalid interval
+	cfg = new(testingconfig.MockConfig)
+	cfg.On("GetPendingDocumentTTL").Return(time.Hour).Once()
+	cfg.On("GetPendingDocumentSweepInterval").Return(time.Duration(0)).Once()
+	s = NewSweeper(cfg, r)
+	errChan := make(chan error, 1)
+	wg.Add(1)
+	s.Start(context.Background(), &wg, errChan)
+	wg.Wait()
+	assert.Error(t, <-errChan)
+	cfg.AssertExpectations(t)
+
+	// sweeps the untouched documents
+	acc, id := utils.RandomSlice(20), utils.RandomSlice(32)
+	key := r.(*repo).getKey(acc, id)
+	assert.NoError(t, r.(*repo).db.Create(key, &doc{DocID: id, Time: time.Now().UTC().Add(-2 * time.Hour)}))
+	cfg = new(testingconfig.MockConfig)
+	cfg.On("GetPendingDocumentTTL").Return(time.Hour).Once()
+	cfg.On("GetPendingDocumentSweepInterval").Return(10 * time.Millisecond).Once()
+	s = NewSweeper(cfg, r)
+	cctx, cancel := context.WithCancel(context.Background())
+	wg.Add(1)
+	go s.Start(cctx, &wg, make(chan error))
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	wg.Wait()
+	_, err := r.Get(acc, id)
+	assert.Error(t, err)
+	cfg.AssertExpectations(t)
+}
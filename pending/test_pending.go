@@ -9,6 +9,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/templates"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -31,6 +32,18 @@ func (m *MockService) Create(ctx context.Context, payload documents.UpdatePayloa
 	return doc, args.Error(1)
 }
 
+func (m *MockService) CreateFromTemplate(ctx context.Context, payload documents.UpdatePayload, tmpl templates.Template) (documents.Model, error) {
+	args := m.Called(ctx, payload, tmpl)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) Clone(ctx context.Context, docID []byte, opts documents.CloneOptions) (documents.Model, error) {
+	args := m.Called(ctx, docID, opts)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
 func (m *MockService) Update(ctx context.Context, payload documents.UpdatePayload) (documents.Model, error) {
 	args := m.Called(ctx, payload)
 	doc, _ := args.Get(0).(documents.Model)
@@ -44,6 +57,58 @@ func (m *MockService) Commit(ctx context.Context, docID []byte) (documents.Model
 	return doc, jobID, args.Error(2)
 }
 
+func (m *MockService) CommitBatch(ctx context.Context, docIDs [][]byte) (jobs.JobID, error) {
+	args := m.Called(ctx, docIDs)
+	jobID, _ := args.Get(0).(jobs.JobID)
+	return jobID, args.Error(1)
+}
+
+func (m *MockService) GetBatchCommitStatus(ctx context.Context, jobID jobs.JobID) (jobs.Status, []BatchCommitStatus, error) {
+	args := m.Called(ctx, jobID)
+	st, _ := args.Get(0).(jobs.Status)
+	docs, _ := args.Get(1).([]BatchCommitStatus)
+	return st, docs, args.Error(2)
+}
+
+func (m *MockService) Delete(ctx context.Context, docID []byte) error {
+	args := m.Called(ctx, docID)
+	return args.Error(0)
+}
+
+func (m *MockService) SetApprovalPolicy(ctx context.Context, docID []byte, p documents.ApprovalPolicy) (documents.ApprovalStatus, error) {
+	args := m.Called(ctx, docID, p)
+	st, _ := args.Get(0).(documents.ApprovalStatus)
+	return st, args.Error(1)
+}
+
+func (m *MockService) GetApprovalStatus(ctx context.Context, docID []byte) (documents.ApprovalStatus, error) {
+	args := m.Called(ctx, docID)
+	st, _ := args.Get(0).(documents.ApprovalStatus)
+	return st, args.Error(1)
+}
+
+func (m *MockService) Approve(ctx context.Context, docID []byte) ([]PendingApproval, error) {
+	args := m.Called(ctx, docID)
+	approvals, _ := args.Get(0).([]PendingApproval)
+	return approvals, args.Error(1)
+}
+
+func (m *MockService) ListPendingApprovals(ctx context.Context) ([]PendingApproval, error) {
+	args := m.Called(ctx)
+	approvals, _ := args.Get(0).([]PendingApproval)
+	return approvals, args.Error(1)
+}
+
+func (m *MockService) ReceiveApprovalRequest(ctx context.Context, model documents.Model, owner identity.DID) error {
+	args := m.Called(ctx, model, owner)
+	return args.Error(0)
+}
+
+func (m *MockService) ReceiveApproval(ctx context.Context, model documents.Model, approver identity.DID) error {
+	args := m.Called(ctx, model, approver)
+	return args.Error(0)
+}
+
 func (m *MockService) Get(ctx context.Context, docID []byte, st documents.Status) (documents.Model, error) {
 	args := m.Called(ctx, docID, st)
 	doc, _ := args.Get(0).(documents.Model)
@@ -56,12 +121,41 @@ func (m *MockService) GetVersion(ctx context.Context, docID, versionID []byte) (
 	return doc, args.Error(1)
 }
 
+func (m *MockService) GrantAccessToken(ctx context.Context, docID []byte, grantee identity.DID, tokenDocID []byte) (*coredocumentpb.AccessToken, error) {
+	args := m.Called(ctx, docID, grantee, tokenDocID)
+	at, _ := args.Get(0).(*coredocumentpb.AccessToken)
+	return at, args.Error(1)
+}
+
+func (m *MockService) GetAccessTokens(ctx context.Context, docID []byte) ([]*coredocumentpb.AccessToken, error) {
+	args := m.Called(ctx, docID)
+	ats, _ := args.Get(0).([]*coredocumentpb.AccessToken)
+	return ats, args.Error(1)
+}
+
+func (m *MockService) RevokeAccessToken(ctx context.Context, docID, tokenID []byte) error {
+	args := m.Called(ctx, docID, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockService) GetWithAccessToken(ctx context.Context, granter identity.DID, tokenID, docID, delegatingDocID []byte) (documents.Model, error) {
+	args := m.Called(ctx, granter, tokenID, docID, delegatingDocID)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
 func (m *MockService) AddSignedAttribute(ctx context.Context, docID []byte, label string, value []byte) (documents.Model, error) {
 	args := m.Called(ctx, docID, label, value)
 	doc, _ := args.Get(0).(documents.Model)
 	return doc, args.Error(1)
 }
 
+func (m *MockService) AddAttachment(ctx context.Context, docID []byte, att documents.Attachment) (documents.Model, error) {
+	args := m.Called(ctx, docID, att)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
 func (m *MockService) RemoveCollaborators(ctx context.Context, docID []byte, dids []identity.DID) (documents.Model, error) {
 	args := m.Called(ctx, docID, dids)
 	doc, _ := args.Get(0).(documents.Model)
@@ -86,6 +180,12 @@ func (m *MockService) UpdateRole(ctx context.Context, docID, roleID []byte, coll
 	return r, args.Error(1)
 }
 
+func (m *MockService) SetRoleValidity(ctx context.Context, docID, roleID []byte, v RoleValidity) (RoleValidity, error) {
+	args := m.Called(ctx, docID, roleID, v)
+	rv, _ := args.Get(0).(RoleValidity)
+	return rv, args.Error(1)
+}
+
 func (m *MockService) AddTransitionRules(ctx context.Context, docID []byte, addRule AddTransitionRules) ([]*coredocumentpb.TransitionRule, error) {
 	args := m.Called(ctx, docID, addRule)
 	r, _ := args.Get(0).([]*coredocumentpb.TransitionRule)
@@ -102,3 +202,85 @@ func (m *MockService) DeleteTransitionRule(ctx context.Context, docID, ruleID []
 	args := m.Called(ctx, docID, ruleID)
 	return args.Error(0)
 }
+
+func (m *MockService) List(ctx context.Context, filter documents.ListFilter) ([]documents.Model, []byte, error) {
+	args := m.Called(ctx, filter)
+	docs, _ := args.Get(0).([]documents.Model)
+	next, _ := args.Get(1).([]byte)
+	return docs, next, args.Error(2)
+}
+
+func (m *MockService) GetVersions(ctx context.Context, docID, cursor []byte, limit int) ([]documents.VersionInfo, []byte, error) {
+	args := m.Called(ctx, docID, cursor, limit)
+	versions, _ := args.Get(0).([]documents.VersionInfo)
+	next, _ := args.Get(1).([]byte)
+	return versions, next, args.Error(2)
+}
+
+func (m *MockService) ExportBundle(ctx context.Context, docID, versionID []byte) ([]byte, error) {
+	args := m.Called(ctx, docID, versionID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (m *MockService) ImportBundle(ctx context.Context, data []byte) (documents.Model, error) {
+	args := m.Called(ctx, data)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) Diff(ctx context.Context, docID, from, to []byte) (documents.Diff, error) {
+	args := m.Called(ctx, docID, from, to)
+	diff, _ := args.Get(0).(documents.Diff)
+	return diff, args.Error(1)
+}
+
+func (m *MockService) GetForks(ctx context.Context, docID []byte) ([]*documents.Fork, error) {
+	args := m.Called(ctx, docID)
+	forks, _ := args.Get(0).([]*documents.Fork)
+	return forks, args.Error(1)
+}
+
+func (m *MockService) Rebase(ctx context.Context, docID, versionID []byte) (documents.Model, error) {
+	args := m.Called(ctx, docID, versionID)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) ListInbox(ctx context.Context, filter documents.InboxFilter) ([]*documents.InboxItem, []byte, error) {
+	args := m.Called(ctx, filter)
+	items, _ := args.Get(0).([]*documents.InboxItem)
+	next, _ := args.Get(1).([]byte)
+	return items, next, args.Error(2)
+}
+
+func (m *MockService) MarkInboxItemRead(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	args := m.Called(ctx, id)
+	item, _ := args.Get(0).(*documents.InboxItem)
+	return item, args.Error(1)
+}
+
+func (m *MockService) AckInboxItem(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	args := m.Called(ctx, id)
+	item, _ := args.Get(0).(*documents.InboxItem)
+	return item, args.Error(1)
+}
+
+func (m *MockService) Validate(ctx context.Context, docID []byte) ([]documents.ValidationFailure, error) {
+	args := m.Called(ctx, docID)
+	failures, _ := args.Get(0).([]documents.ValidationFailure)
+	return failures, args.Error(1)
+}
+
+func (m *MockService) SetSignaturePolicy(ctx context.Context, docID []byte, p documents.SignaturePolicy) (documents.SignaturePolicy, error) {
+	args := m.Called(ctx, docID, p)
+	sp, _ := args.Get(0).(documents.SignaturePolicy)
+	return sp, args.Error(1)
+}
+
+func (m *MockService) RetryCommit(ctx context.Context, docID []byte) (documents.Model, jobs.JobID, error) {
+	args := m.Called(ctx, docID)
+	doc, _ := args.Get(0).(documents.Model)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	return doc, jobID, args.Error(2)
+}
@@ -69,7 +69,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _goCentrifugeBuildConfigsDefault_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x4b\x6f\xdb\x30\x12\xbe\xeb\x57\x0c\x9c\x4b\xbb\x68\x6d\x4b\x7e\xc4\x31\xb0\x07\x27\x4e\xdc\x34\x0f\x38\x71\x9a\xb4\xbd\x2c\x68\x6a\x24\xb1\x96\x48\x95\xa4\xfc\xc8\xaf\x5f\x0c\x45\x39\x49\xd3\x6e\x77\xbb\xd8\x05\x16\xd8\x5c\x2c\x90\x9c\x6f\x86\x33\xdf\x3c\x98\x03\x98\x62\xc2\xaa\xdc\x42\x8c\x6b\xcc\x55\x59\xa0\xb4\x60\xd1\x58\x89\x16\x58\xca\x84\x34\x16\x56\x6a\xcd\x64\xc0\x51\x5a\x2d\x92\x2a\xc5\x6b\xb4\x1b\xa5\x57\x63\x48\x72\x21\x6d\xe0\x40\x84\x44\xb0\x19\x42\xec\xf1\x64\x7d\xc6\x80\xcd\x98\x85\x93\xbd\x2c\x14\x4c\x48\x4b\xb8\x41\x73\x64\x1c\x00\x1c\xc0\xa5\xe2\x2c\x77\xaa\x85\x4c\x81\x2b\x69\x35\xe3\x16\x58\x1c\x6b\x34\x06\x0d\x48\xc4\x18\xac\x82\x25\x82\x41\x0b\x1b\x61\x33\x40\xb9\x86\x35\xd3\x82\x2d\x73\x34\xed\x00\x1a\x79\x82\x04\x10\xf1\x18\x7a\xbd\x9e\xfb\x46\x9b\xa1\xc6\xaa\xf0\xb6\x9f\xc7\x63\x18\xf5\x46\xf5\xde\x52\x29\x6b\xac\x66\xe5\x1c\x51\x9b\x5a\xf6\x3d\xb4\x3a\xa2\xec\x77\xc2\xe8\xb0\xdd\x6d\x77\xdb\x61\xc7\xf2\xb2\xd3\x1b\x45\xdd\xa8\x23\xca\xc4\x74\x6e\x8a\xbb\x9b\xed\x72\xb3\xaa\xbe\x7e\xf9\x32\x4d\xaa\xc7\xbb\xe5\xf6\x74\x72\x8b\x77\xd7\x27\x97\xea\x71\xb7\x1b\x0c\x46\xeb\x1b\x99\xde\xaf\xe7\x57\xdf\x2e\xbf\xac\x5a\xbf\x01\xed\x35\xa0\xf7\xc9\xf0\xf4\x7a\x58\xac\xbe\x3f\xe0\xb7\x87\x8b\x87\xe8\xfb\xbc\x0a\x87\x9f\xcb\x78\xd6\x5b\x7d\x54\xe1\x5d\xaf\xc8\x58\x36\x3f\x1e\x2c\x70\x20\xc3\x1a\xb4\x71\xd5\xa4\xf1\x54\x7d\x01\xba\x3e\x4a\x2b\xec\xee\x8c\x71\xab\xf4\x6e\x0c\xad\x56\xe0\x5c\x7d\xc5\x84\x7c\x15\xf0\x26\x62\xf0\xe6\x82\xc2\xfd\x36\x80\x3a\xbc\x35\xda\x01\x5c\x57\x05\x6a\xc1\xe1\x7c\x0a\x2a\x71\xa1\x7e\x16\x54\x2f\xbb\xf7\x7a\x18\x79\xa9\xe3\xc6\xb5\x90\x0b\x63\x49\x52\xaa\x18\x5f\xb3\xa2\xd4\x6a\x2d\xdc\x86\x72\xd8\x4e\x75\x43\xc4\xdf\x06\xa9\x37\x68\x47\xfd\xa8\x1d\xf5\xba\xed\x30\x1c\xfe\x18\xa9\x30\x9a\xf6\x2e\x94\x7a\x58\x2c\xb7\xcb\x8b\x93\xe5\xd7\xec\xe8\xe3\xbd\x35\x37\xbb\xfb\x59\x7c\x37\xd7\xac\x7f\x5b\x2e\x26\x7d\xbb\x5c\x9b\x21\x93\x61\xf8\x6d\x33\x9b\x44\x8f\xad\x57\xf8\xbd\x7e\xfb\x30\x6a\x87\xd1\xe1\xaf\xe0\x6f\x8a\x88\x2f\x0a\x7d\x2a\xd8\xe2\xea\xbe\x9f\x7e\x5a\x1f\x3e\xcc\xb2\x32\xbd\xdd\xa8\xd1\x46\x9d\x2d\xcc\x87\xec\xeb\x6c\x39\x13\x3d\x36\x19\x6d\x5b\xde\x3d\xa7\x9e\x95\x7b\xe7\x9f\x4f\xe1\x3d\xb8\x00\xfc\x8a\xb5\xfd\xc6\xb5\x97\xcc\x85\x2d\xc6\x32\x57\x3b\x8c\x61\x51\x30\x6d\xe1\xc4\xb3\xc1\x40\xa2\xb4\x73\x65\x2a\xd6\x28\x5f\xb8\xf2\x5f\x60\x4c\x77\x1b\xf6\x86\xd1\x29\x3f\x4e\x46\xc3\xc3\xa3\xa8\xdf\x3b\x8d\xfa\xc9\xa4\x7b\x7a\xd2\x8f\x06\x71\x84\x61\x77\xd2\x1d\x45\x51\x8f\x1f\x4e\x9f\x73\xcb\x58\x96\x52\x16\xbf\xa6\x14\x2b\x96\xa8\xff\x8c\x52\xe1\xbf\x49\x29\xa7\xfa\xb7\x94\xfa\xcf\x93\xea\xff\xb4\xfa\x43\x5a\x51\x4b\x7a\x62\x45\x51\xaf\xfc\x19\x97\xba\xff\x4c\x49\x09\x8f\x46\xed\x30\x8a\xda\x61\xf8\xcb\xe0\x4c\xd2\xde\x29\x9f\x58\xfd\xe5\xfe\x64\xbb\x79\x1c\xae\x86\xe6\xee\x48\x7c\x5d\xdc\x3e\xda\xc7\xa3\xe9\xe1\xee\xd3\x63\x79\x3c\xbf\x3d\x3d\x7b\xd4\x9f\xd4\xfd\xeb\x92\x42\xec\x8a\xc2\x76\x18\xbe\x6a\x2e\x0d\xfe\xc5\x6c\x23\xb6\x9f\x51\x56\x9f\x27\xf7\xdf\x57\x1f\x2f\x0a\xf9\x61\x31\xf9\x38\xfd\xf6\x98\x1c\xe2\xec\x4a\x0d\xad\x56\x22\xfd\xba\x2d\x0e\x27\x83\xdb\x7f\x1c\x7c\xef\xae\x5f\x85\x3f\xfc\xef\x46\x7f\x72\xd6\x1f\x0c\x79\x38\xec\x8d\x86\x6c\xd8\x4f\xe2\xfe\x59\x7f\x39\x3c\x62\x49\xd8\x63\xa3\xe1\x34\xe9\x1e\x0f\x86\xd1\x84\x75\xbb\xad\x80\xa6\x0b\x66\x19\x2c\xac\xd2\x2c\xc5\xc0\xd4\xbf\xf5\xcc\x30\x67\x36\x73\x26\xe5\xd4\xcc\xa6\xc7\x90\x88\x1c\x03\x80\x92\xd9\x6c\x0c\x1d\x5b\x94\x9d\xa7\xa9\xe5\x6f\x31\xb3\xac\xed\x4e\xc6\x4b\xc2\x3d\x51\x32\x11\x69\xa5\x99\x15\x4a\xee\x15\x70\xb7\xba\xf8\x73\x35\x35\xc0\x2b\x6d\x13\xce\x55\x25\xad\x81\x15\xee\xc0\xdf\x22\x60\x7e\x91\xf4\xac\x70\x47\xcb\xe8\x11\x9b\x2d\x92\x3d\x97\x16\x75\xc2\x38\xc2\x86\x22\xe7\x22\x30\x99\x9f\x03\x93\x31\xcc\xa3\x39\x2c\x50\xaf\x51\xbb\x7a\x88\x92\x0a\x5e\x40\x25\xf1\x83\x32\x56\xb2\x02\xa9\x1d\xfb\x79\x23\x38\x80\xb9\xd2\xd6\xc3\x10\xc4\xcf\x45\xe9\xd0\x18\x46\xdd\x51\x44\xea\x29\x3d\xde\x5b\xf5\xbe\x44\xd4\xc0\x9f\x7b\xcd\x04\x65\x54\xd6\x4e\x5a\x94\xc8\x45\xb2\x83\xd3\xad\x45\x2d\x59\x0e\xe7\xf3\x67\xd6\x12\x28\x70\x26\x69\x7a\xd3\xc8\x78\x86\x31\x30\x0b\x22\x81\x25\x66\x42\xc6\x70\x3d\xb9\x23\x18\xf4\xd2\xe7\xf3\x31\x6c\xda\xdb\xf6\xae\xfd\x58\x87\x80\xac\xae\x0c\xc6\x7b\x06\xd2\xbd\x73\xb6\x43\x4d\x81\x70\xe6\xba\xfc\x71\xa7\xef\x44\x81\xaa\x72\xd7\x94\xa0\x4a\x94\x7e\xa4\x94\xc8\x9d\xd5\xd4\x12\xe8\x32\x26\x80\x66\xd9\x8b\x8c\xa1\xd5\xeb\x9a\x96\x43\x29\x84\x14\x45\x55\x40\x8c\x39\xdb\x39\xbd\xb8\x46\xbd\x83\x32\x2a\x41\xa3\x29\x95\x34\x48\x48\x6c\xad\x44\x0c\x56\x14\xa4\x85\x59\xcb\xf8\xca\x38\x00\x16\x7f\xab\x8c\x85\x25\x23\xbb\x95\x84\x4c\x19\x4b\x92\xaa\xd2\x1c\x0d\xbc\x59\x2c\xa6\xef\xe0\x64\xfe\xe9\x1d\x70\xa5\xd1\x40\xbb\xdd\x7e\xeb\x67\x61\xb5\x02\x21\x21\x57\xa9\x4b\xb9\x31\xb4\xc8\x3e\xb2\xd5\x54\x05\xc6\xb0\xdc\xd1\xb5\xea\x18\xb4\xc8\x8b\xdb\xbf\xbe\x59\xb3\xbc\xc2\x5b\x64\x31\xfc\x05\xa2\xb7\x20\x0c\xe4\x68\x5c\x5b\x94\xe0\xf6\x60\x89\xb9\xda\xbc\x23\xef\x49\xe0\x19\x93\x29\xee\xef\x31\x75\x77\xb4\x0a\xb6\x01\xbc\x5c\x1c\x43\x6b\xd0\xed\x16\xc6\xa5\xe2\x4d\x85\x15\xfe\x40\x01\xe7\x19\x66\x76\x92\x67\x5a\x49\x55\x19\xea\xbc\x1c\x8d\x11\x32\x0d\xbe\x93\x40\x4d\x90\xfa\x91\x60\x6a\x3a\x54\xae\x19\xab\x04\xa8\x00\xa1\x36\x1d\x7f\x35\xed\xfb\xf8\x46\xe4\x39\x71\x85\xe5\xb9\xe2\xcc\xd6\x6c\x31\x96\x69\x5b\x95\x01\x90\xfc\x43\x2d\x48\xc5\xbc\xeb\xf0\xcf\x34\xa2\x81\xaa\x24\x8f\x02\xdf\xf1\x1c\x4d\x4d\x80\x5a\x05\x39\x64\xc3\x84\x7b\x5d\xf8\x58\x52\x76\x81\xdf\x7e\x60\xc2\x71\xe0\x6a\x51\x17\x43\xd7\x51\xbc\x8d\x1a\xad\x16\x68\x9c\x31\x1b\x4f\x41\x06\x96\x19\xea\x28\xf4\x73\x5b\x1f\x70\x8d\x85\x0a\x0b\x4a\x7b\x92\xb9\x41\xc8\x25\x85\xe0\x2f\x5d\xe6\x9e\x52\xee\x00\x79\x86\x52\xe3\xd3\xed\xe5\x18\x36\x66\xdc\x79\x7a\x1a\x8c\x8f\x8e\xfa\xfd\xda\x10\xca\x1d\xab\x99\x34\xcc\xd1\x17\x4a\xa5\x72\x28\xd8\x76\x6f\x98\x55\x60\x50\xc6\x64\xd4\xb3\x63\x6a\xed\x92\xa3\x60\xdb\xbd\x7d\x91\xf7\xd5\xcf\x21\x05\x95\x99\x35\xcb\x1d\xee\xae\x76\x1e\x23\xd3\x79\xa5\xb5\x7b\x27\x3c\x93\xc8\x98\x81\x25\x22\x3d\x24\x2c\x72\x8b\x71\x00\x7b\x00\xd2\x47\xc4\x89\x7c\x26\x35\x8f\xcc\x5c\x24\xe8\xb9\x68\x15\xa5\x73\xad\x83\xab\xa2\x10\xd6\x45\x86\x49\x60\x92\x67\xe4\x60\xff\xf8\x24\xba\x90\xbf\xb8\x73\xe8\x7b\x08\x61\x87\x8c\xee\x55\x9f\xbb\x14\x09\x9a\x92\xc9\x31\xb4\x46\x87\xc3\x6e\xe6\x68\xba\x6f\x81\xbf\xf0\x7f\xd3\x00\x7d\xe5\xc2\x1c\xa9\xb7\x6d\x32\xc1\xb3\x7d\x73\x04\x5f\x80\x1b\x4b\xfd\x54\xa1\x88\xc2\x7e\xb4\x8c\x29\x47\x9d\x7d\x95\xb1\xaa\xf0\x4a\x9a\xee\xe0\x5f\xc2\xbe\xee\x5f\xbb\x42\xdc\xa2\x36\xdc\xda\xbf\x77\xeb\x30\xd5\xc0\x7b\xbd\x3c\x17\xe4\x6b\x57\x31\xdf\x6c\x28\x45\xbf\x57\x42\x23\x6c\x0c\x28\x0d\xa2\xe4\xfe\x11\x4c\x6f\x5e\xfa\xe4\xcc\x92\xd9\x8e\xce\x6f\x9f\xf3\x29\xb3\xb6\x1c\x77\x3a\x94\x40\x39\x95\x9e\xf1\xd1\xa0\x3f\xa8\x2b\x1b\xdb\xba\xca\xd6\x10\x3a\x65\x74\x27\xc1\x1d\x5e\xe9\x8b\xdd\x4b\x32\x09\x09\x1b\x14\x4e\x3a\xea\xc2\x6c\x83\x02\xa4\xda\xd4\xf4\x9a\x31\x33\x27\x69\xc7\xaf\xe6\xcf\x1d\x9d\x31\x03\xb9\x28\x84\x9f\x1c\x62\x91\x24\xe8\x98\xb4\x8f\xd0\xbe\x8c\x51\x2a\xa6\xcc\x5c\xba\xd3\xcd\xfb\xfd\x44\x23\xb3\xe8\x72\xdc\x63\xd2\xea\x24\x8e\x2f\x70\x37\x86\xde\xf3\xc5\x5b\x5c\xab\x15\xba\xf5\xc1\xa0\x59\xae\x39\x72\xe2\xf8\x35\x86\xd1\x0f\xeb\x73\x8d\xcd\x56\xf8\x04\x25\x13\x7b\x45\xef\x5e\x38\x7a\xb1\x76\x47\xce\x48\x50\x9f\x69\x55\x8c\x21\x1c\xec\xf7\x98\x31\x68\x17\x75\xe7\x1e\xd2\x2a\x1c\xec\xcb\x97\xc6\x42\xad\xa9\x78\x19\x30\x4a\x49\xfa\x5d\x6a\x11\xa7\x48\xd5\x88\xb2\x25\xd5\xac\x4e\x9d\xa7\xa6\x65\x95\xab\x53\x75\x0c\xe4\x13\x2f\x9e\x47\xc3\x33\x20\x8e\xeb\x7f\x89\x30\x58\xe6\x8a\xaf\xdc\x3c\x50\x13\x01\xac\x16\x69\x8a\xda\x61\xd3\x68\x86\x5b\xdb\x94\xb8\xba\xcd\x0d\xbb\x4d\x9f\xfb\x99\x62\x4d\x7d\x44\xc9\xfc\x59\x9f\x31\xfb\x94\x6c\x4c\x7a\x82\xa6\xb6\xf3\x12\x3e\x1c\x78\xf4\xff\xed\xea\x15\x1c\x00\x93\x3b\x88\x71\x59\xa5\xa9\x9f\x22\x28\xc7\x5d\x80\x53\x05\xe4\x88\xc0\xed\xd6\xb5\x04\xa5\x4b\x4b\xb7\x42\xed\x9b\x64\x02\xa0\xaf\x31\x24\x2c\x37\xe8\x4e\x95\xa5\x56\x49\x9d\x11\x0d\x30\x4d\x31\xb4\xda\x1c\x0b\x6a\x8a\xfa\xff\x65\x95\x1a\xb9\x67\xaa\xd5\x15\x06\x7f\x0f\x00\x00\xff\xff\x33\x64\x8a\xa8\xb8\x13\x00\x00")
+var _goCentrifugeBuildConfigsDefault_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x58\x59\x73\xdb\xb0\x11\x7e\xd7\xaf\xc0\x28\x2f\x49\x27\x96\x45\x52\xf7\x4c\x1f\x64\x4b\x56\x1c\x1f\x23\x4b\x3e\x12\xbf\x74\x20\x12\xa4\x10\x91\x04\x0d\x80\xba\x7e\x7d\x77\x01\x50\xb6\xe3\xb8\x69\xd3\x69\x67\x3a\x53\xfb\x41\x1a\x00\xfb\xed\x62\x8f\x6f\x17\xfa\x40\x46\x2c\xa6\x65\xaa\x49\xc4\xd6\x2c\x15\x45\xc6\x72\x4d\x34\x53\x3a\x67\x9a\xd0\x84\xf2\x5c\x69\xb2\x12\x6b\x9a\xd7\x42\xd8\x92\x3c\x2e\x13\x76\xcd\xf4\x46\xc8\xd5\x80\xc4\x29\xcf\x75\xed\x03\x82\xf0\x9c\x11\xbd\x64\x80\x63\xf1\x72\x7b\x46\xc1\x22\xd5\xe4\xf4\x20\x4b\x32\xc0\xd4\x88\x5b\xab\x8e\x0c\x6a\x84\x7c\x20\x97\x22\xa4\xa9\x51\xcd\xf3\x84\x84\x02\x04\x68\x08\x36\x44\x91\x64\x4a\x31\x05\x88\x2c\x22\x5a\x90\x05\x23\x0a\x8c\xdb\x70\xbd\x24\x2c\x5f\x93\x35\x95\x9c\x2e\x52\xa6\x1a\x80\xe3\xe4\x11\x92\x10\x1e\x0d\x48\x10\x04\xe6\x3b\x03\xe3\x24\x2b\x33\x67\xfb\x39\x6c\xf5\x82\x9e\xdd\x5b\x08\xa1\x15\xa8\x2b\xa6\x8c\x49\x65\x65\x8f\x48\xfd\x98\x17\xad\x63\xcf\xef\x36\x9a\xf0\xef\x1d\xeb\xb0\x38\x0e\x7a\x7e\xd3\x87\xf5\x58\x1d\xdf\x64\xb7\x37\xdb\xc5\x66\x55\x3e\x7e\xff\x3e\x8a\xcb\xfd\xed\x62\x3b\x1e\xce\xd8\xed\xf5\xe9\xa5\xd8\xef\x76\xed\x76\x6f\x7d\x93\x27\xf7\xeb\xe9\xd5\x8f\xcb\xef\xab\xfa\x6f\x40\x83\x0a\xf4\x3e\xee\x8c\xaf\x3b\xd9\xea\xe9\x81\xfd\x78\xb8\x78\xf0\x9f\xa6\xa5\xd7\xf9\x56\x44\x93\x60\xf5\x55\x78\xb7\x41\xb6\xa4\xcb\xe9\x49\x7b\xce\xda\xb9\x67\x41\x2b\x57\x0d\x2b\x4f\xd9\x0b\xe0\xf5\xc1\xeb\x5c\xef\xce\x60\x53\xc8\xdd\x80\xd4\xeb\x35\xe3\xea\x2b\x70\xff\x9b\x80\x57\x11\x23\x1f\x2f\x30\xdc\x9f\xe0\xa4\x09\xaf\x45\xfb\x40\xae\xcb\x8c\x49\x1e\x92\xf3\x11\x11\xb1\x09\xf5\x8b\xa0\x3a\xd9\x83\xd7\x3d\xdf\x49\x9d\x54\xae\x25\x29\x07\x1d\x20\x99\x8b\x88\xbd\xcd\x8a\x42\x8a\x35\x37\x1b\xc2\x60\x1b\xd5\x55\x22\xfe\x36\x48\x41\xbb\xe1\xb7\xfc\x86\x1f\x80\x4b\xbd\xce\xcf\x91\xf2\xfc\x51\x70\x21\xc4\xc3\x7c\xb1\x5d\x5c\x9c\x2e\x1e\x97\xfd\xaf\xf7\x5a\xdd\xec\xee\x27\xd1\xed\x54\xd2\xd6\xac\x98\x0f\x5b\x7a\xb1\x56\x1d\x9a\x7b\xde\x8f\xcd\x64\xe8\xef\xeb\x6f\xf0\x83\x56\xa3\xeb\x37\x20\x72\xef\xc1\xdf\x64\x7e\x38\xcf\xe4\x98\xd3\xf9\xd5\x7d\x2b\xb9\x5b\x77\x1f\x26\xcb\x22\x99\x6d\x44\x6f\x23\xce\xe6\xea\xcb\xf2\x71\xb2\x98\xf0\x80\x0e\x7b\xdb\xba\x73\xcf\xd8\x65\xe5\xc1\xf9\xe0\xdd\x23\x62\x02\xf0\x5e\xd6\xb6\x2a\xd7\x5e\x52\x13\xb6\x88\x15\xa9\xd8\x41\x69\xcc\x33\x2a\xc1\xa7\x2e\x1b\x14\x89\x85\x34\xae\x4c\xf8\x9a\xe5\xaf\x5c\xf9\x2f\x64\x4c\x73\xeb\x05\x1d\x7f\x1c\x9e\xc4\xbd\x4e\xb7\xef\xb7\x82\xb1\xdf\x8a\x87\xcd\xf1\x69\xcb\x6f\x47\x3e\xf3\x9a\xc3\x66\xcf\xf7\x83\xb0\x3b\x7a\x99\x5b\x4a\xd3\x04\xab\xf8\x6d\x4a\xd1\x6c\xc1\xe4\x9f\xa5\x94\xf7\x6f\xa6\x94\x51\xfd\xdb\x94\xfa\xcf\x27\xd5\xff\xd3\xea\x0f\xd3\x0a\x5b\xd2\x73\x56\x64\x76\xe5\xcf\x72\xa9\xf9\xcf\x50\x8a\xd7\xef\x41\x60\x20\x38\xde\xbb\xc1\x19\x26\xc1\x38\x1c\x6a\xf9\xfd\xfe\x74\xbb\xd9\x77\x56\x1d\x75\xdb\xe7\x8f\xf3\xd9\x5e\xef\xfb\xa3\xee\xee\x6e\x5f\x9c\x4c\x67\xe3\xb3\xbd\xbc\x13\xf7\xf5\x5f\x52\x96\xef\x01\xbe\xf7\x1e\xfe\xc5\x64\xc3\xb7\xdf\x58\x5e\x7e\x1b\xde\x3f\xad\xbe\x5e\x64\xf9\x97\xf9\xf0\xeb\xe8\xc7\x3e\xee\xb2\xc9\x95\xe8\x68\x29\x78\xf2\xb8\xcd\xba\xc3\xf6\xec\x1f\x07\xdf\xb9\xeb\xbd\xf0\x7b\xff\xdd\xe8\x0f\xcf\x5a\xed\x4e\xe8\x75\x82\x5e\x87\x76\x5a\x71\xd4\x3a\x6b\x2d\x3a\x7d\x1a\x7b\x01\xed\x75\x46\x71\xf3\xa4\xdd\xf1\x87\xb4\xd9\x84\xe8\xc3\x74\x41\x35\x25\x73\x90\xa5\x09\xab\x29\xfb\x69\x67\x86\x29\x85\x19\x00\x4d\x4a\xb1\x99\x8d\x4e\x48\xcc\x53\x06\x3b\x05\xac\x0f\xc8\xb1\xce\x8a\xe3\xe7\xa9\xe5\x6f\x11\xe0\x34\xcc\xc9\x68\x81\xb8\x70\xab\x98\x27\xa5\xa4\x9a\x8b\xfc\xa0\x20\x34\xab\xf3\x3f\x57\x63\x01\xde\x68\x1b\x86\xa1\x28\x73\x70\xe1\x8a\xed\x88\xbb\x45\x8d\xba\x45\xd4\x03\xeb\xb8\xcc\x1c\x62\xb5\x85\xb2\xe7\xb9\x66\x32\xa6\x21\x23\x1b\x8c\x9c\x89\xc0\x70\x7a\x4e\x68\x1e\x91\xa9\x3f\x25\x73\x26\xd7\xc0\x6d\xc8\x87\x2c\x47\xc2\xab\x21\x25\x7e\x11\x10\x1d\x9a\x31\x6c\xc7\x6e\xde\x00\xac\xa9\x80\x80\x5a\x18\x84\xf8\xb5\x28\x1e\x82\x01\x09\x8a\x10\xd5\x63\x79\x1c\x69\x71\x54\xc0\x27\x09\x5f\x7a\x4d\xd5\x0a\xbf\xb0\x4e\x9a\x17\x2c\xe4\xf1\x8e\x8c\xb7\x60\x6b\x0e\xa3\xdc\xf9\xf4\x85\xb5\x08\x4a\x42\x9a\xe3\xf4\x26\x19\x0d\x97\x90\x5b\x40\xd7\x3c\x86\x85\x25\x87\x6b\x5c\x0f\x6f\x11\x86\x39\xe9\xf3\xe9\x80\x6c\x1a\xdb\xc6\xae\xb1\xb7\x21\x40\xab\x4b\x05\x52\x55\x06\xe2\xbd\x53\xba\x63\x12\x03\x61\xcc\x35\xf5\x63\x4e\xdf\xf2\x8c\x89\xd2\x5c\x33\x27\xa2\x60\xb9\x1b\x29\x73\x16\x1a\xab\xb1\x25\xe0\x65\x54\x8d\x54\xcb\x4e\x04\xb2\x33\x68\xaa\xba\x41\xc9\x78\xce\x33\xa8\xa3\x88\x81\x1e\xa3\x17\xa2\x29\x77\x04\xae\x0c\x77\x50\x05\x00\x31\x44\xa2\x6b\xc1\x61\x32\xe5\x19\x6a\xa1\x5a\xd3\x70\xa5\x0c\x00\x8d\x7e\x94\x50\x4c\x0b\x8a\x76\x43\x8a\x2d\x21\x20\x28\x29\x4a\x19\x42\x5f\xfa\x38\x9f\x8f\x3e\x93\xd3\xe9\xdd\x67\x30\x02\x96\x49\xa3\xd1\xf8\xe4\x66\x61\xb1\x22\xd0\x47\x53\x91\x98\x92\x03\xab\xd0\x3e\xb4\x55\x01\xcf\x45\x64\xb1\xc3\x6b\xd9\x18\xd4\xd1\x8b\xdb\xbf\x7e\x5c\xd3\xb4\x64\x33\x46\x23\xf2\x17\xe2\x7f\x22\x5c\x41\xba\x2a\xd3\x16\x73\x62\xf6\xc0\xd5\xa9\xd8\x7c\x46\xef\xe5\x24\x84\xe5\x84\x1d\xee\x31\x32\x77\x84\xcb\x6c\xc1\x80\x57\x8b\xa0\xbb\xdd\x6c\x66\xca\x94\xe2\x4d\xc9\x4a\xf6\x53\x0a\x18\xcf\x50\xb5\xcb\xc3\xa5\x14\xb9\x28\x15\x76\x5e\xb8\x9f\x02\x77\xd4\x9e\x50\xc0\x26\x88\x7d\x24\x28\x9b\x0e\xa5\x69\xc6\xc0\xd4\x48\x40\x10\x88\x63\x77\x35\xe9\xfa\xf8\x86\xa7\x29\xe6\x0a\x4d\x53\x78\x17\x68\x9b\x2d\x30\x56\x48\x5d\x16\x80\x06\xf2\x0f\x56\x10\xc9\xbc\x69\xf0\xcf\x24\x03\xf4\xb2\x40\x8f\x92\x70\x17\xc2\xed\x6d\x02\x58\x15\xe8\x90\x0d\xe5\xe6\x75\xe1\x62\x89\xd5\x45\xdc\xf6\x03\x6c\xa1\x8f\xaf\xe6\x96\x0c\x4d\x47\x71\x36\x4a\x06\xb5\x0d\x68\x68\xcc\xc6\xa5\x20\x25\x9a\x2a\xec\x28\xf8\x31\xb3\x07\x4c\x63\x41\x62\x01\xe4\xd3\xa5\x19\x84\x4c\x51\x40\x5b\x7a\xe5\x32\xf3\x94\x32\x07\xd0\x33\x58\x1a\x77\xb3\x4b\xc8\x77\x35\x38\x7e\x7e\x1a\x0c\xfa\xfd\x56\xcb\x1a\x82\xb5\x03\xdc\x9a\x2b\x6a\xd2\x17\xd2\x5d\xa4\x40\xe8\xdb\x83\x61\x10\x37\xc5\xa0\x88\xe8\xab\x63\x62\x6d\x8a\x03\x0e\x1e\xec\xf3\x9d\xaf\x7e\x0d\xc9\x91\x66\x20\x55\x0c\xee\xce\x3a\x8f\xa2\xe9\x61\x29\xa5\x79\x27\xbc\x90\x58\x52\x05\x01\x62\xf8\x90\xd0\x50\x3f\x2c\x02\xe0\x0a\x00\xf5\x61\xe2\xf8\xae\x92\xaa\x47\x66\xca\x63\xe6\x72\x11\x4c\x86\x72\xb6\x3a\x42\x91\x65\x5c\x9b\xc8\x40\xae\x52\x48\x24\x74\xb0\x7b\x7c\x62\xba\xa0\xbf\x42\xe3\xd0\x23\xe2\x91\x1d\xa3\x78\x2f\x7b\xee\x12\x20\x55\x41\x73\xd0\xd6\xeb\x76\x9a\xcb\xba\x25\xac\x3c\x42\xb4\x48\x84\xa5\x79\xe0\xbc\x13\x87\xc2\x9e\x73\x2c\xff\x93\x10\xe4\x52\xae\x45\x69\xa8\xca\x92\x0e\x96\x94\x40\x23\x81\xd4\x22\xae\x42\x2a\x23\x16\x7d\x26\xac\x91\x34\x48\xbd\xeb\x83\x72\x73\x30\x68\x92\x88\xee\x54\x83\x34\xf1\x94\x79\x9a\xda\xa7\xb1\x15\xc1\xb2\x80\xb4\xd1\x29\x76\x42\xe7\xa0\xf3\xca\xf3\x98\xfa\x4b\x1e\x2e\x8d\x40\xf1\xc6\x22\xd4\x0c\x06\x85\x2b\x67\x13\xdb\x16\x5c\xee\x00\x42\x6d\x18\x2b\x2a\x14\x00\xf6\xac\x23\x0e\xb3\xc0\x3b\x0e\xa8\x26\x01\x47\xe1\x2c\x65\xd8\xe4\xad\x05\xd5\x1e\x71\x9d\xa8\x0a\x99\x1b\xaf\x04\xd6\xb2\x9b\xb1\x23\x24\x2b\x13\x28\xe0\x3b\x91\x39\x25\x55\x9b\x74\x3f\x09\xb8\x06\x78\x6d\x3a\x52\x1d\xe7\x91\xfa\xe1\xe1\x6f\xf3\xd5\x02\x1f\xf4\x86\x29\xc7\xd8\x99\xd6\xf1\x71\x83\x5c\xf5\x54\x72\x70\xc0\x46\x11\xb8\x3a\x2f\x42\xf7\x6b\x00\x7a\x18\xbf\x02\x0c\x9a\x6d\xea\xfa\xd3\xcb\xc2\x5a\x6a\x5d\x40\x69\x21\x93\xa4\xc8\xc1\x83\x7e\xbb\xd5\xb6\x14\x4f\xb7\x86\xe2\xab\xca\x4e\x28\xde\x89\x87\x06\xaf\x70\xac\xff\xba\xaa\xe0\xa6\x1b\xc6\x8d\xb4\xdf\x24\x13\xf8\x0e\x8a\x36\xb6\xce\x26\x54\x4d\x51\xda\x14\x5a\xf5\x67\x8e\xc2\x0e\x64\x3f\x64\xb9\xa5\xcb\x88\xc7\x31\x33\x25\x75\x88\xd0\x81\xcf\x91\x93\xc0\x8e\x4b\x73\xba\xfa\x21\xe3\x14\x9a\xa6\x66\x86\xec\x1c\x26\xae\xc2\xac\x75\xc1\xa0\xd0\x82\x97\x8b\x33\xb6\x16\x2b\x66\xd6\xdb\xed\x6a\xd9\x16\xcb\xa9\x29\x34\x68\xec\x3f\xad\x4f\x25\xab\xb6\xbc\x67\xa8\x3c\xd6\x57\xf8\x03\x00\xe9\xbf\x5a\xbb\x45\x67\x80\xf5\x67\x52\x64\x70\xbe\x7d\xd8\xa3\x30\xf5\xe9\xb9\x1d\x61\x3a\xb8\x0a\xf7\xae\x78\x5c\xb2\x0c\xe8\x08\x18\x4a\x11\x25\xc0\x8b\x48\x1e\x92\x47\xd0\x81\xa0\xa8\x90\x36\x12\x49\x2d\x87\x3c\x77\x6f\x08\x01\x12\xb6\x8d\x41\xfe\x9c\x17\x2f\xa3\xe1\x32\x20\x8a\xec\x6f\x43\x94\x2c\x20\xca\x2b\x33\x18\xd9\x44\x80\xd3\x3c\x49\x40\x30\xb2\xbd\x5e\xc3\x84\x51\x71\xbd\xed\xf7\x60\xaa\xab\xc2\x5f\x29\x96\xd8\x50\x45\x9e\xbe\x68\xb8\xea\xc0\x4d\x95\x49\xcf\xd0\xd8\x7f\x5f\xc3\x7b\x6d\x87\xfe\xbf\x4d\xe3\x40\x26\x34\xdf\xc1\xa9\x45\x99\x24\x6e\x9c\xc2\x1a\x37\x01\x4e\x04\x41\x47\xd4\xcc\xae\xe5\x12\x96\x9b\xb2\x34\x2b\x38\xc7\x24\x96\xf6\xe0\xdb\x80\xc4\x34\x55\xcc\x9c\x2a\x80\x40\x62\x5b\x11\x15\x30\x8e\x73\xb8\x5a\x1d\xab\xd9\x14\x75\x34\x5d\x48\x16\xba\x4c\xd5\xb2\x64\xb5\xbf\x03\xf8\x69\xab\x64\xc1\x14\x00\x00")
 
 func goCentrifugeBuildConfigsDefault_configYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,7 +84,7 @@ func goCentrifugeBuildConfigsDefault_configYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "go-centrifuge/build/configs/default_config.yaml", size: 5048, mode: os.FileMode(420), modTime: time.Unix(1580138762, 0)}
+	info := bindataFileInfo{name: "go-centrifuge/build/configs/default_config.yaml", size: 5313, mode: os.FileMode(420), modTime: time.Unix(1792201691, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -0,0 +1,23 @@
+package schemas
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// BootstrappedService is the key to bootstrapped schema service
+const BootstrappedService = "BootstrappedSchemaService"
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap sets the required storage and initialises the schema service.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	ldb, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("%s not found in the bootstrapper", storage.BootstrappedDB)
+	}
+
+	ctx[BootstrappedService] = DefaultService(NewRepository(ldb))
+	return nil
+}
@@ -0,0 +1,27 @@
+// +build unit
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapper_Bootstrap(t *testing.T) {
+	ctx := make(map[string]interface{})
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.Nil(t, err)
+
+	// missing repo
+	b := Bootstrapper{}
+	assert.Error(t, b.Bootstrap(ctx))
+
+	// success
+	ctx[storage.BootstrappedDB] = leveldb.NewLevelDBRepository(db)
+	assert.NoError(t, b.Bootstrap(ctx))
+	_, ok := ctx[BootstrappedService].(Service)
+	assert.True(t, ok)
+}
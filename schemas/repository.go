@@ -0,0 +1,108 @@
+package schemas
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// schemaPrefix holds the prefix of a schema in DB
+const schemaPrefix string = "schema_"
+
+// Repository defines the required methods for a schema repository.
+type Repository interface {
+	// Get returns the version of the schema owned by accountID.
+	Get(accountID []byte, name string, version int) (*Schema, error)
+
+	// Create creates the schema version if not present in the DB.
+	Create(accountID []byte, schema *Schema) error
+
+	// GetVersions returns all the versions of the schema owned by accountID, oldest first.
+	GetVersions(accountID []byte, name string) ([]*Schema, error)
+
+	// List returns the latest version of every schema owned by accountID.
+	List(accountID []byte) ([]*Schema, error)
+}
+
+// NewRepository registers the Schema model and returns an implementation of the Repository.
+func NewRepository(db storage.Repository) Repository {
+	db.Register(new(Schema))
+	return &repo{db: db}
+}
+
+type repo struct {
+	db storage.Repository
+}
+
+// accountPrefix returns schema_+accountID+_
+func accountPrefix(accountID []byte) string {
+	return schemaPrefix + hexutil.Encode(accountID) + "_"
+}
+
+// namePrefix returns schema_+accountID+_+name+_
+// Since names cannot contain '_', the prefix of a name never matches another name.
+func namePrefix(accountID []byte, name string) string {
+	return accountPrefix(accountID) + name + "_"
+}
+
+// getKey returns namePrefix+version.
+// Version is zero padded so that the versions are ordered in the DB.
+func getKey(accountID []byte, name string, version int) []byte {
+	return []byte(fmt.Sprintf("%s%010d", namePrefix(accountID, name), version))
+}
+
+// Get returns the version of the schema owned by accountID.
+func (r *repo) Get(accountID []byte, name string, version int) (*Schema, error) {
+	m, err := r.db.Get(getKey(accountID, name, version))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSchemaNotFound, err)
+	}
+
+	s, ok := m.(*Schema)
+	if !ok {
+		return nil, errors.New("schema %s@%d for account %s is not a schema object", name, version, hexutil.Encode(accountID))
+	}
+
+	return s, nil
+}
+
+// Create creates the schema version if not present in the DB.
+func (r *repo) Create(accountID []byte, schema *Schema) error {
+	return r.db.Create(getKey(accountID, schema.Name, schema.Version), schema)
+}
+
+// GetVersions returns all the versions of the schema owned by accountID, oldest first.
+func (r *repo) GetVersions(accountID []byte, name string) (schemas []*Schema, err error) {
+	err = r.db.Iterate(namePrefix(accountID, name), nil, func(key []byte, model storage.Model) bool {
+		if s, ok := model.(*Schema); ok {
+			schemas = append(schemas, s)
+		}
+
+		return true
+	})
+
+	return schemas, err
+}
+
+// List returns the latest version of every schema owned by accountID.
+// Versions of a schema are adjacent and ordered in the DB, so the last one seen is the latest.
+func (r *repo) List(accountID []byte) (schemas []*Schema, err error) {
+	err = r.db.Iterate(accountPrefix(accountID), nil, func(key []byte, model storage.Model) bool {
+		s, ok := model.(*Schema)
+		if !ok {
+			return true
+		}
+
+		if n := len(schemas); n > 0 && schemas[n-1].Name == s.Name {
+			schemas[n-1] = s
+			return true
+		}
+
+		schemas = append(schemas, s)
+		return true
+	})
+
+	return schemas, err
+}
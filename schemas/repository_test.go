@@ -0,0 +1,104 @@
+// +build unit
+
+package schemas
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx map[string]interface{}
+var cfg config.Configuration
+var did = testingidentity.GenerateRandomDID()
+
+func TestMain(m *testing.M) {
+	ctx = make(map[string]interface{})
+	ibootstappers := []bootstrap.TestBootstrapper{
+		&testlogging.TestLoggingBootstrapper{},
+		&config.Bootstrapper{},
+		&leveldb.Bootstrapper{},
+	}
+	bootstrap.RunTestBootstrappers(ibootstappers, ctx)
+	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	cfg.Set("identityId", did.String())
+	cfg.Set("keys.p2p.publicKey", "../build/resources/p2pKey.pub.pem")
+	cfg.Set("keys.p2p.privateKey", "../build/resources/p2pKey.key.pem")
+	cfg.Set("keys.signing.publicKey", "../build/resources/signingKey.pub.pem")
+	cfg.Set("keys.signing.privateKey", "../build/resources/signingKey.key.pem")
+	result := m.Run()
+	bootstrap.RunTestTeardown(ibootstappers)
+	os.Exit(result)
+}
+
+func getRepository(ctx map[string]interface{}) Repository {
+	db := ctx[storage.BootstrappedDB].(storage.Repository)
+	return NewRepository(db)
+}
+
+func TestRepo_Create_Get(t *testing.T) {
+	repo := getRepository(ctx)
+	accID := utils.RandomSlice(20)
+	s := &Schema{Name: "invoice", Version: 1, Rules: []AttributeRule{{Label: "number", Required: true}}}
+
+	// missing
+	_, err := repo.Get(accID, s.Name, s.Version)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaNotFound, err))
+
+	// success
+	assert.NoError(t, repo.Create(accID, s))
+	gs, err := repo.Get(accID, s.Name, s.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, s, gs)
+
+	// already exists
+	assert.Error(t, repo.Create(accID, s))
+}
+
+func TestRepo_GetVersions_List(t *testing.T) {
+	repo := getRepository(ctx)
+	accID := utils.RandomSlice(20)
+	versions, err := repo.GetVersions(accID, "invoice")
+	assert.NoError(t, err)
+	assert.Empty(t, versions)
+
+	for _, s := range []*Schema{
+		{Name: "invoice", Version: 1},
+		{Name: "invoice.v2", Version: 1},
+		{Name: "invoice", Version: 2},
+		{Name: "entity", Version: 1},
+		{Name: "invoice", Version: 10},
+	} {
+		assert.NoError(t, repo.Create(accID, s))
+	}
+
+	// another account
+	assert.NoError(t, repo.Create(utils.RandomSlice(20), &Schema{Name: "invoice", Version: 1}))
+
+	versions, err = repo.GetVersions(accID, "invoice")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+	for i, v := range []int{1, 2, 10} {
+		assert.Equal(t, "invoice", versions[i].Name)
+		assert.Equal(t, v, versions[i].Version)
+	}
+
+	schemas, err := repo.List(accID)
+	assert.NoError(t, err)
+	assert.Len(t, schemas, 3)
+	latest := make(map[string]int)
+	for _, s := range schemas {
+		latest[s.Name] = s.Version
+	}
+	assert.Equal(t, map[string]int{"invoice": 10, "invoice.v2": 1, "entity": 1}, latest)
+}
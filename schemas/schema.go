@@ -0,0 +1,287 @@
+package schemas
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// ErrSchemaNotFound must be used when the schema is not registered for the account.
+	ErrSchemaNotFound = errors.Error("schema not found")
+
+	// ErrInvalidSchema must be used when the schema definition is invalid.
+	ErrInvalidSchema = errors.Error("invalid schema")
+
+	// ErrSchemaValidation must be used when the document does not conform to its schema.
+	ErrSchemaValidation = errors.Error("document does not conform to the schema")
+
+	// AttrLabel is the label of the string attribute that tags a document with a schema.
+	// The value is the schema name, optionally suffixed with @version.
+	// If the version is not provided, the latest version of the schema is used.
+	AttrLabel = "_schema"
+
+	// maxPrecision is the maximum decimal precision supported by documents.Decimal.
+	maxPrecision = 18
+)
+
+var nameRegex = regexp.MustCompile(`^[a-zA-Z0-9\-\.]+$`)
+
+// AttributeRule defines the constraints on a single attribute of the document.
+type AttributeRule struct {
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+
+	// Types are the allowed attribute types. Any type is allowed if empty.
+	Types []documents.AttributeType `json:"types" swaggertype:"array,string"`
+
+	// Precision is the maximum number of decimal places of decimal and monetary values.
+	Precision *int `json:"precision,omitempty"`
+
+	// Min and Max are the inclusive bounds of integer, decimal and monetary values.
+	Min string `json:"min,omitempty"`
+	Max string `json:"max,omitempty"`
+
+	// Pattern is the regular expression that string values must match.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Currencies are the allowed currencies of monetary values. Any currency is allowed if empty.
+	Currencies []string `json:"currencies,omitempty"`
+}
+
+// Schema is a named and versioned set of attribute rules.
+// Schemas are immutable. Updating a schema creates a new version.
+type Schema struct {
+	Name    string          `json:"name"`
+	Version int             `json:"version"`
+	Rules   []AttributeRule `json:"rules"`
+
+	// Strict rejects attributes that are not defined in the schema.
+	Strict    bool      `json:"strict"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JSON returns json marshaled schema.
+func (s *Schema) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads the data into schema.
+func (s *Schema) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the reflect.Type of the schema.
+func (s *Schema) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// ParseTag parses the schema tag into schema name and version.
+// Version is 0 if the tag doesn't have one.
+func ParseTag(tag string) (name string, version int, err error) {
+	parts := strings.SplitN(tag, "@", 2)
+	name = parts[0]
+	if !nameRegex.MatchString(name) {
+		return name, version, errors.NewTypedError(ErrInvalidSchema, errors.New("invalid schema name: %s", name))
+	}
+
+	if len(parts) == 1 {
+		return name, version, nil
+	}
+
+	version, err = strconv.Atoi(parts[1])
+	if err != nil || version < 1 {
+		return name, version, errors.NewTypedError(ErrInvalidSchema, errors.New("invalid schema version: %s", parts[1]))
+	}
+
+	return name, version, nil
+}
+
+func isTypeAllowed(tp documents.AttributeType) bool {
+	switch tp {
+	case documents.AttrInt256, documents.AttrDecimal, documents.AttrString, documents.AttrBytes,
+		documents.AttrTimestamp, documents.AttrSigned, documents.AttrMonetary:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateDefinition checks if the schema is well defined.
+func (s Schema) validateDefinition() (err error) {
+	if !nameRegex.MatchString(s.Name) {
+		err = errors.AppendError(err, errors.New("invalid schema name: %s", s.Name))
+	}
+
+	labels := make(map[string]struct{})
+	for _, r := range s.Rules {
+		if r.Label == "" || r.Label == AttrLabel {
+			err = errors.AppendError(err, errors.New("invalid attribute label: %s", r.Label))
+		}
+
+		if _, ok := labels[r.Label]; ok {
+			err = errors.AppendError(err, errors.New("duplicate attribute label: %s", r.Label))
+		}
+		labels[r.Label] = struct{}{}
+
+		for _, tp := range r.Types {
+			if !isTypeAllowed(tp) {
+				err = errors.AppendError(err, errors.New("%s: invalid attribute type: %s", r.Label, tp))
+			}
+		}
+
+		if r.Precision != nil && (*r.Precision < 0 || *r.Precision > maxPrecision) {
+			err = errors.AppendError(err, errors.New("%s: precision must be between 0 and %d", r.Label, maxPrecision))
+		}
+
+		for _, b := range []string{r.Min, r.Max} {
+			if b == "" {
+				continue
+			}
+
+			if _, errd := decimal.NewFromString(b); errd != nil {
+				err = errors.AppendError(err, errors.New("%s: invalid bound %s: %v", r.Label, b, errd))
+			}
+		}
+
+		if r.Pattern != "" {
+			if _, errr := regexp.Compile(r.Pattern); errr != nil {
+				err = errors.AppendError(err, errors.New("%s: invalid pattern: %v", r.Label, errr))
+			}
+		}
+	}
+
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidSchema, err)
+	}
+
+	return nil
+}
+
+// Validate checks if the attributes conform to the schema.
+func (s Schema) Validate(attrs []documents.Attribute) (err error) {
+	attrMap := make(map[string]documents.Attribute)
+	for _, attr := range attrs {
+		attrMap[attr.KeyLabel] = attr
+	}
+
+	for _, r := range s.Rules {
+		attr, ok := attrMap[r.Label]
+		delete(attrMap, r.Label)
+		if !ok {
+			if r.Required {
+				err = errors.AppendError(err, errors.New("%s: attribute is required", r.Label))
+			}
+
+			continue
+		}
+
+		if errv := r.validate(attr); errv != nil {
+			err = errors.AppendError(err, errv)
+		}
+	}
+
+	delete(attrMap, AttrLabel)
+	if s.Strict {
+		for label := range attrMap {
+			if documents.IsReservedAttribute(label) {
+				continue
+			}
+
+			err = errors.AppendError(err, errors.New("%s: attribute is not defined in the schema", label))
+		}
+	}
+
+	if err != nil {
+		return errors.NewTypedError(ErrSchemaValidation, err)
+	}
+
+	return nil
+}
+
+// validate checks if the attribute conforms to the rule.
+func (r AttributeRule) validate(attr documents.Attribute) error {
+	tp := attr.Value.Type
+	if len(r.Types) > 0 && !containsType(r.Types, tp) {
+		return errors.New("%s: attribute type %s is not allowed", r.Label, tp)
+	}
+
+	switch tp {
+	case documents.AttrString:
+		if r.Pattern != "" && !regexp.MustCompile(r.Pattern).MatchString(attr.Value.Str) {
+			return errors.New("%s: value doesn't match the pattern %s", r.Label, r.Pattern)
+		}
+	case documents.AttrInt256:
+		return r.validateNumber(attr.Value.Int256.String())
+	case documents.AttrDecimal:
+		return r.validateNumber(attr.Value.Decimal.String())
+	case documents.AttrMonetary:
+		m := attr.Value.Monetary
+		if len(r.Currencies) > 0 && !containsCurrency(r.Currencies, currency(m)) {
+			return errors.New("%s: currency %s is not allowed", r.Label, currency(m))
+		}
+
+		return r.validateNumber(m.Value.String())
+	}
+
+	return nil
+}
+
+// validateNumber checks the precision and bounds of the number.
+func (r AttributeRule) validateNumber(val string) error {
+	num, err := decimal.NewFromString(val)
+	if err != nil {
+		return errors.New("%s: invalid number %s: %v", r.Label, val, err)
+	}
+
+	if r.Precision != nil && -num.Exponent() > int32(*r.Precision) {
+		return errors.New("%s: value %s exceeds the precision %d", r.Label, val, *r.Precision)
+	}
+
+	if r.Min != "" && num.LessThan(decimal.RequireFromString(r.Min)) {
+		return errors.New("%s: value %s is less than %s", r.Label, val, r.Min)
+	}
+
+	if r.Max != "" && num.GreaterThan(decimal.RequireFromString(r.Max)) {
+		return errors.New("%s: value %s is greater than %s", r.Label, val, r.Max)
+	}
+
+	return nil
+}
+
+func containsType(types []documents.AttributeType, tp documents.AttributeType) bool {
+	for _, t := range types {
+		if t == tp {
+			return true
+		}
+	}
+
+	return false
+}
+
+// currency returns the readable currency of the monetary value.
+func currency(m documents.Monetary) string {
+	if m.Type == documents.MonetaryToken {
+		return hexutil.Encode(m.ID)
+	}
+
+	return string(m.ID)
+}
+
+func containsCurrency(currencies []string, cur string) bool {
+	for _, c := range currencies {
+		if strings.EqualFold(c, cur) {
+			return true
+		}
+	}
+
+	return false
+}
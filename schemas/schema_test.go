@@ -0,0 +1,225 @@
+// +build unit
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAttr(t *testing.T, label string, tp documents.AttributeType, value string) documents.Attribute {
+	attr, err := documents.NewStringAttribute(label, tp, value)
+	assert.NoError(t, err)
+	return attr
+}
+
+func newMonetaryAttr(t *testing.T, label, value, cur string) documents.Attribute {
+	dec, err := documents.NewDecimal(value)
+	assert.NoError(t, err)
+	attr, err := documents.NewMonetaryAttribute(label, dec, nil, cur)
+	assert.NoError(t, err)
+	return attr
+}
+
+func testSchema() Schema {
+	two := 2
+	return Schema{
+		Name: "invoice",
+		Rules: []AttributeRule{
+			{
+				Label:    "number",
+				Required: true,
+				Types:    []documents.AttributeType{documents.AttrString},
+				Pattern:  `^INV-[0-9]+$`,
+			},
+			{
+				Label:     "amount",
+				Required:  true,
+				Types:     []documents.AttributeType{documents.AttrDecimal, documents.AttrMonetary},
+				Precision: &two,
+				Min:       "0",
+				Max:       "1000",
+			},
+			{
+				Label:      "total",
+				Types:      []documents.AttributeType{documents.AttrMonetary},
+				Currencies: []string{"USD", "EUR"},
+			},
+			{
+				Label: "count",
+				Types: []documents.AttributeType{documents.AttrInt256},
+				Min:   "1",
+			},
+		},
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	name, version, err := ParseTag("invoice")
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice", name)
+	assert.Equal(t, 0, version)
+
+	name, version, err = ParseTag("invoice@3")
+	assert.NoError(t, err)
+	assert.Equal(t, "invoice", name)
+	assert.Equal(t, 3, version)
+
+	for _, tag := range []string{"", "inv_oice", "invoice@", "invoice@0", "invoice@one"} {
+		_, _, err = ParseTag(tag)
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidSchema, err))
+	}
+}
+
+func TestSchema_validateDefinition(t *testing.T) {
+	assert.NoError(t, testSchema().validateDefinition())
+
+	neg := -1
+	s := Schema{
+		Name: "some_name",
+		Rules: []AttributeRule{
+			{Label: ""},
+			{Label: AttrLabel},
+			{Label: "a", Types: []documents.AttributeType{"unknown"}},
+			{Label: "a", Precision: &neg},
+			{Label: "b", Min: "one", Max: "two"},
+			{Label: "c", Pattern: "[a-"},
+		},
+	}
+
+	err := s.validateDefinition()
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSchema, err))
+	for _, msg := range []string{
+		"invalid schema name: some_name",
+		"invalid attribute label: ;",
+		"invalid attribute label: " + AttrLabel,
+		"a: invalid attribute type: unknown",
+		"duplicate attribute label: a",
+		"a: precision must be between 0 and 18",
+		"b: invalid bound one",
+		"b: invalid bound two",
+		"c: invalid pattern",
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	s := testSchema()
+
+	// success
+	attrs := []documents.Attribute{
+		newAttr(t, "number", documents.AttrString, "INV-123"),
+		newMonetaryAttr(t, "amount", "999.99", "USD"),
+		newMonetaryAttr(t, "total", "10", "eur"),
+		newAttr(t, "count", documents.AttrInt256, "1"),
+		newAttr(t, "other", documents.AttrString, "value"),
+		newAttr(t, AttrLabel, documents.AttrString, "invoice"),
+	}
+	assert.NoError(t, s.Validate(attrs))
+
+	// strict fails for unknown attributes
+	s.Strict = true
+	err := s.Validate(attrs)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaValidation, err))
+	assert.Contains(t, err.Error(), "other")
+	assert.NoError(t, s.Validate(attrs[:4]))
+
+	// reserved attributes are allowed in strict schemas
+	key, err := documents.AttrKeyFromLabel("number")
+	assert.NoError(t, err)
+	constraint, err := documents.NewValueConstraintAttribute(key, documents.ValueConstraint{Type: documents.ConstraintImmutable})
+	assert.NoError(t, err)
+	assert.NoError(t, s.Validate(append(attrs[:4:4], constraint)))
+	s.Strict = false
+
+	// failures
+	tests := []struct {
+		attrs []documents.Attribute
+		err   string
+	}{
+		// required
+		{
+			attrs: []documents.Attribute{newAttr(t, "number", documents.AttrString, "INV-1")},
+			err:   "amount: attribute is required",
+		},
+
+		// type
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrInt256, "1"),
+				newAttr(t, "amount", documents.AttrDecimal, "1"),
+			},
+			err: "number: attribute type integer is not allowed",
+		},
+
+		// pattern
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1a"),
+				newAttr(t, "amount", documents.AttrDecimal, "1"),
+			},
+			err: "number: value doesn't match the pattern",
+		},
+
+		// precision
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1"),
+				newAttr(t, "amount", documents.AttrDecimal, "1.001"),
+			},
+			err: "amount: value 1.001 exceeds the precision 2",
+		},
+
+		// min
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1"),
+				newAttr(t, "amount", documents.AttrDecimal, "-1"),
+			},
+			err: "amount: value -1 is less than 0",
+		},
+
+		// max
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1"),
+				newMonetaryAttr(t, "amount", "1000.01", "USD"),
+			},
+			err: "amount: value 1000.01 is greater than 1000",
+		},
+
+		// currency
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1"),
+				newAttr(t, "amount", documents.AttrDecimal, "1"),
+				newMonetaryAttr(t, "total", "10", "GBP"),
+			},
+			err: "total: currency GBP is not allowed",
+		},
+
+		// integer bound
+		{
+			attrs: []documents.Attribute{
+				newAttr(t, "number", documents.AttrString, "INV-1"),
+				newAttr(t, "amount", documents.AttrDecimal, "1"),
+				newAttr(t, "count", documents.AttrInt256, "0"),
+			},
+			err: "count: value 0 is less than 1",
+		},
+	}
+
+	for _, c := range tests {
+		err := s.Validate(c.attrs)
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrSchemaValidation, err))
+		assert.Contains(t, err.Error(), c.err)
+	}
+}
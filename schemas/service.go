@@ -0,0 +1,152 @@
+package schemas
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// Service defines the functions to manage the schemas of an account and to validate the documents against them.
+type Service interface {
+	// Create registers the next version of the schema for the account.
+	// The first version is created if the schema doesn't exist yet.
+	Create(ctx context.Context, schema Schema) (*Schema, error)
+
+	// Get returns the version of the schema. Latest version is returned if the version is 0.
+	Get(ctx context.Context, name string, version int) (*Schema, error)
+
+	// GetVersions returns all the versions of the schema, oldest first.
+	GetVersions(ctx context.Context, name string) ([]*Schema, error)
+
+	// List returns the latest version of every schema registered by the account.
+	List(ctx context.Context) ([]*Schema, error)
+
+	// Validate checks if the attributes of the document conform to the schema the document is tagged with.
+	// Documents without a schema tag are always valid. Documents tagged with an unknown schema are invalid.
+	Validate(ctx context.Context, model documents.Model) error
+}
+
+type service struct {
+	repo Repository
+
+	// mu serialises the version allocation of the schemas.
+	mu *sync.Mutex
+}
+
+// DefaultService returns the default implementation of the Service.
+func DefaultService(repo Repository) Service {
+	return service{repo: repo, mu: new(sync.Mutex)}
+}
+
+// Create registers the next version of the schema for the account.
+func (s service) Create(ctx context.Context, schema Schema) (*Schema, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if err := schema.validateDefinition(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions, err := s.repo.GetVersions(did[:], schema.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Version = len(versions) + 1
+	schema.CreatedAt = time.Now().UTC()
+	return &schema, s.repo.Create(did[:], &schema)
+}
+
+// Get returns the version of the schema. Latest version is returned if the version is 0.
+func (s service) Get(ctx context.Context, name string, version int) (*Schema, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if version != 0 {
+		return s.repo.Get(did[:], name, version)
+	}
+
+	versions, err := s.repo.GetVersions(did[:], name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrSchemaNotFound
+	}
+
+	return versions[len(versions)-1], nil
+}
+
+// GetVersions returns all the versions of the schema, oldest first.
+func (s service) GetVersions(ctx context.Context, name string) ([]*Schema, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	versions, err := s.repo.GetVersions(did[:], name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrSchemaNotFound
+	}
+
+	return versions, nil
+}
+
+// List returns the latest version of every schema registered by the account.
+func (s service) List(ctx context.Context) ([]*Schema, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.repo.List(did[:])
+}
+
+// Validate checks if the attributes of the document conform to the schema the document is tagged with.
+// Document tagged with a schema unknown to the account is invalid.
+func (s service) Validate(ctx context.Context, model documents.Model) error {
+	key, err := documents.AttrKeyFromLabel(AttrLabel)
+	if err != nil {
+		return err
+	}
+
+	if !model.AttributeExists(key) {
+		return nil
+	}
+
+	attr, err := model.GetAttribute(key)
+	if err != nil {
+		return err
+	}
+
+	if attr.Value.Type != documents.AttrString {
+		return errors.NewTypedError(ErrSchemaValidation, errors.New("%s: attribute must be of type string", AttrLabel))
+	}
+
+	name, version, err := ParseTag(attr.Value.Str)
+	if err != nil {
+		return errors.NewTypedError(ErrSchemaValidation, err)
+	}
+
+	schema, err := s.Get(ctx, name, version)
+	if err != nil {
+		return errors.NewTypedError(ErrSchemaValidation, err)
+	}
+
+	return schema.Validate(model.GetAttributes())
+}
@@ -0,0 +1,157 @@
+// +build unit
+
+package schemas
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Create_Get(t *testing.T) {
+	srv := DefaultService(getRepository(ctx))
+
+	// missing account
+	_, err := srv.Create(context.Background(), testSchema())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// invalid schema
+	actx := testingconfig.CreateAccountContext(t, cfg)
+	_, err = srv.Create(actx, Schema{Name: "invalid name"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSchema, err))
+
+	// missing schema
+	name := "schema-" + did.String()
+	_, err = srv.Get(actx, name, 0)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaNotFound, err))
+	_, err = srv.GetVersions(actx, name)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaNotFound, err))
+
+	// first and second versions
+	s := testSchema()
+	s.Name = name
+	s1, err := srv.Create(actx, s)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s1.Version)
+	assert.False(t, s1.CreatedAt.IsZero())
+	s.Strict = true
+	s2, err := srv.Create(actx, s)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s2.Version)
+
+	gs, err := srv.Get(actx, name, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, gs.Version)
+	assert.True(t, gs.Strict)
+
+	gs, err = srv.Get(actx, name, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, gs.Version)
+	assert.False(t, gs.Strict)
+
+	versions, err := srv.GetVersions(actx, name)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	schemas, err := srv.List(actx)
+	assert.NoError(t, err)
+	found := false
+	for _, s := range schemas {
+		if s.Name == name {
+			found = true
+			assert.Equal(t, 2, s.Version)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestService_Create_Concurrent(t *testing.T) {
+	srv := DefaultService(getRepository(ctx))
+	actx := testingconfig.CreateAccountContext(t, cfg)
+	s := testSchema()
+	s.Name = "concurrent-" + did.String()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := srv.Create(actx, s)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	versions, err := srv.GetVersions(actx, s.Name)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 5)
+	for i, v := range versions {
+		assert.Equal(t, i+1, v.Version)
+	}
+}
+
+func TestService_Validate(t *testing.T) {
+	srv := DefaultService(getRepository(ctx))
+	actx := testingconfig.CreateAccountContext(t, cfg)
+	s := testSchema()
+	s.Name = "validate-" + did.String()
+	_, err := srv.Create(actx, s)
+	assert.NoError(t, err)
+	key, err := documents.AttrKeyFromLabel(AttrLabel)
+	assert.NoError(t, err)
+
+	// no schema tag
+	model := new(documents.MockModel)
+	model.On("AttributeExists", key).Return(false).Once()
+	assert.NoError(t, srv.Validate(actx, model))
+
+	// wrong tag type
+	tag := newAttr(t, AttrLabel, documents.AttrInt256, "1")
+	model.On("AttributeExists", key).Return(true)
+	model.On("GetAttribute", key).Return(tag, nil).Once()
+	err = srv.Validate(actx, model)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaValidation, err))
+
+	// unknown schema
+	tag = newAttr(t, AttrLabel, documents.AttrString, s.Name+"@2")
+	model.On("GetAttribute", key).Return(tag, nil).Once()
+	err = srv.Validate(actx, model)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaValidation, err))
+	assert.True(t, errors.IsOfType(ErrSchemaNotFound, err))
+
+	// not conforming
+	tag = newAttr(t, AttrLabel, documents.AttrString, s.Name)
+	model.On("GetAttribute", key).Return(tag, nil).Once()
+	model.On("GetAttributes").Return([]documents.Attribute{tag}).Once()
+	err = srv.Validate(actx, model)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrSchemaValidation, err))
+
+	// success
+	tag = newAttr(t, AttrLabel, documents.AttrString, s.Name+"@1")
+	model.On("GetAttribute", key).Return(tag, nil).Once()
+	model.On("GetAttributes").Return([]documents.Attribute{
+		tag,
+		newAttr(t, "number", documents.AttrString, "INV-1"),
+		newAttr(t, "amount", documents.AttrDecimal, "10.5"),
+	}).Once()
+	assert.NoError(t, srv.Validate(actx, model))
+	model.AssertExpectations(t)
+}
@@ -0,0 +1,52 @@
+// +build integration unit
+
+package schemas
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+// MockService implements Service
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, schema Schema) (*Schema, error) {
+	args := m.Called(ctx, schema)
+	s, _ := args.Get(0).(*Schema)
+	return s, args.Error(1)
+}
+
+func (m *MockService) Get(ctx context.Context, name string, version int) (*Schema, error) {
+	args := m.Called(ctx, name, version)
+	s, _ := args.Get(0).(*Schema)
+	return s, args.Error(1)
+}
+
+func (m *MockService) GetVersions(ctx context.Context, name string) ([]*Schema, error) {
+	args := m.Called(ctx, name)
+	s, _ := args.Get(0).([]*Schema)
+	return s, args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context) ([]*Schema, error) {
+	args := m.Called(ctx)
+	s, _ := args.Get(0).([]*Schema)
+	return s, args.Error(1)
+}
+
+func (m *MockService) Validate(ctx context.Context, model documents.Model) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
@@ -0,0 +1,60 @@
+package leveldb
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// blobPrefix holds the prefix of the blob keyspace in DB.
+// Blobs are not models and must never be iterated by the model repository.
+const blobPrefix = "blob_"
+
+// levelDBBlobStore implements BlobStore using LevelDB as storage layer
+type levelDBBlobStore struct {
+	db *leveldb.DB
+}
+
+// NewLevelDBBlobStore returns levelDb implementation of BlobStore
+func NewLevelDBBlobStore(db *leveldb.DB) storage.BlobStore {
+	return levelDBBlobStore{db: db}
+}
+
+// getBlobKey returns blob_+key
+func getBlobKey(key []byte) []byte {
+	return append([]byte(blobPrefix), key...)
+}
+
+// Exists checks whether the blob exists in db
+func (l levelDBBlobStore) Exists(key []byte) bool {
+	res, err := l.db.Has(getBlobKey(key), nil)
+	if err != nil {
+		return false
+	}
+	return res
+}
+
+// Get retrieves the blob by key, otherwise returns error
+func (l levelDBBlobStore) Get(key []byte) ([]byte, error) {
+	data, err := l.db.Get(getBlobKey(key), nil)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	return data, nil
+}
+
+// Put stores the blob by key, replacing any existing blob
+func (l levelDBBlobStore) Put(key, data []byte) error {
+	err := l.db.Put(getBlobKey(key), data, nil)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
+
+// Delete deletes the blob by key
+func (l levelDBBlobStore) Delete(key []byte) error {
+	return l.db.Delete(getBlobKey(key), nil)
+}
@@ -0,0 +1,41 @@
+// +build unit
+
+package leveldb
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelDBBlobStore(t *testing.T) {
+	db, err := NewLevelDBStorage(GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	blobs := NewLevelDBBlobStore(db)
+	repo := NewLevelDBRepository(db)
+	key, data := utils.RandomSlice(32), utils.RandomSlice(1024)
+
+	// missing blob
+	assert.False(t, blobs.Exists(key))
+	_, err = blobs.Get(key)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
+
+	// put stores the raw bytes apart from the models
+	assert.NoError(t, blobs.Put(key, data))
+	assert.True(t, blobs.Exists(key))
+	assert.False(t, repo.Exists(key))
+	raw, err := db.Get(append([]byte(blobPrefix), key...), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, raw)
+	gdata, err := blobs.Get(key)
+	assert.NoError(t, err)
+	assert.Equal(t, data, gdata)
+
+	// delete
+	assert.NoError(t, blobs.Delete(key))
+	assert.False(t, blobs.Exists(key))
+}
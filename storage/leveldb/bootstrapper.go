@@ -34,5 +34,6 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 		return errors.New("failed to init level db: %v", err)
 	}
 	context[storage.BootstrappedDB] = NewLevelDBRepository(levelDB)
+	context[storage.BootstrappedBlobDB] = NewLevelDBBlobStore(levelDB)
 	return nil
 }
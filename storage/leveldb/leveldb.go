@@ -124,6 +124,36 @@ func (l *levelDBRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
 	return models, iter.Error()
 }
 
+// Iterate calls fn, in key order, for every model whose key matches the prefix.
+// Iteration starts at start if provided and stops when fn returns false.
+// If an error is found parsing one of the matched models, logs warning and continues
+func (l *levelDBRepo) Iterate(prefix string, start []byte, fn func(key []byte, model storage.Model) bool) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	rng := util.BytesPrefix([]byte(prefix))
+	if len(start) > 0 {
+		rng.Start = start
+	}
+
+	iter := l.db.NewIterator(rng, nil)
+	defer iter.Release()
+	for iter.Next() {
+		model, err := l.parseModel(iter.Value())
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			continue
+		}
+
+		// iterator reuses the key buffer
+		key := append([]byte(nil), iter.Key()...)
+		if !fn(key, model) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
 func (l *levelDBRepo) save(key []byte, model storage.Model) error {
 	data, err := model.JSON()
 	if err != nil {
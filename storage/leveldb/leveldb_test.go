@@ -129,6 +129,52 @@ func TestLevelDBRepo_GetAllByPrefix(t *testing.T) {
 	assert.Equal(t, 2, len(models))
 }
 
+func TestLevelDBRepo_Iterate(t *testing.T) {
+	prefix := "prefix-"
+	repo, _, err := getRandomRepository()
+	assert.Nil(t, err)
+	repo.Register(&doc{})
+
+	var keys [][]byte
+	collect := func(key []byte, model storage.Model) bool {
+		keys = append(keys, key)
+		return true
+	}
+
+	// No match
+	err = repo.Iterate(prefix, nil, collect)
+	assert.Nil(t, err)
+	assert.Len(t, keys, 0)
+
+	id1 := append([]byte(prefix), 0x1)
+	id2 := append([]byte(prefix), 0x2)
+	id3 := append([]byte(prefix), 0x3)
+	for _, id := range [][]byte{id1, id2, id3} {
+		assert.Nil(t, repo.Create(id, &doc{SomeString: "Hello, Repo!"}))
+	}
+	assert.Nil(t, repo.Create([]byte("other-prefix"), &doc{SomeString: "Hello, Repo!"}))
+
+	// all the keys in order
+	err = repo.Iterate(prefix, nil, collect)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{id1, id2, id3}, keys)
+
+	// start from id2
+	keys = nil
+	err = repo.Iterate(prefix, id2, collect)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{id2, id3}, keys)
+
+	// stop after first
+	keys = nil
+	err = repo.Iterate(prefix, nil, func(key []byte, model storage.Model) bool {
+		keys = append(keys, key)
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{id1}, keys)
+}
+
 func TestLevelDBRepo_Create(t *testing.T) {
 	repo, _, err := getRandomRepository()
 	assert.Nil(t, err)
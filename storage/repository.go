@@ -9,6 +9,8 @@ const (
 	BootstrappedDB string = "BootstrappedDB"
 	// BootstrappedConfigDB is a key mapped to DB for configs at boot
 	BootstrappedConfigDB string = "BootstrappedConfigDB"
+	// BootstrappedBlobDB is a key mapped to the BlobStore at boot
+	BootstrappedBlobDB string = "BootstrappedBlobDB"
 )
 
 // Model is an interface to abstract away storage model specificness
@@ -29,8 +31,21 @@ type Repository interface {
 	Exists(key []byte) bool
 	Get(key []byte) (Model, error)
 	GetAllByPrefix(prefix string) ([]Model, error)
+
+	// Iterate calls fn, in key order, for every model whose key matches the prefix.
+	// Iteration starts at start if provided and stops when fn returns false.
+	Iterate(prefix string, start []byte, fn func(key []byte, model Model) bool) error
 	Create(key []byte, model Model) error
 	Update(key []byte, model Model) error
 	Delete(key []byte) error
 	Close() error
 }
+
+// BlobStore stores raw bytes by key.
+// Blobs are kept apart from the models so that large contents are stored without any encoding.
+type BlobStore interface {
+	Exists(key []byte) bool
+	Get(key []byte) ([]byte, error)
+	Put(key, data []byte) error
+	Delete(key []byte) error
+}
@@ -0,0 +1,93 @@
+package templates
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// templatePrefix holds the prefix of a template in DB
+const templatePrefix string = "template_"
+
+// Repository defines the required methods for a template repository.
+type Repository interface {
+	// Get returns the template owned by accountID.
+	Get(accountID []byte, name string) (*Template, error)
+
+	// Create creates the template if not present in the DB.
+	Create(accountID []byte, template *Template) error
+
+	// Delete deletes the template owned by accountID.
+	Delete(accountID []byte, name string) error
+
+	// List returns all the templates owned by accountID.
+	List(accountID []byte) ([]*Template, error)
+}
+
+// NewRepository registers the Template model and returns an implementation of the Repository.
+func NewRepository(db storage.Repository) Repository {
+	db.Register(new(Template))
+	return &repo{db: db}
+}
+
+type repo struct {
+	db storage.Repository
+}
+
+// accountPrefix returns template_+accountID+_
+func accountPrefix(accountID []byte) string {
+	return templatePrefix + hexutil.Encode(accountID) + "_"
+}
+
+// getKey returns accountPrefix+name
+func getKey(accountID []byte, name string) []byte {
+	return []byte(accountPrefix(accountID) + name)
+}
+
+// Get returns the template owned by accountID.
+func (r *repo) Get(accountID []byte, name string) (*Template, error) {
+	m, err := r.db.Get(getKey(accountID, name))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrTemplateNotFound, err)
+	}
+
+	t, ok := m.(*Template)
+	if !ok {
+		return nil, errors.New("template %s for account %s is not a template object", name, hexutil.Encode(accountID))
+	}
+
+	return t, nil
+}
+
+// Create creates the template if not present in the DB.
+func (r *repo) Create(accountID []byte, template *Template) error {
+	key := getKey(accountID, template.Name)
+	if r.db.Exists(key) {
+		return ErrTemplateExists
+	}
+
+	return r.db.Create(key, template)
+}
+
+// Delete deletes the template owned by accountID.
+func (r *repo) Delete(accountID []byte, name string) error {
+	key := getKey(accountID, name)
+	if !r.db.Exists(key) {
+		return ErrTemplateNotFound
+	}
+
+	return r.db.Delete(key)
+}
+
+// List returns all the templates owned by accountID.
+func (r *repo) List(accountID []byte) (templates []*Template, err error) {
+	err = r.db.Iterate(accountPrefix(accountID), nil, func(key []byte, model storage.Model) bool {
+		if t, ok := model.(*Template); ok {
+			templates = append(templates, t)
+		}
+
+		return true
+	})
+
+	return templates, err
+}
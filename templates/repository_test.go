@@ -0,0 +1,96 @@
+// +build unit
+
+package templates
+
+import (
+	"os"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx map[string]interface{}
+var cfg config.Configuration
+var did = testingidentity.GenerateRandomDID()
+
+func TestMain(m *testing.M) {
+	ctx = make(map[string]interface{})
+	ibootstappers := []bootstrap.TestBootstrapper{
+		&testlogging.TestLoggingBootstrapper{},
+		&config.Bootstrapper{},
+		&leveldb.Bootstrapper{},
+	}
+	bootstrap.RunTestBootstrappers(ibootstappers, ctx)
+	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	cfg.Set("identityId", did.String())
+	cfg.Set("keys.p2p.publicKey", "../build/resources/p2pKey.pub.pem")
+	cfg.Set("keys.p2p.privateKey", "../build/resources/p2pKey.key.pem")
+	cfg.Set("keys.signing.publicKey", "../build/resources/signingKey.pub.pem")
+	cfg.Set("keys.signing.privateKey", "../build/resources/signingKey.key.pem")
+	result := m.Run()
+	bootstrap.RunTestTeardown(ibootstappers)
+	os.Exit(result)
+}
+
+func getRepository(ctx map[string]interface{}) Repository {
+	db := ctx[storage.BootstrappedDB].(storage.Repository)
+	return NewRepository(db)
+}
+
+func TestRepo_Create_Get_Delete(t *testing.T) {
+	repo := getRepository(ctx)
+	accID := utils.RandomSlice(20)
+	tmpl := testTemplate()
+
+	// missing
+	_, err := repo.Get(accID, tmpl.Name)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+	err = repo.Delete(accID, tmpl.Name)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+
+	// success
+	assert.NoError(t, repo.Create(accID, &tmpl))
+	gt, err := repo.Get(accID, tmpl.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, tmpl.Roles, gt.Roles)
+	assert.Equal(t, tmpl.Attributes, gt.Attributes)
+
+	// already exists
+	err = repo.Create(accID, &tmpl)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateExists, err))
+
+	// delete
+	assert.NoError(t, repo.Delete(accID, tmpl.Name))
+	_, err = repo.Get(accID, tmpl.Name)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+}
+
+func TestRepo_List(t *testing.T) {
+	repo := getRepository(ctx)
+	accID := utils.RandomSlice(20)
+	templates, err := repo.List(accID)
+	assert.NoError(t, err)
+	assert.Empty(t, templates)
+
+	for _, name := range []string{"invoice", "invoice.v2", "po"} {
+		assert.NoError(t, repo.Create(accID, &Template{Name: name, Scheme: "generic"}))
+	}
+
+	// another account
+	assert.NoError(t, repo.Create(utils.RandomSlice(20), &Template{Name: "invoice", Scheme: "generic"}))
+
+	templates, err = repo.List(accID)
+	assert.NoError(t, err)
+	assert.Len(t, templates, 3)
+}
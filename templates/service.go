@@ -0,0 +1,82 @@
+package templates
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+)
+
+// Service defines the functions to manage the document templates of an account.
+type Service interface {
+	// Create registers the template for the account.
+	Create(ctx context.Context, template Template) (*Template, error)
+
+	// Get returns the template registered by the account.
+	Get(ctx context.Context, name string) (*Template, error)
+
+	// List returns all the templates registered by the account.
+	List(ctx context.Context) ([]*Template, error)
+
+	// Delete removes the template registered by the account.
+	Delete(ctx context.Context, name string) error
+}
+
+type service struct {
+	repo Repository
+}
+
+// DefaultService returns the default implementation of the Service.
+func DefaultService(repo Repository) Service {
+	return service{repo: repo}
+}
+
+// Create registers the template for the account.
+func (s service) Create(ctx context.Context, template Template) (*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	if err := template.validateDefinition(); err != nil {
+		return nil, err
+	}
+
+	template.CreatedAt = time.Now().UTC()
+	err = s.repo.Create(did[:], &template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// Get returns the template registered by the account.
+func (s service) Get(ctx context.Context, name string) (*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.repo.Get(did[:], name)
+}
+
+// List returns all the templates registered by the account.
+func (s service) List(ctx context.Context) ([]*Template, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.repo.List(did[:])
+}
+
+// Delete removes the template registered by the account.
+func (s service) Delete(ctx context.Context, name string) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return contextutil.ErrDIDMissingFromContext
+	}
+
+	return s.repo.Delete(did[:], name)
+}
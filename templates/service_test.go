@@ -0,0 +1,65 @@
+// +build unit
+
+package templates
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService(t *testing.T) {
+	srv := DefaultService(getRepository(ctx))
+
+	// missing account
+	_, err := srv.Create(context.Background(), testTemplate())
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(contextutil.ErrDIDMissingFromContext, err))
+
+	// invalid template
+	actx := testingconfig.CreateAccountContext(t, cfg)
+	_, err = srv.Create(actx, Template{Name: "invalid name"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidTemplate, err))
+
+	// missing template
+	name := "template-" + did.String()
+	_, err = srv.Get(actx, name)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+
+	// success
+	tmpl := testTemplate()
+	tmpl.Name = name
+	ct, err := srv.Create(actx, tmpl)
+	assert.NoError(t, err)
+	assert.False(t, ct.CreatedAt.IsZero())
+
+	// already exists
+	_, err = srv.Create(actx, tmpl)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateExists, err))
+
+	gt, err := srv.Get(actx, name)
+	assert.NoError(t, err)
+	assert.Equal(t, tmpl.Roles, gt.Roles)
+
+	templates, err := srv.List(actx)
+	assert.NoError(t, err)
+	found := false
+	for _, tmpl := range templates {
+		if tmpl.Name == name {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	// delete
+	assert.NoError(t, srv.Delete(actx, name))
+	_, err = srv.Get(actx, name)
+	assert.True(t, errors.IsOfType(ErrTemplateNotFound, err))
+}
@@ -0,0 +1,357 @@
+package templates
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+)
+
+const (
+	// ErrTemplateNotFound must be used when the template is not registered for the account.
+	ErrTemplateNotFound = errors.Error("template not found")
+
+	// ErrTemplateExists must be used when a template with the same name is already registered for the account.
+	ErrTemplateExists = errors.Error("template already exists")
+
+	// ErrInvalidTemplate must be used when the template definition is invalid.
+	ErrInvalidTemplate = errors.Error("invalid template")
+
+	// ErrTemplateParams must be used when the parameters do not satisfy the template placeholders.
+	ErrTemplateParams = errors.Error("invalid template parameters")
+
+	// ErrTemplateSchemeMismatch must be used when the document scheme is different from the template scheme.
+	ErrTemplateSchemeMismatch = errors.Error("document scheme doesn't match the template scheme")
+)
+
+var (
+	nameRegex = regexp.MustCompile(`^[a-zA-Z0-9\-\.]+$`)
+
+	// placeholderRegex matches the placeholders of the form ${name}.
+	placeholderRegex = regexp.MustCompile(`\$\{([a-zA-Z0-9_\-\.]+)\}`)
+)
+
+// Role defines a role that is created on the document.
+type Role struct {
+	Key string `json:"key"`
+
+	// Collaborators are hex encoded DIDs or placeholders like ${buyer}.
+	Collaborators []string `json:"collaborators"`
+
+	// Validity optionally restricts the role to a time window.
+	Validity *documents.Validity `json:"validity,omitempty"`
+}
+
+// TransitionRule grants the role write access to the attribute.
+type TransitionRule struct {
+	RoleKey        string `json:"role_key"`
+	AttributeLabel string `json:"attribute_label"`
+}
+
+// ReadRule grants the role read access to the document.
+type ReadRule struct {
+	RoleKey string `json:"role_key"`
+
+	// Validity optionally restricts the read rule to a time window.
+	Validity *documents.Validity `json:"validity,omitempty"`
+}
+
+// Attribute defines the default value of an attribute.
+// Value can contain placeholders like ${amount}.
+type Attribute struct {
+	Label string                  `json:"label"`
+	Type  documents.AttributeType `json:"type" swaggertype:"primitive,string" enums:"integer,decimal,string,bytes,timestamp,monetary"`
+	Value string                  `json:"value"`
+
+	// Currency is the currency of the monetary attribute.
+	Currency string `json:"currency,omitempty"`
+}
+
+// Template captures the scheme, roles, rules and default attributes of a document.
+type Template struct {
+	Name            string           `json:"name"`
+	Scheme          string           `json:"scheme"`
+	Roles           []Role           `json:"roles"`
+	TransitionRules []TransitionRule `json:"transition_rules"`
+	ReadRules       []ReadRule       `json:"read_rules"`
+	Attributes      []Attribute      `json:"attributes"`
+	CreatedAt       time.Time        `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JSON returns json marshaled template.
+func (t *Template) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON loads the data into template.
+func (t *Template) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// Type returns the reflect.Type of the template.
+func (t *Template) Type() reflect.Type {
+	return reflect.TypeOf(t)
+}
+
+// hasPlaceholder returns true if the value contains at least one placeholder.
+func hasPlaceholder(v string) bool {
+	return placeholderRegex.MatchString(v)
+}
+
+// toDocumentAttribute converts the template attribute to document attribute.
+func (a Attribute) toDocumentAttribute() (documents.Attribute, error) {
+	if a.Type != documents.AttrMonetary {
+		return documents.NewStringAttribute(a.Label, a.Type, a.Value)
+	}
+
+	dec, err := documents.NewDecimal(a.Value)
+	if err != nil {
+		return documents.Attribute{}, err
+	}
+
+	return documents.NewMonetaryAttribute(a.Label, dec, nil, a.Currency)
+}
+
+// validateDefinition checks if the template is well defined.
+func (t Template) validateDefinition() (err error) {
+	if !nameRegex.MatchString(t.Name) {
+		err = errors.AppendError(err, errors.New("invalid template name: %s", t.Name))
+	}
+
+	if strings.TrimSpace(t.Scheme) == "" {
+		err = errors.AppendError(err, errors.New("scheme is required"))
+	}
+
+	roles := make(map[string]struct{})
+	for _, r := range t.Roles {
+		if strings.TrimSpace(r.Key) == "" {
+			err = errors.AppendError(err, errors.New("role key is required"))
+		}
+
+		if _, ok := roles[r.Key]; ok {
+			err = errors.AppendError(err, errors.New("duplicate role: %s", r.Key))
+		}
+		roles[r.Key] = struct{}{}
+
+		if len(r.Collaborators) < 1 {
+			err = errors.AppendError(err, errors.New("%s: role requires at least one collaborator", r.Key))
+		}
+
+		for _, c := range r.Collaborators {
+			if hasPlaceholder(c) {
+				continue
+			}
+
+			if _, derr := identity.NewDIDFromString(c); derr != nil {
+				err = errors.AppendError(err, errors.New("%s: invalid collaborator: %s", r.Key, c))
+			}
+		}
+
+		if r.Validity != nil {
+			if verr := r.Validity.Validate(); verr != nil {
+				err = errors.AppendError(err, errors.New("%s: %v", r.Key, verr))
+			}
+		}
+	}
+
+	for _, r := range t.TransitionRules {
+		if _, ok := roles[r.RoleKey]; !ok {
+			err = errors.AppendError(err, errors.New("transition rule: unknown role: %s", r.RoleKey))
+		}
+
+		if strings.TrimSpace(r.AttributeLabel) == "" {
+			err = errors.AppendError(err, errors.New("transition rule: attribute label is required"))
+		}
+	}
+
+	for _, r := range t.ReadRules {
+		if _, ok := roles[r.RoleKey]; !ok {
+			err = errors.AppendError(err, errors.New("read rule: unknown role: %s", r.RoleKey))
+		}
+
+		if r.Validity != nil {
+			if verr := r.Validity.Validate(); verr != nil {
+				err = errors.AppendError(err, errors.New("read rule: %s: %v", r.RoleKey, verr))
+			}
+		}
+	}
+
+	labels := make(map[string]struct{})
+	for _, a := range t.Attributes {
+		if _, ok := labels[a.Label]; ok {
+			err = errors.AppendError(err, errors.New("duplicate attribute label: %s", a.Label))
+		}
+		labels[a.Label] = struct{}{}
+
+		// values with placeholders are validated once the parameters are substituted
+		if hasPlaceholder(a.Value) {
+			continue
+		}
+
+		if _, aerr := a.toDocumentAttribute(); aerr != nil {
+			err = errors.AppendError(err, errors.New("%s: invalid attribute: %v", a.Label, aerr))
+		}
+	}
+
+	if err != nil {
+		return errors.NewTypedError(ErrInvalidTemplate, err)
+	}
+
+	return nil
+}
+
+// Params returns the sorted names of the placeholders used in the template.
+func (t Template) Params() []string {
+	pm := make(map[string]struct{})
+	collect := func(v string) {
+		for _, m := range placeholderRegex.FindAllStringSubmatch(v, -1) {
+			pm[m[1]] = struct{}{}
+		}
+	}
+
+	for _, r := range t.Roles {
+		for _, c := range r.Collaborators {
+			collect(c)
+		}
+	}
+
+	for _, a := range t.Attributes {
+		collect(a.Value)
+	}
+
+	var params []string
+	for p := range pm {
+		params = append(params, p)
+	}
+
+	sort.Strings(params)
+	return params
+}
+
+// Substitute returns a copy of the template with the placeholders replaced by the params.
+// Errors out if a placeholder doesn't have a param.
+func (t Template) Substitute(params map[string]string) (Template, error) {
+	var missing []string
+	for _, p := range t.Params() {
+		if _, ok := params[p]; !ok {
+			missing = append(missing, p)
+		}
+	}
+
+	if len(missing) > 0 {
+		return t, errors.NewTypedError(ErrTemplateParams, errors.New("missing parameters: %s", strings.Join(missing, ", ")))
+	}
+
+	replace := func(v string) string {
+		return placeholderRegex.ReplaceAllStringFunc(v, func(p string) string {
+			return params[placeholderRegex.FindStringSubmatch(p)[1]]
+		})
+	}
+
+	nt := t
+	nt.Roles = make([]Role, len(t.Roles))
+	for i, r := range t.Roles {
+		nr := Role{Key: r.Key, Validity: r.Validity}
+		for _, c := range r.Collaborators {
+			nr.Collaborators = append(nr.Collaborators, replace(c))
+		}
+		nt.Roles[i] = nr
+	}
+
+	nt.Attributes = make([]Attribute, len(t.Attributes))
+	for i, a := range t.Attributes {
+		a.Value = replace(a.Value)
+		nt.Attributes[i] = a
+	}
+
+	return nt, nil
+}
+
+// Prepare sets the template scheme on the payload and adds the default attributes.
+// Attributes present in the payload take precedence over the defaults.
+// Template is expected to be substituted already.
+func (t Template) Prepare(payload *documents.UpdatePayload) error {
+	if payload.Scheme != "" && payload.Scheme != t.Scheme {
+		return ErrTemplateSchemeMismatch
+	}
+	payload.Scheme = t.Scheme
+
+	if payload.Attributes == nil {
+		payload.Attributes = make(map[documents.AttrKey]documents.Attribute)
+	}
+
+	for _, a := range t.Attributes {
+		attr, err := a.toDocumentAttribute()
+		if err != nil {
+			return errors.NewTypedError(ErrTemplateParams, errors.New("%s: %v", a.Label, err))
+		}
+
+		if _, ok := payload.Attributes[attr.Key]; ok {
+			continue
+		}
+
+		payload.Attributes[attr.Key] = attr
+	}
+
+	return nil
+}
+
+// ApplyRules adds the roles, transition rules and read rules of the template to the document.
+// Template is expected to be substituted already.
+func (t Template) ApplyRules(doc documents.Model) error {
+	roleIDs := make(map[string][]byte)
+	for _, r := range t.Roles {
+		dids, err := identity.NewDIDsFromStrings(r.Collaborators)
+		if err != nil {
+			return errors.NewTypedError(ErrTemplateParams, errors.New("%s: invalid collaborators: %v", r.Key, err))
+		}
+
+		role, err := doc.AddRole(r.Key, dids)
+		if err != nil {
+			return err
+		}
+
+		if r.Validity != nil {
+			err = doc.SetRoleValidity(role.RoleKey, *r.Validity)
+			if err != nil {
+				return err
+			}
+		}
+
+		roleIDs[r.Key] = role.RoleKey
+	}
+
+	for _, r := range t.TransitionRules {
+		key, err := documents.AttrKeyFromLabel(r.AttributeLabel)
+		if err != nil {
+			return err
+		}
+
+		_, err = doc.AddTransitionRuleForAttribute(roleIDs[r.RoleKey], key)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, r := range t.ReadRules {
+		_, err := doc.AddReadRuleForRole(roleIDs[r.RoleKey])
+		if err != nil {
+			return err
+		}
+
+		if r.Validity != nil {
+			err = doc.SetReadRuleValidity(roleIDs[r.RoleKey], *r.Validity)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+// +build unit
+
+package templates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTemplate() Template {
+	return Template{
+		Name:   "purchase-order",
+		Scheme: "generic",
+		Roles: []Role{
+			{Key: "buyer", Collaborators: []string{"${buyer}"}},
+			{Key: "auditor", Collaborators: []string{did.String()}},
+		},
+		TransitionRules: []TransitionRule{{RoleKey: "buyer", AttributeLabel: "status"}},
+		ReadRules:       []ReadRule{{RoleKey: "auditor"}},
+		Attributes: []Attribute{
+			{Label: "status", Type: documents.AttrString, Value: "draft"},
+			{Label: "amount", Type: documents.AttrMonetary, Value: "${amount}", Currency: "USD"},
+		},
+	}
+}
+
+func TestTemplate_validateDefinition(t *testing.T) {
+	assert.NoError(t, testTemplate().validateDefinition())
+
+	tests := []func(tmpl *Template){
+		func(tmpl *Template) { tmpl.Name = "invalid name" },
+		func(tmpl *Template) { tmpl.Scheme = "" },
+		func(tmpl *Template) { tmpl.Roles[1].Key = "buyer" },
+		func(tmpl *Template) { tmpl.Roles[1].Key = "" },
+		func(tmpl *Template) { tmpl.Roles[0].Collaborators = nil },
+		func(tmpl *Template) { tmpl.Roles[1].Collaborators = []string{"0x1234"} },
+		func(tmpl *Template) { tmpl.TransitionRules[0].RoleKey = "seller" },
+		func(tmpl *Template) { tmpl.TransitionRules[0].AttributeLabel = "" },
+		func(tmpl *Template) { tmpl.ReadRules[0].RoleKey = "seller" },
+		func(tmpl *Template) { tmpl.Attributes[1].Label = "status" },
+		func(tmpl *Template) { tmpl.Attributes[0].Type = documents.AttrInt256 },
+		func(tmpl *Template) {
+			nb := time.Now()
+			na := nb.Add(-time.Hour)
+			tmpl.Roles[1].Validity = &documents.Validity{NotBefore: &nb, NotAfter: &na}
+		},
+	}
+
+	for _, c := range tests {
+		tmpl := testTemplate()
+		c(&tmpl)
+		err := tmpl.validateDefinition()
+		assert.Error(t, err)
+		assert.True(t, errors.IsOfType(ErrInvalidTemplate, err))
+	}
+}
+
+func TestTemplate_Substitute(t *testing.T) {
+	tmpl := testTemplate()
+	assert.Equal(t, []string{"amount", "buyer"}, tmpl.Params())
+
+	// missing params
+	_, err := tmpl.Substitute(map[string]string{"amount": "10"})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateParams, err))
+	assert.Contains(t, err.Error(), "buyer")
+
+	// success
+	buyer := testingidentity.GenerateRandomDID()
+	st, err := tmpl.Substitute(map[string]string{"amount": "10.5", "buyer": buyer.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{buyer.String()}, st.Roles[0].Collaborators)
+	assert.Equal(t, "10.5", st.Attributes[1].Value)
+	assert.Empty(t, st.Params())
+
+	// original is untouched
+	assert.Equal(t, "${buyer}", tmpl.Roles[0].Collaborators[0])
+	assert.Equal(t, "${amount}", tmpl.Attributes[1].Value)
+}
+
+func TestTemplate_Prepare(t *testing.T) {
+	tmpl, err := testTemplate().Substitute(map[string]string{"amount": "10", "buyer": did.String()})
+	assert.NoError(t, err)
+
+	// scheme mismatch
+	payload := documents.UpdatePayload{CreatePayload: documents.CreatePayload{Scheme: "entity"}}
+	err = tmpl.Prepare(&payload)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateSchemeMismatch, err))
+
+	// invalid substituted value
+	bt := testTemplate()
+	bt.Attributes[1].Value = "ten"
+	err = bt.Prepare(&documents.UpdatePayload{})
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateParams, err))
+
+	// payload attributes take precedence
+	status, err := documents.NewStringAttribute("status", documents.AttrString, "sent")
+	assert.NoError(t, err)
+	payload = documents.UpdatePayload{CreatePayload: documents.CreatePayload{
+		Attributes: map[documents.AttrKey]documents.Attribute{status.Key: status},
+	}}
+	assert.NoError(t, tmpl.Prepare(&payload))
+	assert.Equal(t, "generic", payload.Scheme)
+	assert.Len(t, payload.Attributes, 2)
+	assert.Equal(t, "sent", payload.Attributes[status.Key].Value.Str)
+	amount, err := documents.AttrKeyFromLabel("amount")
+	assert.NoError(t, err)
+	assert.Equal(t, documents.AttrMonetary, payload.Attributes[amount].Value.Type)
+}
+
+func TestTemplate_ApplyRules(t *testing.T) {
+	buyer := testingidentity.GenerateRandomDID()
+	tmpl, err := testTemplate().Substitute(map[string]string{"amount": "10", "buyer": buyer.String()})
+	assert.NoError(t, err)
+	buyerRole := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32)}
+	auditorRole := &coredocumentpb.Role{RoleKey: utils.RandomSlice(32)}
+	status, err := documents.AttrKeyFromLabel("status")
+	assert.NoError(t, err)
+
+	// invalid collaborator
+	bt := tmpl
+	bt.Roles = []Role{{Key: "buyer", Collaborators: []string{"0x12"}}}
+	err = bt.ApplyRules(new(documents.MockModel))
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrTemplateParams, err))
+
+	// failed to add role
+	doc := new(documents.MockModel)
+	doc.On("AddRole", "buyer", []identity.DID{buyer}).Return(nil, errors.New("failed")).Once()
+	assert.Error(t, tmpl.ApplyRules(doc))
+	doc.AssertExpectations(t)
+
+	// success
+	doc = new(documents.MockModel)
+	doc.On("AddRole", "buyer", []identity.DID{buyer}).Return(buyerRole, nil).Once()
+	doc.On("AddRole", "auditor", []identity.DID{did}).Return(auditorRole, nil).Once()
+	doc.On("AddTransitionRuleForAttribute", buyerRole.RoleKey, status).Return(new(coredocumentpb.TransitionRule), nil).Once()
+	doc.On("AddReadRuleForRole", auditorRole.RoleKey).Return(new(coredocumentpb.ReadRule), nil).Once()
+	assert.NoError(t, tmpl.ApplyRules(doc))
+	doc.AssertExpectations(t)
+
+	// success with validity windows
+	na := time.Now().UTC().Add(24 * time.Hour)
+	v := documents.Validity{NotAfter: &na}
+	vt := tmpl
+	vt.Roles = []Role{tmpl.Roles[0], {Key: tmpl.Roles[1].Key, Collaborators: tmpl.Roles[1].Collaborators, Validity: &v}}
+	vt.ReadRules = []ReadRule{{RoleKey: tmpl.ReadRules[0].RoleKey, Validity: &v}}
+	doc = new(documents.MockModel)
+	doc.On("AddRole", "buyer", []identity.DID{buyer}).Return(buyerRole, nil).Once()
+	doc.On("AddRole", "auditor", []identity.DID{did}).Return(auditorRole, nil).Once()
+	doc.On("SetRoleValidity", auditorRole.RoleKey, v).Return(nil).Once()
+	doc.On("AddTransitionRuleForAttribute", buyerRole.RoleKey, status).Return(new(coredocumentpb.TransitionRule), nil).Once()
+	doc.On("AddReadRuleForRole", auditorRole.RoleKey).Return(new(coredocumentpb.ReadRule), nil).Once()
+	doc.On("SetReadRuleValidity", auditorRole.RoleKey, v).Return(nil).Once()
+	assert.NoError(t, vt.ApplyRules(doc))
+	doc.AssertExpectations(t)
+}
@@ -0,0 +1,45 @@
+// +build integration unit
+
+package templates
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (b Bootstrapper) TestBootstrap(context map[string]interface{}) error {
+	return b.Bootstrap(context)
+}
+
+func (Bootstrapper) TestTearDown() error {
+	return nil
+}
+
+// MockService implements Service
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, template Template) (*Template, error) {
+	args := m.Called(ctx, template)
+	t, _ := args.Get(0).(*Template)
+	return t, args.Error(1)
+}
+
+func (m *MockService) Get(ctx context.Context, name string) (*Template, error) {
+	args := m.Called(ctx, name)
+	t, _ := args.Get(0).(*Template)
+	return t, args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context) ([]*Template, error) {
+	args := m.Called(ctx)
+	t, _ := args.Get(0).([]*Template)
+	return t, args.Error(1)
+}
+
+func (m *MockService) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
@@ -197,6 +197,16 @@ func (m *MockConfig) GetCentChainNodeURL() string {
 	return args.Get(0).(string)
 }
 
+func (m *MockConfig) GetPendingDocumentTTL() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetPendingDocumentSweepInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func CreateAccountContext(t *testing.T, cfg config.Configuration) context.Context {
 	return CreateTenantContextWithContext(t, context.Background(), cfg)
 }
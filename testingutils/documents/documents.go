@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
@@ -91,12 +93,87 @@ func (m *MockService) Commit(ctx context.Context, doc documents.Model) (jobs.Job
 	return jobID, args.Error(1)
 }
 
+func (m *MockService) Validate(ctx context.Context, model documents.Model, old documents.Model) error {
+	args := m.Called(ctx, model, old)
+	return args.Error(0)
+}
+
 func (m *MockService) Derive(ctx context.Context, payload documents.UpdatePayload) (documents.Model, error) {
 	args := m.Called(ctx, payload)
 	model, _ := args.Get(0).(documents.Model)
 	return model, args.Error(1)
 }
 
+func (m *MockService) ExportBundle(ctx context.Context, documentID, versionID []byte) ([]byte, error) {
+	args := m.Called(ctx, documentID, versionID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+func (m *MockService) ImportBundle(ctx context.Context, data []byte) (documents.Model, error) {
+	args := m.Called(ctx, data)
+	doc, _ := args.Get(0).(documents.Model)
+	return doc, args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context, filter documents.ListFilter) ([]documents.Model, []byte, error) {
+	args := m.Called(ctx, filter)
+	docs, _ := args.Get(0).([]documents.Model)
+	next, _ := args.Get(1).([]byte)
+	return docs, next, args.Error(2)
+}
+
+func (m *MockService) GetVersions(ctx context.Context, documentID, cursor []byte, limit int) ([]documents.VersionInfo, []byte, error) {
+	args := m.Called(ctx, documentID, cursor, limit)
+	versions, _ := args.Get(0).([]documents.VersionInfo)
+	next, _ := args.Get(1).([]byte)
+	return versions, next, args.Error(2)
+}
+
+func (m *MockService) GetForks(ctx context.Context, documentID []byte) ([]*documents.Fork, error) {
+	args := m.Called(ctx, documentID)
+	forks, _ := args.Get(0).([]*documents.Fork)
+	return forks, args.Error(1)
+}
+
+func (m *MockService) Rebase(ctx context.Context, documentID, versionID []byte) (documents.Rebase, error) {
+	args := m.Called(ctx, documentID, versionID)
+	rb, _ := args.Get(0).(documents.Rebase)
+	return rb, args.Error(1)
+}
+
+func (m *MockService) ListInbox(ctx context.Context, filter documents.InboxFilter) ([]*documents.InboxItem, []byte, error) {
+	args := m.Called(ctx, filter)
+	items, _ := args.Get(0).([]*documents.InboxItem)
+	next, _ := args.Get(1).([]byte)
+	return items, next, args.Error(2)
+}
+
+func (m *MockService) MarkInboxItemRead(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	args := m.Called(ctx, id)
+	item, _ := args.Get(0).(*documents.InboxItem)
+	return item, args.Error(1)
+}
+
+func (m *MockService) AckInboxItem(ctx context.Context, id []byte) (*documents.InboxItem, error) {
+	args := m.Called(ctx, id)
+	item, _ := args.Get(0).(*documents.InboxItem)
+	return item, args.Error(1)
+}
+
+func (m *MockService) DryRun(ctx context.Context, model documents.Model) ([]documents.ValidationFailure, error) {
+	args := m.Called(ctx, model)
+	failures, _ := args.Get(0).([]documents.ValidationFailure)
+	return failures, args.Error(1)
+}
+
+func (m *MockService) RetryCommit(ctx context.Context, documentID []byte) (documents.Model, jobs.JobID, error) {
+	args := m.Called(ctx, documentID)
+	doc, _ := args.Get(0).(documents.Model)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	return doc, jobID, args.Error(2)
+}
+
 type MockModel struct {
 	documents.Model
 	mock.Mock
@@ -187,6 +264,11 @@ func (m *MockModel) IsDIDCollaborator(did identity.DID) (bool, error) {
 	return ok, args.Error(1)
 }
 
+func (m *MockModel) AccountCanRead(account identity.DID) bool {
+	args := m.Called(account)
+	return args.Bool(0)
+}
+
 func (m *MockModel) GetAccessTokens() ([]*coredocumentpb.AccessToken, error) {
 	args := m.Called()
 	ac, _ := args.Get(0).([]*coredocumentpb.AccessToken)
@@ -230,3 +312,56 @@ func (m MockRegistry) OwnerOf(registry common.Address, tokenID []byte) (common.A
 	addr, _ := args.Get(0).(common.Address)
 	return addr, args.Error(1)
 }
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Exists(hash []byte) bool {
+	args := m.Called(hash)
+	return args.Bool(0)
+}
+
+func (m *MockAttachmentRepository) Get(hash []byte) ([]byte, error) {
+	args := m.Called(hash)
+	content, _ := args.Get(0).([]byte)
+	return content, args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Create(content []byte) ([]byte, error) {
+	args := m.Called(content)
+	hash, _ := args.Get(0).([]byte)
+	return hash, args.Error(1)
+}
+
+type MockP2PClient struct {
+	documents.Client
+	mock.Mock
+}
+
+func (m *MockP2PClient) GetAttachmentRequest(ctx context.Context, requesterID identity.DID, in *p2pcommon.GetAttachmentRequest) (*p2pcommon.GetAttachmentResponse, error) {
+	args := m.Called(ctx, requesterID, in)
+	resp, _ := args.Get(0).(*p2pcommon.GetAttachmentResponse)
+	return resp, args.Error(1)
+}
+
+type MockRequestProcessor struct {
+	mock.Mock
+}
+
+func (m *MockRequestProcessor) RequestDocumentWithAccessToken(ctx context.Context, granterDID identity.DID, tokenIdentifier,
+	documentIdentifier, delegatingDocumentIdentifier []byte) (*p2ppb.GetDocumentResponse, error) {
+	args := m.Called(granterDID, tokenIdentifier, documentIdentifier, delegatingDocumentIdentifier)
+	resp, _ := args.Get(0).(*p2ppb.GetDocumentResponse)
+	return resp, args.Error(1)
+}
+
+func (m *MockRequestProcessor) RequestApprovals(ctx context.Context, model documents.Model, approvers []identity.DID) error {
+	args := m.Called(model, approvers)
+	return args.Error(0)
+}
+
+func (m *MockRequestProcessor) SendApproval(ctx context.Context, owner identity.DID, model documents.Model) error {
+	args := m.Called(owner, model)
+	return args.Error(0)
+}
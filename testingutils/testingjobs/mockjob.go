@@ -30,3 +30,14 @@ func (m MockJobManager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID,
 	args := m.Called(accountID, id, status, taskName, message)
 	return args.Error(0)
 }
+
+func (m MockJobManager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key string, value []byte) error {
+	args := m.Called(accountID, id, key, value)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	args := m.Called(accountID, id)
+	job, _ := args.Get(0).(*jobs.Job)
+	return job, args.Error(1)
+}